@@ -0,0 +1,220 @@
+package goaliniex
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	bech32Charset  = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+	base58CheckLength   = 25
+	base58ChecksumSize  = 4
+	tronAddressVersion  = 0x41
+	btcP2PKHVersion     = 0x00
+	btcP2SHVersion      = 0x05
+	evmAddressHexLength = 40
+	// maxBase58Length bounds decodeBase58 input, which takes quadratic time.
+	// A 25-byte base58check address is at most 35 characters.
+	maxBase58Length = 50
+
+	bech32Const         = 1
+	bech32mConst        = 0x2bc830a3
+	bech32ChecksumSize  = 6
+	bech32MaxLength     = 90
+	bitcoinHRP          = "bc"
+	maxWitnessVersion   = 16
+	minWitnessProgram   = 2
+	maxWitnessProgram   = 40
+	witnessV0KeyHash    = 20
+	witnessV0ScriptHash = 32
+)
+
+// decodeBase58Check decodes a base58check string into its version byte and
+// payload, verifying the double SHA-256 checksum.
+func decodeBase58Check(s string) (byte, []byte, bool) {
+	decoded, ok := decodeBase58(s)
+	if !ok || len(decoded) != base58CheckLength {
+		return 0, nil, false
+	}
+
+	body, checksum := decoded[:len(decoded)-base58ChecksumSize], decoded[len(decoded)-base58ChecksumSize:]
+	first := sha256.Sum256(body)
+	second := sha256.Sum256(first[:])
+
+	if !bytes.Equal(second[:base58ChecksumSize], checksum) {
+		return 0, nil, false
+	}
+
+	return body[0], body[1:], true
+}
+
+func decodeBase58(s string) ([]byte, bool) {
+	if s == "" || len(s) > maxBase58Length {
+		return nil, false
+	}
+
+	var decoded []byte // big-endian
+
+	for i := range len(s) {
+		carry := strings.IndexByte(base58Alphabet, s[i])
+		if carry < 0 {
+			return nil, false
+		}
+
+		for j := len(decoded) - 1; j >= 0; j-- {
+			carry += int(decoded[j]) * len(base58Alphabet)
+			decoded[j] = byte(carry)
+			carry >>= 8
+		}
+
+		for ; carry > 0; carry >>= 8 {
+			decoded = append([]byte{byte(carry)}, decoded...)
+		}
+	}
+
+	leadingZeros := len(s) - len(strings.TrimLeft(s, base58Alphabet[:1]))
+
+	return append(make([]byte, leadingZeros), decoded...), true
+}
+
+// isEVMAddress checks a 0x-prefixed address. Mixed-case addresses must carry
+// a valid EIP-55 checksum; all-lower or all-upper ones have none to check.
+func isEVMAddress(address string) bool {
+	hexPart, found := strings.CutPrefix(address, "0x")
+	if !found || len(hexPart) != evmAddressHexLength {
+		return false
+	}
+
+	if _, err := hex.DecodeString(hexPart); err != nil {
+		return false
+	}
+
+	lower := strings.ToLower(hexPart)
+	if hexPart == lower || hexPart == strings.ToUpper(hexPart) {
+		return true
+	}
+
+	return hexPart == eip55Checksum(lower)
+}
+
+// eip55Checksum capitalises the letters of a lowercase hex address whose
+// nibble in the Keccak-256 hash of the address is 8 or more.
+func eip55Checksum(lowerHex string) string {
+	hash := keccak256([]byte(lowerHex))
+	out := []byte(lowerHex)
+
+	for i, c := range out {
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0x0f
+		}
+
+		if c >= 'a' && c <= 'f' && nibble >= 8 {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+
+	return string(out)
+}
+
+// ToChecksumAddress returns address in EIP-55 mixed case.
+func ToChecksumAddress(address string) (string, error) {
+	if !isEVMAddress(address) {
+		return "", ErrInvalidWalletAddress
+	}
+
+	return "0x" + eip55Checksum(strings.ToLower(address[2:])), nil
+}
+
+// isSegwitAddress checks a bc1 address: bech32 for witness version 0 and
+// bech32m for later versions, per BIP 173 and BIP 350.
+func isSegwitAddress(address string) bool {
+	lower := strings.ToLower(address)
+	if len(address) > bech32MaxLength || (address != lower && address != strings.ToUpper(address)) {
+		return false
+	}
+
+	separator := strings.LastIndexByte(lower, '1')
+	if lower[:max(separator, 0)] != bitcoinHRP || len(lower)-separator-1 < bech32ChecksumSize+1 {
+		return false
+	}
+
+	data := make([]byte, 0, len(lower)-separator-1)
+
+	for i := separator + 1; i < len(lower); i++ {
+		value := strings.IndexByte(bech32Charset, lower[i])
+		if value < 0 {
+			return false
+		}
+
+		data = append(data, byte(value))
+	}
+
+	version := data[0]
+	program, ok := convertBits(data[1 : len(data)-bech32ChecksumSize])
+
+	switch {
+	case !ok, version > maxWitnessVersion, len(program) < minWitnessProgram, len(program) > maxWitnessProgram:
+		return false
+	case version == 0:
+		return (len(program) == witnessV0KeyHash || len(program) == witnessV0ScriptHash) &&
+			bech32Polymod(bitcoinHRP, data) == bech32Const
+	default:
+		return bech32Polymod(bitcoinHRP, data) == bech32mConst
+	}
+}
+
+func bech32Polymod(hrp string, data []byte) uint32 {
+	generators := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	checksum := uint32(1)
+
+	values := make([]byte, 0, len(hrp)*2+1+len(data))
+	for i := range len(hrp) {
+		values = append(values, hrp[i]>>5)
+	}
+
+	values = append(values, 0)
+	for i := range len(hrp) {
+		values = append(values, hrp[i]&31)
+	}
+
+	for _, value := range append(values, data...) {
+		top := checksum >> 25
+		checksum = (checksum&0x1ffffff)<<5 ^ uint32(value)
+
+		for i, generator := range generators {
+			if (top>>i)&1 == 1 {
+				checksum ^= generator
+			}
+		}
+	}
+
+	return checksum
+}
+
+// convertBits regroups 5-bit values into bytes, rejecting non-zero padding.
+func convertBits(data []byte) ([]byte, bool) {
+	var (
+		acc  uint32
+		bits uint
+		out  []byte
+	)
+
+	for _, value := range data {
+		acc = acc<<5 | uint32(value)
+		bits += 5
+
+		for bits >= 8 {
+			bits -= 8
+			out = append(out, byte(acc>>bits))
+		}
+
+		acc &= 1<<bits - 1
+	}
+
+	return out, bits < 5 && acc == 0
+}
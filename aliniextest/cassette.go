@@ -0,0 +1,413 @@
+package aliniextest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/andyle182810/goaliniex"
+)
+
+// Redacted replaces scrubbed values in cassettes.
+const Redacted = "REDACTED"
+
+// minScrubLength keeps short values such as "1" from being scrubbed out of
+// unrelated text.
+const minScrubLength = 4
+
+const cassetteFileMode = 0o600
+
+var (
+	ErrNoInteraction = errors.New("aliniextest: no recorded interaction matches request")
+	ErrInvalidMode   = errors.New("aliniextest: invalid recorder mode")
+)
+
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette and never touches the
+	// network.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests upstream and writes the exchanges to the
+	// cassette on Stop.
+	ModeRecord
+)
+
+// defaultRedactedFields are scrubbed from request and response bodies:
+// credentials, signatures and personal data.
+func defaultRedactedFields() []string {
+	return []string{
+		"partnerCode", "signature", "webhookSecretKey",
+		"userEmail", "email", "firstName", "lastName", "dateOfBirth", "nationalId",
+		"phoneNumber", "address", "addressLine1", "addressLine2",
+		"frontIdImage", "backIdImage", "holdIdImage",
+		"bankAccountNumber", "bankAccountName",
+	}
+}
+
+type RecorderOptions struct {
+	Mode Mode
+	// HTTPClient sends requests in ModeRecord, http.DefaultClient by default.
+	HTTPClient goaliniex.HTTPClient
+	// Redact adds JSON keys whose string values are scrubbed.
+	Redact []string
+	// Secrets are literal strings scrubbed wherever they appear, such as
+	// the secret key.
+	Secrets []string
+	// IgnoreFields are request body keys left out of matching, for values
+	// that change between runs such as generated order IDs.
+	IgnoreFields []string
+	// Source is written to the cassette in ModeRecord to say where the
+	// exchanges came from, such as the sandbox URL or SourceFakeServer.
+	Source string
+}
+
+// SourceFakeServer marks cassettes recorded against this package's Server
+// rather than the real API.
+const SourceFakeServer = "aliniextest"
+
+// Cassette is the file format of recorded exchanges.
+type Cassette struct {
+	// Source is where the exchanges were recorded; see RecorderOptions.
+	Source       string        `json:"source,omitempty"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Query  string          `json:"query,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode  int             `json:"statusCode"`
+	ContentType string          `json:"contentType,omitempty"`
+	Body        json.RawMessage `json:"body,omitempty"`
+	// Text holds a body that is not JSON.
+	Text string `json:"text,omitempty"`
+}
+
+// Recorder is a goaliniex.HTTPClient that records exchanges to a cassette
+// file or replays them. Requests match on method, path, query and body with
+// scrubbed and ignored fields removed; each recorded exchange is replayed
+// once, in order.
+type Recorder struct {
+	path         string
+	mode         Mode
+	next         goaliniex.HTTPClient
+	redact       map[string]struct{}
+	secrets      []string
+	ignoreFields []string
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// NewRecorder opens the cassette at path. In ModeReplay the file must
+// exist; the error wraps os.ErrNotExist when it does not.
+func NewRecorder(path string, opts *RecorderOptions) (*Recorder, error) {
+	if opts == nil {
+		opts = &RecorderOptions{} //nolint:exhaustruct // defaults
+	}
+
+	recorder := &Recorder{
+		path:         path,
+		mode:         opts.Mode,
+		next:         opts.HTTPClient,
+		redact:       map[string]struct{}{},
+		secrets:      nil,
+		ignoreFields: opts.IgnoreFields,
+		mu:           sync.Mutex{},
+		cassette:     Cassette{Source: opts.Source, Interactions: nil},
+		used:         nil,
+	}
+
+	if recorder.next == nil {
+		recorder.next = http.DefaultClient
+	}
+
+	for _, field := range append(defaultRedactedFields(), opts.Redact...) {
+		recorder.redact[field] = struct{}{}
+	}
+
+	for _, secret := range opts.Secrets {
+		if len(secret) >= minScrubLength {
+			recorder.secrets = append(recorder.secrets, secret)
+		}
+	}
+
+	switch opts.Mode {
+	case ModeRecord:
+		return recorder, nil
+	case ModeReplay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("aliniextest: read cassette: %w", err)
+		}
+
+		if err := json.Unmarshal(data, &recorder.cassette); err != nil {
+			return nil, fmt.Errorf("aliniextest: decode cassette %s: %w", path, err)
+		}
+
+		recorder.used = make([]bool, len(recorder.cassette.Interactions))
+
+		return recorder, nil
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrInvalidMode, opts.Mode)
+	}
+}
+
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	var body []byte
+
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		_ = req.Body.Close()
+		body = data
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	scrubbed, values := r.scrubJSON(body)
+	recorded := RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  normalizeQuery(req.URL.RawQuery),
+		Body:   scrubbed,
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	return r.record(req, recorded, values)
+}
+
+// Stop writes the cassette in ModeRecord. It is a no-op when replaying.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil { //nolint:mnd // directory permissions
+		return err
+	}
+
+	return os.WriteFile(r.path, append(data, '\n'), cassetteFileMode)
+}
+
+// Cassette returns a copy of the recorded exchanges.
+func (r *Recorder) Cassette() Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return Cassette{Source: r.cassette.Source, Interactions: slices.Clone(r.cassette.Interactions)}
+}
+
+func (r *Recorder) record(req *http.Request, recorded RecordedRequest, values []string) (*http.Response, error) {
+	resp, err := r.next.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	response := RecordedResponse{
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        nil,
+		Text:        "",
+	}
+
+	text := scrubText(string(body), append(values, r.secrets...))
+	if json.Valid([]byte(text)) {
+		response.Body, _ = r.scrubJSON([]byte(text))
+	} else {
+		response.Text = text
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{Request: recorded, Response: response})
+	r.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	key := r.matchKey(recorded)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || r.matchKey(interaction.Request) != key {
+			continue
+		}
+
+		r.used[i] = true
+
+		return replayResponse(req, interaction.Response), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, recorded.Method, recorded.Path)
+}
+
+func replayResponse(req *http.Request, recorded RecordedResponse) *http.Response {
+	body := []byte(recorded.Text)
+	if len(recorded.Body) > 0 {
+		body = recorded.Body
+	}
+
+	header := http.Header{}
+	if recorded.ContentType != "" {
+		header.Set("Content-Type", recorded.ContentType)
+	}
+
+	return &http.Response{
+		Status:           fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:       recorded.StatusCode,
+		Proto:            "HTTP/1.1",
+		ProtoMajor:       1,
+		ProtoMinor:       1,
+		Header:           header,
+		Body:             io.NopCloser(bytes.NewReader(body)),
+		ContentLength:    int64(len(body)),
+		TransferEncoding: nil,
+		Close:            false,
+		Uncompressed:     false,
+		Trailer:          nil,
+		Request:          req,
+		TLS:              nil,
+	}
+}
+
+// matchKey identifies a request for matching, ignoring key order and
+// IgnoreFields.
+func (r *Recorder) matchKey(req RecordedRequest) string {
+	body := string(req.Body)
+
+	var fields map[string]any
+	if err := decodeJSON(req.Body, &fields); err == nil && fields != nil {
+		for _, field := range r.ignoreFields {
+			delete(fields, field)
+		}
+
+		normalized, _ := json.Marshal(fields) //nolint:errchkjson // decoded JSON
+		body = string(normalized)
+	}
+
+	return req.Method + " " + req.Path + "?" + req.Query + " " + body
+}
+
+// scrubJSON redacts configured keys in a JSON body and returns it compacted
+// along with the string values removed. Other bodies only have secrets
+// scrubbed.
+func (r *Recorder) scrubJSON(body []byte) (json.RawMessage, []string) {
+	if len(body) == 0 {
+		return nil, nil
+	}
+
+	var value any
+	if err := decodeJSON(body, &value); err != nil {
+		text, _ := json.Marshal(scrubText(string(body), r.secrets)) //nolint:errchkjson // string
+
+		return text, nil
+	}
+
+	var values []string
+
+	value = r.redactValue(value, &values)
+
+	encoded, _ := json.Marshal(value) //nolint:errchkjson // decoded JSON
+
+	return json.RawMessage(scrubText(string(encoded), r.secrets)), values
+}
+
+func (r *Recorder) redactValue(value any, values *[]string) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		for key, field := range typed {
+			text, isString := field.(string)
+
+			if _, redact := r.redact[key]; redact && isString && text != "" {
+				if len(text) >= minScrubLength {
+					*values = append(*values, text)
+				}
+
+				typed[key] = Redacted
+
+				continue
+			}
+
+			typed[key] = r.redactValue(field, values)
+		}
+	case []any:
+		for i, item := range typed {
+			typed[i] = r.redactValue(item, values)
+		}
+	}
+
+	return value
+}
+
+func decodeJSON(data []byte, dest any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	return decoder.Decode(dest)
+}
+
+// scrubText replaces every occurrence of values, longest first.
+func scrubText(text string, values []string) string {
+	sorted := slices.Clone(values)
+	slices.SortFunc(sorted, func(a, b string) int { return len(b) - len(a) })
+
+	for _, value := range sorted {
+		text = strings.ReplaceAll(text, value, Redacted)
+	}
+
+	return text
+}
+
+func normalizeQuery(rawQuery string) string {
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return rawQuery
+	}
+
+	return values.Encode()
+}
@@ -0,0 +1,198 @@
+package aliniextest_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andyle182810/goaliniex"
+	"github.com/andyle182810/goaliniex/aliniextest"
+)
+
+func recordFakeSession(t *testing.T, path string) {
+	t.Helper()
+
+	privateKey, publicKey, err := aliniextest.GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %v", err)
+	}
+
+	server, err := aliniextest.NewServer(aliniextest.Config{
+		PartnerCode: testPartner,
+		SecretKey:   testSecret,
+		PublicKey:   publicKey,
+		Algorithm:   "",
+		ResponseKey: nil,
+		Balances:    map[goaliniex.Currency]float64{goaliniex.CurrencyUSDT: 25},
+		OrderTTL:    0,
+		Now:         nil,
+	})
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+
+	t.Cleanup(server.Close)
+
+	recorder, err := aliniextest.NewRecorder(path, &aliniextest.RecorderOptions{
+		Mode:         aliniextest.ModeRecord,
+		HTTPClient:   nil,
+		Redact:       nil,
+		Secrets:      []string{testSecret},
+		IgnoreFields: nil,
+		Source:       aliniextest.SourceFakeServer,
+	})
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	client, err := goaliniex.NewClient(server.URL, testPartner, testSecret, privateKey, goaliniex.WithHTTPClient(recorder))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	ctx := context.Background()
+
+	if _, err := client.SubmitKyc(ctx, kycRequest("jane.recorded@example.com")); err != nil {
+		t.Fatalf("SubmitKyc: %v", err)
+	}
+
+	missing, err := client.GetKyc(ctx, &goaliniex.KycInformationRequest{UserEmail: "nobody@example.com"})
+	if err != nil || missing.Success {
+		t.Fatalf("GetKyc: %+v, %v", missing, err)
+	}
+
+	if _, err := client.GetWalletBalance(ctx, &goaliniex.GetWalletBalanceRequest{Currency: goaliniex.CurrencyUSDT}); err != nil {
+		t.Fatalf("GetWalletBalance: %v", err)
+	}
+
+	if err := recorder.Stop(); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+}
+
+func newReplayClient(t *testing.T, path string, ignore ...string) *goaliniex.Client {
+	t.Helper()
+
+	recorder, err := aliniextest.NewRecorder(path, &aliniextest.RecorderOptions{
+		Mode:         aliniextest.ModeReplay,
+		HTTPClient:   nil,
+		Redact:       nil,
+		Secrets:      nil,
+		IgnoreFields: ignore,
+		Source:       "",
+	})
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	privateKey, _, err := aliniextest.GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %v", err)
+	}
+
+	// Replays need no real credentials: partner code and signature are
+	// scrubbed before matching.
+	client, err := goaliniex.NewClient("https://replay.invalid", "OTHER_PARTNER", "OTHER_SECRET", privateKey,
+		goaliniex.WithHTTPClient(recorder))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	return client
+}
+
+func TestRecorder_ScrubsCassette(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "session.json")
+	recordFakeSession(t, path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read cassette: %v", err)
+	}
+
+	cassette := string(data)
+
+	for _, secret := range []string{testPartner, testSecret, "jane.recorded@example.com", "Smith", "987654321", testImage} {
+		if strings.Contains(cassette, secret) {
+			t.Errorf("cassette contains %q", secret)
+		}
+	}
+
+	for _, kept := range []string{
+		"/api/v2/user/submit-kyc", "User not found", `"balance": 25`, aliniextest.Redacted, `"source": "aliniextest"`,
+	} {
+		if !strings.Contains(cassette, kept) {
+			t.Errorf("cassette is missing %q", kept)
+		}
+	}
+}
+
+func TestRecorder_Replay(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	recordFakeSession(t, path)
+
+	client := newReplayClient(t, path)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	// Replays are matched by content, not by order.
+	balance, err := client.GetWalletBalance(ctx, &goaliniex.GetWalletBalanceRequest{Currency: goaliniex.CurrencyUSDT})
+	if err != nil || !balance.Success || balance.Data.Balance != 25 {
+		t.Fatalf("GetWalletBalance: %+v, %v", balance, err)
+	}
+
+	submitted, err := client.SubmitKyc(ctx, kycRequest("someone.else@example.com"))
+	if err != nil || !submitted.Success {
+		t.Fatalf("SubmitKyc: %+v, %v", submitted, err)
+	}
+
+	missing, err := client.GetKyc(ctx, &goaliniex.KycInformationRequest{UserEmail: "whoever@example.com"})
+	if err != nil || missing.Success || missing.ErrorCode != aliniextest.ErrorCodeNotFound {
+		t.Fatalf("GetKyc: %+v, %v", missing, err)
+	}
+
+	// Each interaction replays once.
+	_, err = client.GetWalletBalance(ctx, &goaliniex.GetWalletBalanceRequest{Currency: goaliniex.CurrencyUSDT})
+	if !errors.Is(err, aliniextest.ErrNoInteraction) {
+		t.Errorf("expected ErrNoInteraction, got %v", err)
+	}
+}
+
+func TestRecorder_ReplayMismatch(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	recordFakeSession(t, path)
+
+	client := newReplayClient(t, path)
+
+	_, err := client.GetWalletBalance(context.Background(), &goaliniex.GetWalletBalanceRequest{Currency: goaliniex.CurrencyBTC})
+	if !errors.Is(err, aliniextest.ErrNoInteraction) {
+		t.Errorf("expected ErrNoInteraction, got %v", err)
+	}
+
+	client = newReplayClient(t, path, "currency")
+
+	balance, err := client.GetWalletBalance(context.Background(), &goaliniex.GetWalletBalanceRequest{Currency: "DOGE"})
+	if err != nil || !balance.Success {
+		t.Errorf("expected ignored field to match, got %+v, %v", balance, err)
+	}
+}
+
+func TestNewRecorder_MissingCassette(t *testing.T) {
+	t.Parallel()
+
+	_, err := aliniextest.NewRecorder(filepath.Join(t.TempDir(), "missing.json"), nil)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected os.ErrNotExist, got %v", err)
+	}
+}
@@ -0,0 +1,513 @@
+package aliniextest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/andyle182810/goaliniex"
+	"github.com/andyle182810/goaliniex/experimental"
+	"github.com/andyle182810/goaliniex/signer"
+)
+
+const successMessage = "Success"
+
+// apiError is a failed response: success false with a message and code.
+type apiError struct {
+	code    int
+	message string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s (errorCode=%d)", e.message, e.code)
+}
+
+func failure(code int, format string, args ...any) *apiError {
+	return &apiError{code: code, message: fmt.Sprintf(format, args...)}
+}
+
+type envelope struct {
+	Success   bool   `json:"success"`
+	Message   string `json:"message"`
+	Data      any    `json:"data"`
+	ErrorCode int    `json:"errorCode"`
+}
+
+type handlerFunc func(r *http.Request, body []byte) (any, error)
+
+// handle wraps an endpoint with fault injection, signature verification for
+// signed endpoints and the response envelope.
+func (s *Server) handle(endpoint string, next handlerFunc) http.HandlerFunc {
+	fields, signed := signedFields()[endpoint]
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if fault, ok := s.takeFault(endpoint); ok {
+			writeFault(w, fault)
+
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, envelope{
+				Success: false, Message: err.Error(), Data: nil, ErrorCode: ErrorCodeBadRequest,
+			})
+
+			return
+		}
+
+		if signed {
+			if apiErr := s.verifyRequest(fields, body); apiErr != nil {
+				writeError(w, apiErr)
+
+				return
+			}
+		}
+
+		data, err := next(r, body)
+		if err != nil {
+			var apiErr *apiError
+			if !errors.As(err, &apiErr) {
+				apiErr = failure(ErrorCodeBadRequest, "%s", err)
+			}
+
+			writeError(w, apiErr)
+
+			return
+		}
+
+		writeJSON(w, http.StatusOK, envelope{Success: true, Message: successMessage, Data: data, ErrorCode: 0})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, apiErr *apiError) {
+	writeJSON(w, http.StatusOK, envelope{Success: false, Message: apiErr.message, Data: nil, ErrorCode: apiErr.code})
+}
+
+func writeFault(w http.ResponseWriter, fault Fault) {
+	status := http.StatusOK
+	if fault.StatusCode >= http.StatusBadRequest {
+		status = fault.StatusCode
+	}
+
+	message := fault.Message
+	if message == "" {
+		message = "injected failure"
+	}
+
+	writeJSON(w, status, envelope{Success: false, Message: message, Data: nil, ErrorCode: fault.ErrorCode})
+}
+
+func (s *Server) verifyRequest(payloadFields []string, body []byte) *apiError {
+	var fields map[string]any
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return failure(ErrorCodeBadRequest, "invalid request body: %s", err)
+	}
+
+	if partnerCode, _ := fields["partnerCode"].(string); partnerCode != s.cfg.PartnerCode {
+		return failure(ErrorCodeInvalidSignature, "Invalid partner code")
+	}
+
+	signature, _ := fields["signature"].(string)
+	if signature == "" {
+		return failure(ErrorCodeInvalidSignature, "Missing signature")
+	}
+
+	payload, err := expectedPayload(payloadFields, s.cfg.SecretKey, body)
+	if err != nil {
+		return failure(ErrorCodeBadRequest, "invalid request body: %s", err)
+	}
+
+	if err := signer.VerifyWithAlgorithm(s.cfg.Algorithm, s.cfg.PublicKey, []byte(payload), signature); err != nil {
+		return failure(ErrorCodeInvalidSignature, "Invalid signature")
+	}
+
+	return nil
+}
+
+// signResponse returns the signature for data when a response key is set.
+func (s *Server) signResponse(op goaliniex.Operation, data any) (string, error) {
+	spec, ok := goaliniex.ResponseSignatureSpec(op)
+	if !ok || len(s.cfg.ResponseKey) == 0 {
+		return "", nil
+	}
+
+	payload, err := spec.Payload(s.cfg.PartnerCode, s.cfg.SecretKey, data)
+	if err != nil {
+		return "", err
+	}
+
+	return signer.SignWithAlgorithm(s.cfg.Algorithm, s.cfg.ResponseKey, []byte(payload))
+}
+
+func (s *Server) signedOrder(op goaliniex.Operation, order goaliniex.OrderDetails) (*goaliniex.OrderDetails, error) {
+	signature, err := s.signResponse(op, &order)
+	if err != nil {
+		return nil, err
+	}
+
+	order.Signature = signature
+
+	return &order, nil
+}
+
+func (s *Server) createSellOrder(_ *http.Request, body []byte) (any, error) {
+	var req goaliniex.CreateOrderRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, failure(ErrorCodeBadRequest, "invalid request body: %s", err)
+	}
+
+	if req.BankCode == "" || req.BankAccountNumber == "" {
+		return nil, failure(ErrorCodeBadRequest, "bankCode and bankAccountNumber are required")
+	}
+
+	content := req.Content
+	if content == "" {
+		content = req.ExternalOrderID
+	}
+
+	return s.createOrder(goaliniex.OperationCreateOrder, goaliniex.OrderDetails{ //nolint:exhaustruct // filled by createOrder
+		ExternalOrderID: req.ExternalOrderID,
+		Type:            string(goaliniex.OrderTypeSell),
+		FiatAmount:      req.FiatAmount,
+		TokenTransfer: goaliniex.TokenTransfer{ //nolint:exhaustruct // filled by createOrder
+			Currency: req.Currency,
+		},
+		BankTransfer: goaliniex.BankTransfer{
+			BankCode:          req.BankCode,
+			BankName:          "",
+			BankAccountNumber: req.BankAccountNumber,
+			BankAccountName:   "",
+			Content:           req.Content,
+			ContentPayment:    content,
+			TotalPayment:      req.FiatAmount,
+			QRCodeURL:         "",
+		},
+	}, req.FiatCurrency, req.UserEmail)
+}
+
+func (s *Server) createBuyOrder(_ *http.Request, body []byte) (any, error) {
+	var req experimental.CreateBuyOrderRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, failure(ErrorCodeBadRequest, "invalid request body: %s", err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, failure(ErrorCodeBadRequest, "%s", err)
+	}
+
+	// Buy orders are signed like sell orders; the client does not check them.
+	return s.createOrder(goaliniex.OperationCreateOrder, goaliniex.OrderDetails{ //nolint:exhaustruct // filled by createOrder
+		ExternalOrderID: req.ExternalOrderID,
+		Type:            string(goaliniex.OrderTypeBuy),
+		FiatAmount:      req.FiatAmount,
+		TokenTransfer: goaliniex.TokenTransfer{ //nolint:exhaustruct // filled by createOrder
+			Currency:      req.Currency,
+			Network:       req.Network,
+			WalletAddress: req.WalletAddress,
+		},
+		BankTransfer: goaliniex.BankTransfer{
+			BankCode:          CollectionBankCode,
+			BankName:          CollectionBankName,
+			BankAccountNumber: CollectionAccountNumber,
+			BankAccountName:   CollectionAccountName,
+			Content:           req.ExternalOrderID,
+			ContentPayment:    req.ExternalOrderID,
+			TotalPayment:      req.FiatAmount,
+			QRCodeURL:         "",
+		},
+	}, req.FiatCurrency, req.UserEmail)
+}
+
+// createOrder prices and stores a new order built by a create handler.
+func (s *Server) createOrder(
+	op goaliniex.Operation, order goaliniex.OrderDetails, fiat goaliniex.FiatCurrency, email string,
+) (any, error) {
+	switch {
+	case order.ExternalOrderID == "":
+		return nil, failure(ErrorCodeBadRequest, "externalOrderId is required")
+	case order.FiatAmount <= 0:
+		return nil, failure(ErrorCodeBadRequest, "fiatAmount must be positive")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.orders[order.ExternalOrderID]; exists {
+		return nil, failure(ErrorCodeBadRequest, "Order %s already exists", order.ExternalOrderID)
+	}
+
+	price, amount, err := s.tokenAmount(order.TokenTransfer.Currency, fiat, order.FiatAmount)
+	if err != nil {
+		return nil, failure(ErrorCodeInvalidCurrency, "Invalid currency")
+	}
+
+	now := s.cfg.Now().UTC()
+
+	order.TokenTransfer.Price = price
+	order.TokenTransfer.Amount = amount
+	order.Status = goaliniex.OrderStatusAwaitingPayment
+	order.CreatedAt = now.Format(timeLayout)
+	order.ExpiresAt = now.Add(s.cfg.OrderTTL).Format(timeLayout)
+
+	stored := order
+	s.orders[order.ExternalOrderID] = &stored
+	s.orderIDs = append(s.orderIDs, order.ExternalOrderID)
+	s.emails[order.ExternalOrderID] = strings.ToLower(email)
+
+	return s.signedOrder(op, order)
+}
+
+const timeLayout = "2006-01-02T15:04:05Z07:00"
+
+func (s *Server) orderDetails(_ *http.Request, body []byte) (any, error) {
+	var req goaliniex.GetOrderDetailsRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, failure(ErrorCodeBadRequest, "invalid request body: %s", err)
+	}
+
+	order, ok := s.Order(req.ExternalOrderID)
+	if !ok {
+		return nil, failure(ErrorCodeNotFound, "Order not found")
+	}
+
+	return s.signedOrder(goaliniex.OperationGetOrderDetails, order)
+}
+
+// cancelOrder cancels an order that is still awaiting payment.
+func (s *Server) cancelOrder(_ *http.Request, body []byte) (any, error) {
+	var req experimental.CancelOrderRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, failure(ErrorCodeBadRequest, "invalid request body: %s", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, ok := s.orders[req.ExternalOrderID]
+	if !ok {
+		return nil, failure(ErrorCodeNotFound, "Order not found")
+	}
+
+	if order.Status != goaliniex.OrderStatusAwaitingPayment {
+		return nil, failure(ErrorCodeNotCancellable, "Order %s is %s and cannot be cancelled", order.ExternalOrderID, order.Status)
+	}
+
+	s.transition(order, experimental.OrderStatusCancelled)
+	order.Descriptions = req.Reason
+
+	// Signed like order details, as experimental has no cancel operation.
+	return s.signedOrder(goaliniex.OperationGetOrderDetails, *order)
+}
+
+// listOrders pages the orders matching the filters in creation order.
+func (s *Server) listOrders(_ *http.Request, body []byte) (any, error) {
+	var req experimental.ListOrdersRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, failure(ErrorCodeBadRequest, "invalid request body: %s", err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, failure(ErrorCodeBadRequest, "%s", err)
+	}
+
+	page, pageSize := max(req.Page, 1), req.PageSize
+	if pageSize == 0 {
+		pageSize = defaultOrderPageSize
+	}
+
+	matches := s.matchingOrders(&req)
+	start := min((page-1)*pageSize, len(matches))
+	end := min(start+pageSize, len(matches))
+
+	items := make([]goaliniex.OrderDetails, 0, end-start)
+
+	for _, order := range matches[start:end] {
+		signed, err := s.signedOrder(goaliniex.OperationGetOrderDetails, order)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, *signed)
+	}
+
+	return &experimental.OrderPage{
+		Items:    items,
+		Page:     page,
+		PageSize: pageSize,
+		Total:    len(matches),
+		HasMore:  end < len(matches),
+	}, nil
+}
+
+func (s *Server) matchingOrders(req *experimental.ListOrdersRequest) []goaliniex.OrderDetails {
+	from, _ := time.Parse(time.RFC3339, req.FromDate)
+	to, _ := time.Parse(time.RFC3339, req.ToDate)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matches []goaliniex.OrderDetails
+
+	for _, id := range s.orderIDs {
+		order := s.orders[id]
+		createdAt, _ := time.Parse(time.RFC3339, order.CreatedAt)
+
+		switch {
+		case req.Status != "" && order.Status != req.Status,
+			req.Type != "" && order.Type != string(req.Type),
+			req.Currency != "" && order.TokenTransfer.Currency != req.Currency,
+			req.UserEmail != "" && s.emails[id] != strings.ToLower(req.UserEmail),
+			!from.IsZero() && createdAt.Before(from),
+			!to.IsZero() && createdAt.After(to):
+			continue
+		}
+
+		matches = append(matches, *order)
+	}
+
+	return matches
+}
+
+func (s *Server) submitKyc(_ *http.Request, body []byte) (any, error) {
+	var req goaliniex.SubmitKycRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, failure(ErrorCodeBadRequest, "invalid request body: %s", err)
+	}
+
+	if err := req.ValidateAt(s.cfg.Now()); err != nil {
+		return nil, failure(ErrorCodeBadRequest, "%s", err)
+	}
+
+	email := strings.ToLower(req.UserEmail)
+
+	s.mu.Lock()
+
+	if existing, ok := s.kycs[email]; ok && existing.kyc.KycStatus != goaliniex.KycStatusRejected {
+		s.mu.Unlock()
+
+		return nil, failure(ErrorCodeBadRequest, "User already has KYC submitted")
+	}
+
+	record := &kycRecord{id: s.nextKycID, kyc: kycFromRequest(&req)}
+	s.kycs[email] = record
+	s.nextKycID++
+
+	s.mu.Unlock()
+
+	response := &goaliniex.SubmitKycResponse{
+		ID:        record.id,
+		KycStatus: string(record.kyc.KycStatus),
+		Signature: "",
+	}
+
+	signature, err := s.signResponse(goaliniex.OperationSubmitKyc, response)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Signature = signature
+
+	return response, nil
+}
+
+func kycFromRequest(req *goaliniex.SubmitKycRequest) goaliniex.Kyc {
+	var address []string
+
+	for _, part := range []string{req.AddressLine1, req.AddressLine2, req.City, req.State, req.ZipCode} {
+		if part != "" {
+			address = append(address, part)
+		}
+	}
+
+	return goaliniex.Kyc{
+		FirstName:        req.FirstName,
+		LastName:         req.LastName,
+		DateOfBirth:      goaliniex.ParseDate(req.DateOfBirth),
+		Gender:           req.Gender,
+		Nationality:      req.Nationality,
+		IDType:           req.DocumentType,
+		NationalID:       req.NationalID,
+		IssueDate:        goaliniex.ParseDate(req.IssueDate),
+		ExpiryDate:       goaliniex.ParseDate(req.ExpiryDate),
+		Address:          strings.Join(address, ", "),
+		FrontIDImage:     req.FrontIDImage,
+		BackIDImage:      req.BackIDImage,
+		HoldIDImage:      req.HoldIDImage,
+		PhoneNumber:      req.PhoneNumber,
+		PhoneCountryCode: req.PhoneCountryCode,
+		KycStatus:        goaliniex.KycStatusProcessing,
+		RejectReason:     "",
+	}
+}
+
+func (s *Server) getKycInformation(_ *http.Request, body []byte) (any, error) {
+	var req goaliniex.KycInformationRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, failure(ErrorCodeBadRequest, "invalid request body: %s", err)
+	}
+
+	kyc, ok := s.Kyc(req.UserEmail)
+	if !ok {
+		return nil, failure(ErrorCodeNotFound, "User not found")
+	}
+
+	return &kyc, nil
+}
+
+func (s *Server) walletBalance(_ *http.Request, body []byte) (any, error) {
+	var req goaliniex.GetWalletBalanceRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, failure(ErrorCodeBadRequest, "invalid request body: %s", err)
+	}
+
+	switch req.Currency {
+	case goaliniex.CurrencyUSDT, goaliniex.CurrencyETH, goaliniex.CurrencyBTC:
+	default:
+		return nil, failure(ErrorCodeInvalidCurrency, "Invalid currency")
+	}
+
+	balance := &goaliniex.WalletBalance{Balance: s.Balance(req.Currency), Currency: req.Currency, Signature: ""}
+
+	signature, err := s.signResponse(goaliniex.OperationGetWalletBalance, balance)
+	if err != nil {
+		return nil, err
+	}
+
+	balance.Signature = signature
+
+	return balance, nil
+}
+
+func (s *Server) getQRCodeInfo(r *http.Request, _ []byte) (any, error) {
+	content := r.URL.Query().Get("qrContent")
+	if content == "" {
+		return nil, failure(ErrorCodeQRRequired, "QR content is required")
+	}
+
+	s.mu.Lock()
+	info, ok := s.qrCodes[content]
+	s.mu.Unlock()
+
+	if ok {
+		return &info, nil
+	}
+
+	parsed, err := goaliniex.ParseQRCode(content)
+	if err != nil {
+		return nil, failure(ErrorCodeUnsupportedQR, "The QR code has not support yet.")
+	}
+
+	return parsed, nil
+}
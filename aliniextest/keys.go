@@ -0,0 +1,66 @@
+package aliniextest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+)
+
+const keyBits = 2048
+
+// GenerateKeyPair returns a new RSA private key (PKCS#8 PEM) and its public
+// key (PKIX PEM).
+func GenerateKeyPair() ([]byte, []byte, error) {
+	key, err := rsa.GenerateKey(rand.Reader, keyBits)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	publicKey, err := marshalPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Headers: nil, Bytes: der}), publicKey, nil
+}
+
+// PublicKeyPEM derives the PKIX public key PEM of an RSA private key in
+// PKCS#1 or PKCS#8 PEM form.
+func PublicKeyPEM(privateKeyPEM []byte) ([]byte, error) {
+	block, _ := pem.Decode(privateKeyPEM)
+	if block == nil {
+		return nil, ErrInvalidKeyPEM
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return marshalPublicKey(&key.PublicKey)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKeyPEM, err)
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T is not an RSA key", ErrInvalidKeyPEM, key)
+	}
+
+	return marshalPublicKey(&rsaKey.PublicKey)
+}
+
+func marshalPublicKey(key *rsa.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Headers: nil, Bytes: der}), nil
+}
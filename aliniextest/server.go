@@ -0,0 +1,431 @@
+// Package aliniextest provides an in-process fake of the Aliniex API for
+// tests. The server verifies request signatures, keeps orders, KYC records
+// and wallet balances in memory and lets tests drive order status and inject
+// failures.
+package aliniextest
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andyle182810/goaliniex"
+	"github.com/andyle182810/goaliniex/experimental"
+	"github.com/andyle182810/goaliniex/signer"
+)
+
+// Endpoints served by the fake. EndpointCreateBuyOrder, EndpointListOrders
+// and EndpointCancelOrder are assumed endpoints of package experimental.
+const (
+	EndpointCreateSellOrder   = "/api/v2/orders/create-sell-order"
+	EndpointCreateBuyOrder    = experimental.EndpointCreateBuyOrder
+	EndpointOrderDetails      = "/api/v2/orders/details"
+	EndpointListOrders        = experimental.EndpointListOrders
+	EndpointCancelOrder       = experimental.EndpointCancelOrder
+	EndpointSubmitKyc         = "/api/v2/user/submit-kyc"
+	EndpointGetKycInformation = "/api/v2/user/get-kyc-information"
+	EndpointWalletBalance     = "/api/v2/wallet/balance"
+	EndpointGetQRCodeInfo     = "/api/v2/public/get-qr-code-info"
+)
+
+// Error codes sent in failed responses.
+const (
+	ErrorCodeBadRequest       = 400
+	ErrorCodeInvalidSignature = 401
+	ErrorCodeNotFound         = 404
+	ErrorCodeNotCancellable   = 409
+	ErrorCodeQRRequired       = 1
+	ErrorCodeUnsupportedQR    = 33
+	ErrorCodeInvalidCurrency  = 1001
+)
+
+// Bank account buy orders are paid into.
+const (
+	CollectionBankCode      = "970436"
+	CollectionBankName      = "Vietcombank"
+	CollectionAccountNumber = "1234567890"
+	CollectionAccountName   = "ALINIEX COLLECTION"
+)
+
+const (
+	defaultOrderTTL      = 15 * time.Minute
+	defaultOrderPageSize = 20
+)
+
+var (
+	ErrMissingConfig   = errors.New("aliniextest: partner code, secret key and public key are required")
+	ErrOrderNotFound   = errors.New("aliniextest: order not found")
+	ErrOrderFinal      = errors.New("aliniextest: order is in a final status")
+	ErrKycNotFound     = errors.New("aliniextest: kyc record not found")
+	ErrInvalidKeyPEM   = errors.New("aliniextest: invalid private key PEM")
+	ErrUnsupportedPair = errors.New("aliniextest: no rate for currency pair")
+)
+
+type Config struct {
+	PartnerCode string
+	SecretKey   string
+	// PublicKey is the partner's RSA public key (PEM) used to verify request
+	// signatures.
+	PublicKey []byte
+	// Algorithm is the signature scheme for requests and responses. The
+	// default is signer.DefaultAlgorithm.
+	Algorithm signer.Algorithm
+	// ResponseKey, an RSA private key (PEM), signs the data of order, KYC
+	// submission and balance responses when set.
+	ResponseKey []byte
+	// Balances seeds the partner's wallet balances.
+	Balances map[goaliniex.Currency]float64
+	// OrderTTL sets ExpiresAt on new orders, 15 minutes by default.
+	OrderTTL time.Duration
+	// Now overrides the clock used for timestamps and KYC validation.
+	Now func() time.Time
+}
+
+// Fault describes an injected failure. A StatusCode of 400 or above is sent
+// as an HTTP error; otherwise the response is a 200 with success false.
+type Fault struct {
+	StatusCode int
+	Message    string
+	ErrorCode  int
+	// Times is the number of calls that fail; zero means one.
+	Times int
+}
+
+type currencyPair struct {
+	currency goaliniex.Currency
+	fiat     goaliniex.FiatCurrency
+}
+
+type kycRecord struct {
+	id  int
+	kyc goaliniex.Kyc
+}
+
+// Server is a running fake. Create it with NewServer and stop it with Close.
+type Server struct {
+	// URL is the base URL to pass to goaliniex.NewClient.
+	URL string
+
+	cfg        Config
+	httpServer *httptest.Server
+
+	mu        sync.Mutex
+	orders    map[string]*goaliniex.OrderDetails
+	orderIDs  []string
+	emails    map[string]string
+	kycs      map[string]*kycRecord
+	nextKycID int
+	balances  map[goaliniex.Currency]float64
+	rates     map[currencyPair]float64
+	qrCodes   map[string]goaliniex.QRCodeInfo
+	faults    map[string][]Fault
+	calls     map[string]int
+}
+
+// NewServer starts a fake Aliniex server.
+func NewServer(cfg Config) (*Server, error) {
+	if cfg.PartnerCode == "" || cfg.SecretKey == "" || len(cfg.PublicKey) == 0 {
+		return nil, ErrMissingConfig
+	}
+
+	if cfg.Algorithm == "" {
+		cfg.Algorithm = signer.DefaultAlgorithm
+	}
+
+	if _, err := signer.ParseAlgorithm(string(cfg.Algorithm)); err != nil {
+		return nil, err
+	}
+
+	if cfg.OrderTTL <= 0 {
+		cfg.OrderTTL = defaultOrderTTL
+	}
+
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+
+	server := &Server{
+		URL:        "",
+		cfg:        cfg,
+		httpServer: nil,
+		mu:         sync.Mutex{},
+		orders:     map[string]*goaliniex.OrderDetails{},
+		orderIDs:   nil,
+		emails:     map[string]string{},
+		kycs:       map[string]*kycRecord{},
+		nextKycID:  1,
+		balances:   map[goaliniex.Currency]float64{},
+		rates:      defaultRates(),
+		qrCodes:    map[string]goaliniex.QRCodeInfo{},
+		faults:     map[string][]Fault{},
+		calls:      map[string]int{},
+	}
+
+	for currency, balance := range cfg.Balances {
+		server.balances[currency] = balance
+	}
+
+	server.httpServer = httptest.NewServer(server.routes())
+	server.URL = server.httpServer.URL
+
+	return server, nil
+}
+
+// defaultRates returns USDT prices in each fiat currency the SDK knows.
+func defaultRates() map[currencyPair]float64 {
+	return map[currencyPair]float64{
+		{goaliniex.CurrencyUSDT, goaliniex.FiatCurrencyVND}: 26000,
+		{goaliniex.CurrencyUSDT, goaliniex.FiatCurrencyPHP}: 58,
+		{goaliniex.CurrencyUSDT, goaliniex.FiatCurrencyTHB}: 33,
+		{goaliniex.CurrencyUSDT, goaliniex.FiatCurrencyGEL}: 2.7,
+		{goaliniex.CurrencyUSDT, goaliniex.FiatCurrencyBRL}: 5.4,
+		{goaliniex.CurrencyUSDT, goaliniex.FiatCurrencyARS}: 1400,
+		{goaliniex.CurrencyUSDT, goaliniex.FiatCurrencyPEN}: 3.5,
+		{goaliniex.CurrencyUSDT, goaliniex.FiatCurrencyNGN}: 1500,
+	}
+}
+
+func (s *Server) Close() {
+	s.httpServer.Close()
+}
+
+// NewClient returns a goaliniex client for the server, signing with
+// privateKey. Response signatures are verified when Config.ResponseKey is set.
+func (s *Server) NewClient(privateKey []byte, opts ...goaliniex.Option) (*goaliniex.Client, error) {
+	defaults := []goaliniex.Option{
+		goaliniex.WithHTTPClient(s.httpServer.Client()),
+		goaliniex.WithSigningAlgorithm(s.cfg.Algorithm),
+	}
+
+	if len(s.cfg.ResponseKey) > 0 {
+		publicKey, err := PublicKeyPEM(s.cfg.ResponseKey)
+		if err != nil {
+			return nil, err
+		}
+
+		defaults = append(defaults, goaliniex.WithResponsePublicKey(publicKey),
+			goaliniex.WithResponseSigningAlgorithm(s.cfg.Algorithm))
+	}
+
+	return goaliniex.NewClient(s.URL, s.cfg.PartnerCode, s.cfg.SecretKey, privateKey, append(defaults, opts...)...)
+}
+
+func (s *Server) SetBalance(currency goaliniex.Currency, balance float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.balances[currency] = balance
+}
+
+func (s *Server) Balance(currency goaliniex.Currency) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.balances[currency]
+}
+
+// SetRate sets the price of one unit of currency in fiat.
+func (s *Server) SetRate(currency goaliniex.Currency, fiat goaliniex.FiatCurrency, price float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rates[currencyPair{currency, fiat}] = price
+}
+
+// SetQRCodeInfo makes get-qr-code-info return info for content. Other
+// content is parsed with goaliniex.ParseQRCode.
+func (s *Server) SetQRCodeInfo(content string, info goaliniex.QRCodeInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.qrCodes[content] = info
+}
+
+// SetKyc stores a KYC record for email, replacing any existing one.
+func (s *Server) SetKyc(email string, kyc goaliniex.Kyc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.kycs[strings.ToLower(email)] = &kycRecord{id: s.nextKycID, kyc: kyc}
+	s.nextKycID++
+}
+
+func (s *Server) Kyc(email string) (goaliniex.Kyc, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.kycs[strings.ToLower(email)]
+	if !ok {
+		return goaliniex.Kyc{}, false //nolint:exhaustruct // not found
+	}
+
+	return record.kyc, true
+}
+
+// SetKycStatus reviews a KYC record, e.g. to verify or reject a submission.
+func (s *Server) SetKycStatus(email string, status goaliniex.KycStatus, rejectReason string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.kycs[strings.ToLower(email)]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrKycNotFound, email)
+	}
+
+	record.kyc.KycStatus = status
+	record.kyc.RejectReason = rejectReason
+
+	return nil
+}
+
+func (s *Server) Order(externalOrderID string) (goaliniex.OrderDetails, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, ok := s.orders[externalOrderID]
+	if !ok {
+		return goaliniex.OrderDetails{}, false //nolint:exhaustruct // not found
+	}
+
+	return *order, true
+}
+
+// SetOrderStatus moves an order to status. Reaching SUCCESS fills the paid
+// amount and transaction hash and debits the token amount from the balance.
+func (s *Server) SetOrderStatus(externalOrderID string, status goaliniex.OrderStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, ok := s.orders[externalOrderID]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrOrderNotFound, externalOrderID)
+	}
+
+	s.transition(order, status)
+
+	return nil
+}
+
+// AdvanceOrder moves an order one step along AWAITING_PAYMENT,
+// PAYMENT_COMPLETED, PROCESSING_TOKEN_TRANSFER and SUCCESS.
+func (s *Server) AdvanceOrder(externalOrderID string) (goaliniex.OrderStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, ok := s.orders[externalOrderID]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrOrderNotFound, externalOrderID)
+	}
+
+	var next goaliniex.OrderStatus
+
+	switch order.Status {
+	case goaliniex.OrderStatusAwaitingPayment:
+		next = goaliniex.OrderStatusPaymentCompleted
+	case goaliniex.OrderStatusPaymentCompleted:
+		next = goaliniex.OrderStatusProcessingTokenTransfer
+	case goaliniex.OrderStatusProcessingTokenTransfer:
+		next = goaliniex.OrderStatusSuccess
+	case goaliniex.OrderStatusSuccess, goaliniex.OrderStatusError, goaliniex.OrderStatusFail,
+		experimental.OrderStatusCancelled, experimental.OrderStatusExpired:
+		return order.Status, fmt.Errorf("%w: %s is %s", ErrOrderFinal, externalOrderID, order.Status)
+	default:
+		next = goaliniex.OrderStatusAwaitingPayment
+	}
+
+	s.transition(order, next)
+
+	return next, nil
+}
+
+func (s *Server) transition(order *goaliniex.OrderDetails, status goaliniex.OrderStatus) {
+	if status == goaliniex.OrderStatusPaymentCompleted || status == goaliniex.OrderStatusSuccess {
+		order.PaidAmount = order.BankTransfer.TotalPayment
+	}
+
+	if status == goaliniex.OrderStatusSuccess && order.Status != goaliniex.OrderStatusSuccess {
+		order.TokenTransfer.TxHash = fmt.Sprintf("0x%x", sha256.Sum256([]byte(order.ExternalOrderID)))
+		s.balances[order.TokenTransfer.Currency] -= order.TokenTransfer.Amount
+	}
+
+	order.Status = status
+}
+
+// FailNext makes the next fault.Times calls to endpoint fail.
+func (s *Server) FailNext(endpoint string, fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	times := max(fault.Times, 1)
+	for range times {
+		s.faults[endpoint] = append(s.faults[endpoint], fault)
+	}
+}
+
+// Calls returns how many requests endpoint has received.
+func (s *Server) Calls(endpoint string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls[endpoint]
+}
+
+func (s *Server) takeFault(endpoint string) (Fault, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls[endpoint]++
+
+	queue := s.faults[endpoint]
+	if len(queue) == 0 {
+		return Fault{}, false //nolint:exhaustruct // no fault
+	}
+
+	s.faults[endpoint] = queue[1:]
+
+	return queue[0], true
+}
+
+// tokenAmount returns the price and the token amount for fiatAmount.
+func (s *Server) tokenAmount(
+	currency goaliniex.Currency, fiat goaliniex.FiatCurrency, fiatAmount float64,
+) (float64, float64, error) {
+	price, ok := s.rates[currencyPair{currency, fiat}]
+	if !ok || price <= 0 {
+		return 0, 0, fmt.Errorf("%w: %s/%s", ErrUnsupportedPair, currency, fiat)
+	}
+
+	const precision = 1e6
+
+	return price, math.Round(fiatAmount/price*precision) / precision, nil
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	for _, route := range []struct {
+		method   string
+		endpoint string
+		next     handlerFunc
+	}{
+		{"POST", EndpointCreateSellOrder, s.createSellOrder},
+		{"POST", EndpointCreateBuyOrder, s.createBuyOrder},
+		{"POST", EndpointOrderDetails, s.orderDetails},
+		{"POST", EndpointListOrders, s.listOrders},
+		{"POST", EndpointCancelOrder, s.cancelOrder},
+		{"POST", EndpointSubmitKyc, s.submitKyc},
+		{"POST", EndpointGetKycInformation, s.getKycInformation},
+		{"POST", EndpointWalletBalance, s.walletBalance},
+		{"GET", EndpointGetQRCodeInfo, s.getQRCodeInfo},
+	} {
+		mux.HandleFunc(route.method+" "+route.endpoint, s.handle(route.endpoint, route.next))
+	}
+
+	return mux
+}
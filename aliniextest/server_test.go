@@ -0,0 +1,602 @@
+package aliniextest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andyle182810/goaliniex"
+	"github.com/andyle182810/goaliniex/aliniextest"
+	"github.com/andyle182810/goaliniex/experimental"
+)
+
+const (
+	testPartner = "TEST_PARTNER"
+	testSecret  = "TEST_SECRET"
+	testImage   = "data:image/jpeg;base64,/9j/4AAQSkZJRg=="
+)
+
+func newServer(t *testing.T) (*aliniextest.Server, *goaliniex.Client) {
+	t.Helper()
+
+	privateKey, publicKey, err := aliniextest.GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %v", err)
+	}
+
+	responseKey, _, err := aliniextest.GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %v", err)
+	}
+
+	cfg := aliniextest.Config{
+		PartnerCode: testPartner,
+		SecretKey:   testSecret,
+		PublicKey:   publicKey,
+		Algorithm:   "",
+		ResponseKey: responseKey,
+		Balances:    map[goaliniex.Currency]float64{goaliniex.CurrencyUSDT: 1000},
+		OrderTTL:    0,
+		Now:         func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) },
+	}
+
+	server, err := aliniextest.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+
+	t.Cleanup(server.Close)
+
+	client, err := server.NewClient(privateKey)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	return server, client
+}
+
+func sellOrder(id string) *goaliniex.CreateOrderRequest {
+	return &goaliniex.CreateOrderRequest{
+		Currency:          goaliniex.CurrencyUSDT,
+		FiatAmount:        260000,
+		FiatCurrency:      goaliniex.FiatCurrencyVND,
+		BankCode:          "VCB",
+		BankAccountNumber: "0123456789",
+		ExternalOrderID:   id,
+		WebhookSecretKey:  "",
+		UserEmail:         "jane@example.com",
+		UserKYCVerified:   true,
+		Content:           "",
+		ExtendInfo:        nil,
+	}
+}
+
+func kycRequest(email string) *goaliniex.SubmitKycRequest {
+	return &goaliniex.SubmitKycRequest{
+		UserEmail:        email,
+		FirstName:        "Jane",
+		LastName:         "Smith",
+		DateOfBirth:      "1985-05-15",
+		Gender:           goaliniex.GenderFemale,
+		Nationality:      "VN",
+		DocumentType:     goaliniex.IDTypeIDCard,
+		NationalID:       "987654321",
+		IssueDate:        "2019-06-01",
+		ExpiryDate:       "2029-06-01",
+		AddressLine1:     "456 Oak Ave",
+		AddressLine2:     "",
+		City:             "Ho Chi Minh",
+		State:            "HCM",
+		ZipCode:          "70000",
+		FrontIDImage:     testImage,
+		BackIDImage:      testImage,
+		HoldIDImage:      testImage,
+		PhoneNumber:      "987654321",
+		PhoneCountryCode: "+84",
+	}
+}
+
+func TestNewServer_RequiresConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := aliniextest.NewServer(aliniextest.Config{}) //nolint:exhaustruct // empty config
+	if !errors.Is(err, aliniextest.ErrMissingConfig) {
+		t.Fatalf("expected ErrMissingConfig, got %v", err)
+	}
+}
+
+func TestServer_OrderLifecycle(t *testing.T) {
+	t.Parallel()
+
+	server, client := newServer(t)
+	ctx := context.Background()
+
+	created, err := client.CreateOrder(ctx, sellOrder("ORD-1"))
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	if !created.Success || created.Data.Status != goaliniex.OrderStatusAwaitingPayment {
+		t.Fatalf("unexpected create response: %+v", created)
+	}
+
+	if created.Data.TokenTransfer.Amount != 10 || created.Data.TokenTransfer.Price != 26000 {
+		t.Errorf("token transfer = %+v, want 10 USDT at 26000", created.Data.TokenTransfer)
+	}
+
+	if created.Data.ExpiresAt != "2025-01-02T03:19:05Z" {
+		t.Errorf("ExpiresAt = %q", created.Data.ExpiresAt)
+	}
+
+	for _, want := range []goaliniex.OrderStatus{
+		goaliniex.OrderStatusPaymentCompleted,
+		goaliniex.OrderStatusProcessingTokenTransfer,
+		goaliniex.OrderStatusSuccess,
+	} {
+		status, err := server.AdvanceOrder("ORD-1")
+		if err != nil || status != want {
+			t.Fatalf("AdvanceOrder = %s, %v; want %s", status, err, want)
+		}
+	}
+
+	if _, err := server.AdvanceOrder("ORD-1"); !errors.Is(err, aliniextest.ErrOrderFinal) {
+		t.Errorf("expected ErrOrderFinal, got %v", err)
+	}
+
+	details, err := client.GetOrderDetails(ctx, &goaliniex.GetOrderDetailsRequest{ExternalOrderID: "ORD-1"})
+	if err != nil {
+		t.Fatalf("GetOrderDetails: %v", err)
+	}
+
+	if details.Data.Status != goaliniex.OrderStatusSuccess || details.Data.PaidAmount != 260000 {
+		t.Errorf("unexpected details: %+v", details.Data)
+	}
+
+	if !strings.HasPrefix(details.Data.TokenTransfer.TxHash, "0x") {
+		t.Errorf("TxHash = %q", details.Data.TokenTransfer.TxHash)
+	}
+
+	if got := server.Balance(goaliniex.CurrencyUSDT); got != 990 {
+		t.Errorf("balance = %v, want 990", got)
+	}
+}
+
+func TestServer_BuyOrder(t *testing.T) {
+	t.Parallel()
+
+	server, client := newServer(t)
+	ctx := context.Background()
+
+	created, err := experimental.CreateBuyOrder(ctx, client, &experimental.CreateBuyOrderRequest{
+		Currency:         goaliniex.CurrencyUSDT,
+		Network:          goaliniex.NetworkTRC20,
+		FiatAmount:       520000,
+		FiatCurrency:     goaliniex.FiatCurrencyVND,
+		WalletAddress:    "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+		ExternalOrderID:  "BUY-1",
+		WebhookSecretKey: "",
+		UserEmail:        "user@example.com",
+		UserKYCVerified:  true,
+		ExtendInfo:       nil,
+	})
+	if err != nil {
+		t.Fatalf("CreateBuyOrder: %v", err)
+	}
+
+	if created.Data.Type != goaliniex.OrderTypeBuy || created.Data.TokenTransfer.Amount != 20 {
+		t.Errorf("unexpected buy order: %+v", created.Data)
+	}
+
+	if created.Data.BankTransfer.BankAccountNumber != aliniextest.CollectionAccountNumber {
+		t.Errorf("bank transfer = %+v, want collection account", created.Data.BankTransfer)
+	}
+
+	if _, err := server.AdvanceOrder("BUY-1"); err != nil {
+		t.Fatalf("AdvanceOrder: %v", err)
+	}
+
+	details, err := client.GetOrderDetails(ctx, &goaliniex.GetOrderDetailsRequest{ExternalOrderID: "BUY-1"})
+	if err != nil {
+		t.Fatalf("GetOrderDetails: %v", err)
+	}
+
+	if details.Data.Type != string(goaliniex.OrderTypeBuy) || details.Data.Status != goaliniex.OrderStatusPaymentCompleted {
+		t.Errorf("unexpected details: %+v", details.Data)
+	}
+}
+
+func TestServer_ListOrders(t *testing.T) {
+	t.Parallel()
+
+	_, client := newServer(t)
+	ctx := context.Background()
+
+	for _, id := range []string{"ORD-1", "ORD-2", "ORD-3"} {
+		if _, err := client.CreateOrder(ctx, sellOrder(id)); err != nil {
+			t.Fatalf("CreateOrder: %v", err)
+		}
+	}
+
+	//nolint:exhaustruct // each case sets at most one filter
+	testCases := []struct {
+		name  string
+		req   experimental.ListOrdersRequest
+		total int
+	}{
+		{name: "all", req: experimental.ListOrdersRequest{}, total: 3},
+		{name: "by type", req: experimental.ListOrdersRequest{Type: goaliniex.OrderTypeBuy}, total: 0},
+		{name: "by email", req: experimental.ListOrdersRequest{UserEmail: "JANE@example.com"}, total: 3},
+		{name: "until created", req: experimental.ListOrdersRequest{ToDate: "2025-01-02T03:04:05Z"}, total: 3},
+		{name: "after created", req: experimental.ListOrdersRequest{FromDate: "2025-01-02T03:04:06Z"}, total: 0},
+	}
+
+	for _, tc := range testCases {
+		resp, err := experimental.ListOrders(ctx, client, &tc.req)
+		if err != nil {
+			t.Fatalf("%s: ListOrders: %v", tc.name, err)
+		}
+
+		if resp.Data.Total != tc.total || len(resp.Data.Items) != tc.total || resp.Data.HasMore {
+			t.Errorf("%s: unexpected page: %+v", tc.name, resp.Data)
+		}
+	}
+}
+
+func TestServer_CancelOrder(t *testing.T) {
+	t.Parallel()
+
+	server, client := newServer(t)
+	ctx := context.Background()
+
+	if _, err := client.CreateOrder(ctx, sellOrder("ORD-1")); err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	resp, err := experimental.CancelOrder(ctx, client, &experimental.CancelOrderRequest{ExternalOrderID: "ORD-1", Reason: "expired"})
+	if err != nil {
+		t.Fatalf("CancelOrder: %v", err)
+	}
+
+	if resp.Data.Status != experimental.OrderStatusCancelled || resp.Data.Descriptions != "expired" {
+		t.Errorf("unexpected order: %+v", resp.Data)
+	}
+
+	if _, err := server.AdvanceOrder("ORD-1"); !errors.Is(err, aliniextest.ErrOrderFinal) {
+		t.Errorf("expected ErrOrderFinal, got %v", err)
+	}
+
+	for id, code := range map[string]int{"ORD-1": aliniextest.ErrorCodeNotCancellable, "MISSING": aliniextest.ErrorCodeNotFound} {
+		resp, err := experimental.CancelOrder(ctx, client, &experimental.CancelOrderRequest{ExternalOrderID: id, Reason: ""})
+		if err != nil || resp.Success || resp.ErrorCode != code {
+			t.Errorf("cancel %s = %+v, %v; want errorCode %d", id, resp, err, code)
+		}
+	}
+}
+
+func TestServer_OrderErrors(t *testing.T) {
+	t.Parallel()
+
+	_, client := newServer(t)
+	ctx := context.Background()
+
+	if _, err := client.CreateOrder(ctx, sellOrder("DUP")); err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	duplicate, err := client.CreateOrder(ctx, sellOrder("DUP"))
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	if duplicate.Success || duplicate.ErrorCode != aliniextest.ErrorCodeBadRequest {
+		t.Errorf("duplicate order: %+v", duplicate)
+	}
+
+	unsupported := sellOrder("EUR")
+	unsupported.FiatCurrency = "EUR"
+
+	response, err := client.CreateOrder(ctx, unsupported)
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	if response.Success || response.ErrorCode != aliniextest.ErrorCodeInvalidCurrency {
+		t.Errorf("unsupported pair: %+v", response)
+	}
+
+	missing, err := client.GetOrderDetails(ctx, &goaliniex.GetOrderDetailsRequest{ExternalOrderID: "NOPE"})
+	if err != nil {
+		t.Fatalf("GetOrderDetails: %v", err)
+	}
+
+	if missing.Success || missing.ErrorCode != aliniextest.ErrorCodeNotFound {
+		t.Errorf("missing order: %+v", missing)
+	}
+}
+
+func TestServer_Kyc(t *testing.T) {
+	t.Parallel()
+
+	server, client := newServer(t)
+	ctx := context.Background()
+
+	submitted, err := client.SubmitKyc(ctx, kycRequest("Jane@Example.com"))
+	if err != nil {
+		t.Fatalf("SubmitKyc: %v", err)
+	}
+
+	if !submitted.Success || submitted.Data.KycStatus != string(goaliniex.KycStatusProcessing) {
+		t.Fatalf("unexpected submit response: %+v", submitted)
+	}
+
+	again, err := client.SubmitKyc(ctx, kycRequest("jane@example.com"))
+	if err != nil {
+		t.Fatalf("SubmitKyc: %v", err)
+	}
+
+	if again.Success || again.Message != "User already has KYC submitted" {
+		t.Errorf("resubmission: %+v", again)
+	}
+
+	if err := server.SetKycStatus("jane@example.com", goaliniex.KycStatusVerified, ""); err != nil {
+		t.Fatalf("SetKycStatus: %v", err)
+	}
+
+	kyc, err := client.GetKyc(ctx, &goaliniex.KycInformationRequest{UserEmail: "jane@example.com"})
+	if err != nil {
+		t.Fatalf("GetKyc: %v", err)
+	}
+
+	if kyc.Data.KycStatus != goaliniex.KycStatusVerified || kyc.Data.Address != "456 Oak Ave, Ho Chi Minh, HCM, 70000" {
+		t.Errorf("unexpected kyc: %+v", kyc.Data)
+	}
+
+	unknown, err := client.GetKyc(ctx, &goaliniex.KycInformationRequest{UserEmail: "nobody@example.com"})
+	if err != nil {
+		t.Fatalf("GetKyc: %v", err)
+	}
+
+	if unknown.Success || unknown.ErrorCode != aliniextest.ErrorCodeNotFound {
+		t.Errorf("unknown user: %+v", unknown)
+	}
+
+	err = server.SetKycStatus("nobody@example.com", goaliniex.KycStatusVerified, "")
+	if !errors.Is(err, aliniextest.ErrKycNotFound) {
+		t.Errorf("expected ErrKycNotFound, got %v", err)
+	}
+}
+
+func TestServer_WalletBalance(t *testing.T) {
+	t.Parallel()
+
+	server, client := newServer(t)
+	server.SetBalance(goaliniex.CurrencyETH, 1.5)
+
+	request := &goaliniex.GetWalletBalanceRequest{Currency: goaliniex.CurrencyETH}
+
+	balance, err := client.GetWalletBalance(context.Background(), request)
+	if err != nil {
+		t.Fatalf("GetWalletBalance: %v", err)
+	}
+
+	if balance.Data.Balance != 1.5 || balance.Data.Currency != goaliniex.CurrencyETH {
+		t.Errorf("unexpected balance: %+v", balance.Data)
+	}
+
+	invalid, err := client.GetWalletBalance(context.Background(), &goaliniex.GetWalletBalanceRequest{Currency: "DOGE"})
+	if err != nil {
+		t.Fatalf("GetWalletBalance: %v", err)
+	}
+
+	if invalid.Success || invalid.ErrorCode != aliniextest.ErrorCodeInvalidCurrency {
+		t.Errorf("invalid currency: %+v", invalid)
+	}
+}
+
+func TestServer_QRCodeInfo(t *testing.T) {
+	t.Parallel()
+
+	server, client := newServer(t)
+	ctx := context.Background()
+
+	server.SetQRCodeInfo("custom", goaliniex.QRCodeInfo{ //nolint:exhaustruct // only the fields under test
+		QRType:      goaliniex.QRTypePIX,
+		CountryCode: "BR",
+	})
+
+	info, err := client.GetQRCodeInfo(ctx, &goaliniex.GetQRCodeInfoRequest{QRContent: "custom"})
+	if err != nil {
+		t.Fatalf("GetQRCodeInfo: %v", err)
+	}
+
+	if !info.Success || info.Data.QRType != goaliniex.QRTypePIX {
+		t.Errorf("unexpected qr info: %+v", info)
+	}
+
+	unsupported, err := client.GetQRCodeInfo(ctx, &goaliniex.GetQRCodeInfoRequest{QRContent: "not a qr code"})
+	if err != nil {
+		t.Fatalf("GetQRCodeInfo: %v", err)
+	}
+
+	if unsupported.Success || unsupported.ErrorCode != aliniextest.ErrorCodeUnsupportedQR {
+		t.Errorf("unsupported qr: %+v", unsupported)
+	}
+}
+
+func TestServer_RejectsBadSignature(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newServer(t)
+
+	otherKey, _, err := aliniextest.GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %v", err)
+	}
+
+	client, err := server.NewClient(otherKey)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	response, err := client.CreateOrder(context.Background(), sellOrder("ORD-BAD"))
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	if response.Success || response.ErrorCode != aliniextest.ErrorCodeInvalidSignature {
+		t.Errorf("expected signature rejection, got %+v", response)
+	}
+
+	if _, ok := server.Order("ORD-BAD"); ok {
+		t.Error("order created despite bad signature")
+	}
+}
+
+// TestServer_RejectsReorderedSigningFields signs create-sell-order with
+// currency and externalOrderId swapped, as a drifted client spec would. The
+// server checks the documented order rather than the SDK's spec, so the
+// request must be refused; the documented order is accepted.
+func TestServer_RejectsReorderedSigningFields(t *testing.T) {
+	t.Parallel()
+
+	server, client := newServer(t)
+
+	documented := []string{
+		goaliniex.FieldPartnerCode, "externalOrderId", "currency", "fiatAmount",
+		"bankCode", "bankAccountNumber", "content", "userEmail",
+	}
+	reordered := []string{
+		goaliniex.FieldPartnerCode, "currency", "externalOrderId", "fiatAmount",
+		"bankCode", "bankAccountNumber", "content", "userEmail",
+	}
+
+	for _, testCase := range []struct {
+		id       string
+		fields   []string
+		accepted bool
+	}{
+		{id: "ORD-REORDERED", fields: reordered, accepted: false},
+		{id: "ORD-DOCUMENTED", fields: documented, accepted: true},
+		{id: "ORD-SHORT", fields: documented[:7], accepted: false},
+	} {
+		response, err := goaliniex.Do[goaliniex.CreateOrderResponse](context.Background(), client, goaliniex.Call{
+			Method:        http.MethodPost,
+			Endpoint:      aliniextest.EndpointCreateSellOrder,
+			Params:        sellOrder(testCase.id),
+			SigningFields: testCase.fields,
+			Public:        false,
+		})
+		if err != nil {
+			t.Fatalf("%s: Do: %v", testCase.id, err)
+		}
+
+		if testCase.accepted != response.Success {
+			t.Errorf("%s: expected accepted=%v, got %+v", testCase.id, testCase.accepted, response)
+		}
+
+		if !testCase.accepted && response.ErrorCode != aliniextest.ErrorCodeInvalidSignature {
+			t.Errorf("%s: expected ErrorCodeInvalidSignature, got %d", testCase.id, response.ErrorCode)
+		}
+
+		if _, ok := server.Order(testCase.id); ok != testCase.accepted {
+			t.Errorf("%s: order stored = %v", testCase.id, ok)
+		}
+	}
+}
+
+func TestServer_ResponseSignatureVerified(t *testing.T) {
+	t.Parallel()
+
+	privateKey, publicKey, err := aliniextest.GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %v", err)
+	}
+
+	_, wrongKey, err := aliniextest.GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %v", err)
+	}
+
+	server, err := aliniextest.NewServer(aliniextest.Config{
+		PartnerCode: testPartner,
+		SecretKey:   testSecret,
+		PublicKey:   publicKey,
+		Algorithm:   "",
+		ResponseKey: privateKey,
+		Balances:    nil,
+		OrderTTL:    0,
+		Now:         nil,
+	})
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+
+	t.Cleanup(server.Close)
+
+	request := &goaliniex.GetWalletBalanceRequest{Currency: goaliniex.CurrencyUSDT}
+
+	client, err := server.NewClient(privateKey)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	if _, err := client.GetWalletBalance(context.Background(), request); err != nil {
+		t.Fatalf("GetWalletBalance: %v", err)
+	}
+
+	client, err = server.NewClient(privateKey, goaliniex.WithResponsePublicKey(wrongKey))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	if _, err := client.GetWalletBalance(context.Background(), request); !errors.Is(err, goaliniex.ErrResponseSignature) {
+		t.Errorf("expected ErrResponseSignature, got %v", err)
+	}
+}
+
+func TestServer_FailNext(t *testing.T) {
+	t.Parallel()
+
+	server, client := newServer(t)
+	ctx := context.Background()
+
+	server.FailNext(aliniextest.EndpointWalletBalance, aliniextest.Fault{
+		StatusCode: http.StatusServiceUnavailable,
+		Message:    "maintenance",
+		ErrorCode:  0,
+		Times:      1,
+	})
+	server.FailNext(aliniextest.EndpointWalletBalance, aliniextest.Fault{
+		StatusCode: 0,
+		Message:    "busy",
+		ErrorCode:  42,
+		Times:      1,
+	})
+
+	request := &goaliniex.GetWalletBalanceRequest{Currency: goaliniex.CurrencyUSDT}
+
+	if _, err := client.GetWalletBalance(ctx, request); !errors.Is(err, goaliniex.ErrUnexpectedStatus) {
+		t.Fatalf("expected ErrUnexpectedStatus, got %v", err)
+	}
+
+	response, err := client.GetWalletBalance(ctx, request)
+	if err != nil {
+		t.Fatalf("GetWalletBalance: %v", err)
+	}
+
+	if response.Success || response.ErrorCode != 42 || response.Message != "busy" {
+		t.Errorf("unexpected fault response: %+v", response)
+	}
+
+	response, err = client.GetWalletBalance(ctx, request)
+	if err != nil || !response.Success || response.Data.Balance != 1000 {
+		t.Fatalf("expected recovery, got %+v, %v", response, err)
+	}
+
+	if calls := server.Calls(aliniextest.EndpointWalletBalance); calls != 3 {
+		t.Errorf("Calls = %d, want 3", calls)
+	}
+}
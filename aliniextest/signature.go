@@ -0,0 +1,85 @@
+package aliniextest
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// signedFields returns the request fields each signed endpoint signs,
+// followed by the secret key. Documented endpoints use the order Aliniex
+// documents; the buy, list and cancel endpoints are undocumented and use the
+// fields package experimental assumes. They are kept apart from
+// goaliniex.RequestSignatureSpec on purpose: a client spec with a missing or
+// reordered field then fails verification here.
+func signedFields() map[string][]string {
+	return map[string][]string{
+		EndpointCreateSellOrder: {
+			"partnerCode", "externalOrderId", "currency", "fiatAmount",
+			"bankCode", "bankAccountNumber", "content", "userEmail",
+		},
+		EndpointCreateBuyOrder: {
+			"partnerCode", "externalOrderId", "currency", "network",
+			"fiatAmount", "walletAddress", "userEmail",
+		},
+		EndpointOrderDetails: {"partnerCode", "externalOrderId"},
+		EndpointListOrders: {
+			"partnerCode", "status", "type", "currency", "userEmail",
+			"fromDate", "toDate", "page", "pageSize",
+		},
+		EndpointCancelOrder:       {"partnerCode", "externalOrderId", "reason"},
+		EndpointSubmitKyc:         {"partnerCode", "userEmail", "nationality"},
+		EndpointGetKycInformation: {"partnerCode", "userEmail"},
+		EndpointWalletBalance:     {"partnerCode", "currency"},
+	}
+}
+
+// expectedPayload joins the signed fields of body with "|" and appends the
+// secret key. Numbers use their shortest decimal form, missing fields are
+// empty.
+func expectedPayload(fields []string, secretKey string, body []byte) (string, error) {
+	var values map[string]any
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&values); err != nil {
+		return "", err
+	}
+
+	parts := make([]string, 0, len(fields)+1)
+
+	for _, field := range fields {
+		part, err := formatPayloadValue(values[field])
+		if err != nil {
+			return "", err
+		}
+
+		parts = append(parts, part)
+	}
+
+	return strings.Join(append(parts, secretKey), "|"), nil
+}
+
+func formatPayloadValue(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return "", err
+		}
+
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	default:
+		raw, err := json.Marshal(v)
+
+		return string(raw), err
+	}
+}
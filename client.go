@@ -10,6 +10,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/andyle182810/goaliniex/signer"
 )
@@ -33,6 +34,11 @@ var (
 	// HTTP / transport errors.
 	ErrHTTPFailure      = errors.New("http request failed")
 	ErrUnexpectedStatus = errors.New("unexpected http status code")
+
+	// Signature errors.
+	ErrUnknownOperation     = errors.New("unknown operation")
+	ErrOperationRequestType = errors.New("request type does not match operation")
+	ErrResponseSignature    = errors.New("invalid response signature")
 )
 
 type Logger interface {
@@ -52,6 +58,12 @@ type Client struct {
 	partnerCode string
 	secretKey   string
 	privateKey  []byte
+	responseKey []byte
+	responseAlg signer.Algorithm
+	algorithm   signer.Algorithm
+	opAlgorithm map[Operation]signer.Algorithm
+	kycGate     *kycGate
+	strict      bool
 	logger      Logger
 	debug       bool
 	httpClient  HTTPClient
@@ -75,6 +87,54 @@ func WithDebug(debug bool) Option {
 	}
 }
 
+// WithResponsePublicKey opts in to verifying signed response data against
+// Aliniex's RSA public key (PEM). Verification is off without it. The
+// signed fields are assumed, see ResponseSignatureSpec, and responses
+// without a spec are not checked.
+func WithResponsePublicKey(publicKeyPEM []byte) Option {
+	return func(c *Client) {
+		c.responseKey = publicKeyPEM
+	}
+}
+
+// WithResponseSigningAlgorithm sets the signature scheme response signatures
+// are verified with. It is independent of the request signing algorithm; the
+// default is signer.DefaultAlgorithm.
+func WithResponseSigningAlgorithm(alg signer.Algorithm) Option {
+	return func(c *Client) {
+		c.responseAlg = alg
+	}
+}
+
+// WithSigningAlgorithm sets the signature scheme used for every signed call.
+// The default is signer.DefaultAlgorithm.
+func WithSigningAlgorithm(alg signer.Algorithm) Option {
+	return func(c *Client) {
+		c.algorithm = alg
+	}
+}
+
+// WithOperationSigningAlgorithm overrides the signature scheme for a single
+// operation, taking precedence over WithSigningAlgorithm.
+func WithOperationSigningAlgorithm(op Operation, alg signer.Algorithm) Option {
+	return func(c *Client) {
+		if c.opAlgorithm == nil {
+			c.opAlgorithm = make(map[Operation]signer.Algorithm)
+		}
+
+		c.opAlgorithm[op] = alg
+	}
+}
+
+// WithStrictDecoding compares every response with the SDK structs and
+// reports unknown and missing fields in Response.Unknown and the log instead
+// of failing.
+func WithStrictDecoding(strict bool) Option {
+	return func(c *Client) {
+		c.strict = strict
+	}
+}
+
 func WithHTTPClient(client HTTPClient) Option {
 	return func(c *Client) {
 		c.httpClient = client
@@ -109,6 +169,12 @@ func NewClient(
 		partnerCode: partnerCode,
 		secretKey:   secretKey,
 		privateKey:  privateKey,
+		responseKey: nil,
+		responseAlg: signer.DefaultAlgorithm,
+		algorithm:   signer.DefaultAlgorithm,
+		opAlgorithm: nil,
+		kycGate:     newKycGate(KycGatePolicy{}), //nolint:exhaustruct // default policy
+		strict:      false,
 		httpClient:  http.DefaultClient,
 		logger:      slog.Default(),
 		debug:       false,
@@ -118,9 +184,29 @@ func NewClient(
 		opt(client)
 	}
 
+	for _, alg := range []signer.Algorithm{client.algorithm, client.responseAlg} {
+		if _, err := signer.ParseAlgorithm(string(alg)); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, alg := range client.opAlgorithm {
+		if _, err := signer.ParseAlgorithm(string(alg)); err != nil {
+			return nil, err
+		}
+	}
+
 	return client, nil
 }
 
+func (c *Client) signingAlgorithm(op Operation) signer.Algorithm {
+	if alg, ok := c.opAlgorithm[op]; ok {
+		return alg
+	}
+
+	return c.algorithm
+}
+
 func (c *Client) logDebug(msg string, attrs ...any) {
 	if c.debug {
 		c.logger.Debug(msg, attrs...)
@@ -173,6 +259,19 @@ func (c *Client) buildGETRequest(req *request, fullURL string, headers http.Head
 	return nil
 }
 
+func (c *Client) signingData(req *request) ([]byte, error) {
+	if req.SigningData != nil {
+		return req.SigningData, nil
+	}
+
+	payload, err := SignaturePayload(req.Operation, c.partnerCode, c.secretKey, req.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(payload), nil
+}
+
 func (c *Client) buildRequest(req *request) error {
 	if req == nil {
 		return ErrNilRequest
@@ -200,7 +299,12 @@ func (c *Client) buildRequest(req *request) error {
 	}
 
 	if !req.Public {
-		signature, err := signer.Sign(c.privateKey, req.SigningData)
+		signingData, err := c.signingData(req)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrRequestSign, err)
+		}
+
+		signature, err := signer.SignWithAlgorithm(c.signingAlgorithm(req.Operation), c.privateKey, signingData)
 		if err != nil {
 			return fmt.Errorf("%w: %w", ErrRequestSign, err)
 		}
@@ -241,14 +345,21 @@ func (c *Client) execute(ctx context.Context, req *request) ([]byte, error) {
 
 	httpReq.Header = req.Header
 
+	meta := ResponseMetaFrom(ctx)
+	started := time.Now()
+
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
+		meta.record(httpReq, nil, nil, started, 1)
+
 		return nil, fmt.Errorf("%w: %w", ErrHTTPFailure, err)
 	}
 
 	defer resp.Body.Close()
 
 	responseBody, err := io.ReadAll(resp.Body)
+	meta.record(httpReq, resp, responseBody, started, 1)
+
 	if err != nil {
 		return nil, err
 	}
@@ -267,3 +378,64 @@ func (c *Client) execute(ctx context.Context, req *request) ([]byte, error) {
 
 	return responseBody, nil
 }
+
+// decodeResponse unmarshals a response envelope from endpoint, recording
+// schema drift when strict decoding is on.
+func decodeResponse[T any](c *Client, endpoint string, rawResponse []byte) (*Response[T], error) {
+	response := new(Response[T])
+	if err := json.Unmarshal(rawResponse, response); err != nil {
+		return nil, err
+	}
+
+	if !c.strict {
+		return response, nil
+	}
+
+	drift, err := DetectSchemaDrift[Response[T]](endpoint, rawResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Unknown = drift
+
+	if !drift.Empty() {
+		c.logger.Info("response schema drift",
+			"endpoint", endpoint, "unknown", drift.UnknownPaths(), "missing", drift.Missing)
+	}
+
+	return response, nil
+}
+
+func verifyResponse[T any](c *Client, op Operation, response *Response[T]) error {
+	if response.Data == nil {
+		return nil
+	}
+
+	return c.verifyData(op, response.Data)
+}
+
+// verifyData checks the signature carried in data against op's response
+// spec when a response public key is configured.
+func (c *Client) verifyData(op Operation, data any) error {
+	if len(c.responseKey) == 0 {
+		return nil
+	}
+
+	spec, ok := ResponseSignatureSpec(op)
+	if !ok {
+		return nil
+	}
+
+	payload, err := spec.Payload(c.partnerCode, c.secretKey, data)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrResponseSignature, err)
+	}
+
+	signature := responseSignature(data)
+
+	if err := signer.VerifyWithAlgorithm(c.responseAlg, c.responseKey, []byte(payload), signature); err != nil {
+		return fmt.Errorf("%w: %w", ErrResponseSignature, err)
+	}
+
+	return nil
+}
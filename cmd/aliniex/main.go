@@ -0,0 +1,209 @@
+// Command aliniex is a support tool for investigating Aliniex API calls.
+//
+// Usage:
+//
+//	aliniex sign -op create-order [-request file.json] [-key private.pem] [-algorithm PSS-SHA256] [--explain]
+//
+// The request JSON is read from -request or stdin. Partner code and secret key
+// default to ALIX_PARTNER_CODE and ALIX_SECRET_KEY. With --explain the
+// canonical payload is printed with the secret masked instead of signed.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/andyle182810/goaliniex"
+	"github.com/andyle182810/goaliniex/signer"
+)
+
+var (
+	errUsage          = errors.New("usage: aliniex sign -op <operation> [-request file] [-key file] [--explain]")
+	errUnknownCommand = errors.New("unknown command")
+	errMissingOp      = errors.New("-op is required")
+	errMissingPartner = errors.New("partner code is required (-partner-code or ALIX_PARTNER_CODE)")
+	errMissingSecret  = errors.New("secret key is required (-secret-key or ALIX_SECRET_KEY)")
+	errMissingKey     = errors.New("-key is required unless --explain is set")
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "sign":
+		return runSign(args[1:], stdin, stdout)
+	default:
+		return fmt.Errorf("%w: %q\n%w", errUnknownCommand, args[0], errUsage)
+	}
+}
+
+type signOptions struct {
+	op          string
+	requestPath string
+	keyPath     string
+	algorithm   string
+	partnerCode string
+	secretKey   string
+	explain     bool
+}
+
+func parseSignFlags(args []string) (*signOptions, error) {
+	opts := &signOptions{
+		op:          "",
+		requestPath: "",
+		keyPath:     "",
+		algorithm:   "",
+		partnerCode: "",
+		secretKey:   "",
+		explain:     false,
+	}
+
+	flags := flag.NewFlagSet("sign", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.StringVar(&opts.op, "op", "", "operation to sign, one of: "+operationNames())
+	flags.StringVar(&opts.requestPath, "request", "-", "request JSON file, - for stdin")
+	flags.StringVar(&opts.keyPath, "key", "", "RSA private key PEM file")
+	flags.StringVar(&opts.algorithm, "algorithm", "", "signing algorithm (default "+string(signer.DefaultAlgorithm)+")")
+	flags.StringVar(&opts.partnerCode, "partner-code", os.Getenv("ALIX_PARTNER_CODE"), "partner code")
+	flags.StringVar(&opts.secretKey, "secret-key", os.Getenv("ALIX_SECRET_KEY"), "secret key")
+	flags.BoolVar(&opts.explain, "explain", false, "print the canonical payload with the secret masked")
+
+	if err := flags.Parse(args); err != nil {
+		return nil, fmt.Errorf("%w\n%w", err, errUsage)
+	}
+
+	if opts.op == "" {
+		return nil, errMissingOp
+	}
+
+	if opts.partnerCode == "" {
+		return nil, errMissingPartner
+	}
+
+	if !opts.explain && opts.secretKey == "" {
+		return nil, errMissingSecret
+	}
+
+	if !opts.explain && opts.keyPath == "" {
+		return nil, errMissingKey
+	}
+
+	return opts, nil
+}
+
+func runSign(args []string, stdin io.Reader, stdout io.Writer) error {
+	opts, err := parseSignFlags(args)
+	if err != nil {
+		return err
+	}
+
+	op := goaliniex.Operation(opts.op)
+
+	req, err := readRequest(op, opts.requestPath, stdin)
+	if err != nil {
+		return err
+	}
+
+	if opts.explain {
+		payload, err := goaliniex.SignaturePayload(op, opts.partnerCode, goaliniex.SecretMask, req)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintln(stdout, payload)
+
+		return err
+	}
+
+	payload, err := goaliniex.SignaturePayload(op, opts.partnerCode, opts.secretKey, req)
+	if err != nil {
+		return err
+	}
+
+	alg, err := signer.ParseAlgorithm(opts.algorithm)
+	if err != nil {
+		return err
+	}
+
+	privateKey, err := os.ReadFile(opts.keyPath)
+	if err != nil {
+		return fmt.Errorf("read private key: %w", err)
+	}
+
+	signature, err := signer.SignWithAlgorithm(alg, privateKey, []byte(payload))
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(stdout, signature)
+
+	return err
+}
+
+func readRequest(op goaliniex.Operation, path string, stdin io.Reader) (any, error) {
+	req, err := newRequest(op)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	if path == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read request: %w", err)
+	}
+
+	if err := json.Unmarshal(data, req); err != nil {
+		return nil, fmt.Errorf("decode request: %w", err)
+	}
+
+	return req, nil
+}
+
+func newRequest(op goaliniex.Operation) (any, error) {
+	switch op {
+	case goaliniex.OperationCreateOrder:
+		return new(goaliniex.CreateOrderRequest), nil
+	case goaliniex.OperationGetOrderDetails:
+		return new(goaliniex.GetOrderDetailsRequest), nil
+	case goaliniex.OperationSubmitKyc:
+		return new(goaliniex.SubmitKycRequest), nil
+	case goaliniex.OperationGetKycInformation:
+		return new(goaliniex.KycInformationRequest), nil
+	case goaliniex.OperationGetUserKyc:
+		return new(goaliniex.GetUserKycRequest), nil
+	case goaliniex.OperationGetWalletBalance:
+		return new(goaliniex.GetWalletBalanceRequest), nil
+	default:
+		return nil, fmt.Errorf("%w: %q (want one of: %s)", goaliniex.ErrUnknownOperation, op, operationNames())
+	}
+}
+
+func operationNames() string {
+	ops := goaliniex.Operations()
+	names := make([]string, 0, len(ops))
+
+	for _, op := range ops {
+		names = append(names, string(op))
+	}
+
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andyle182810/goaliniex"
+	"github.com/andyle182810/goaliniex/signer"
+)
+
+func TestRun_SignExplain(t *testing.T) {
+	t.Parallel()
+
+	stdin := strings.NewReader(`{"externalOrderId":"order-1"}`)
+	stdout := new(bytes.Buffer)
+
+	err := run([]string{
+		"sign", "-op", "get-order-details", "-partner-code", "P", "-secret-key", "S", "--explain",
+	}, stdin, stdout)
+	if err != nil {
+		t.Fatalf("run returned error: %v", err)
+	}
+
+	expected := "P|order-1|" + goaliniex.SecretMask + "\n"
+	if stdout.String() != expected {
+		t.Errorf("expected %q, got %q", expected, stdout.String())
+	}
+}
+
+func TestRun_Sign(t *testing.T) {
+	t.Parallel()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	keyPath := filepath.Join(t.TempDir(), "key.pem")
+	keyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:    "RSA PRIVATE KEY",
+		Headers: nil,
+		Bytes:   x509.MarshalPKCS1PrivateKey(privateKey),
+	})
+
+	if err := os.WriteFile(keyPath, keyPEM, 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+
+	stdout := new(bytes.Buffer)
+
+	err = run([]string{
+		"sign", "-op", "get-wallet-balance", "-partner-code", "P", "-secret-key", "S", "-key", keyPath,
+	}, strings.NewReader(`{"currency":"USDT"}`), stdout)
+	if err != nil {
+		t.Fatalf("run returned error: %v", err)
+	}
+
+	if strings.TrimSpace(stdout.String()) == "" {
+		t.Error("expected signature output")
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		args     []string
+		expected error
+	}{
+		{name: "no command", args: nil, expected: errUsage},
+		{name: "unknown command", args: []string{"verify"}, expected: errUnknownCommand},
+		{name: "missing op", args: []string{"sign", "-partner-code", "P", "--explain"}, expected: errMissingOp},
+		{
+			name:     "missing key",
+			args:     []string{"sign", "-op", "get-order-details", "-partner-code", "P", "-secret-key", "S"},
+			expected: errMissingKey,
+		},
+		{
+			name: "unknown algorithm",
+			args: []string{
+				"sign", "-op", "get-order-details", "-partner-code", "P", "-secret-key", "S", "-key", "k", "-algorithm", "x",
+			},
+			expected: signer.ErrUnsupportedAlgo,
+		},
+		{
+			name:     "unknown op",
+			args:     []string{"sign", "-op", "nope", "-partner-code", "P", "--explain"},
+			expected: goaliniex.ErrUnknownOperation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := run(tc.args, strings.NewReader("{}"), new(bytes.Buffer))
+			if !errors.Is(err, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
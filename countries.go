@@ -0,0 +1,452 @@
+package goaliniex
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Country is an ISO 3166-1 entry. Kosovo is included under its user-assigned
+// code XK.
+type Country struct {
+	Alpha2  CountryCode
+	Alpha3  string
+	Numeric string
+	Name    string
+	// DialCodes lists the international dialing codes without "+", the
+	// primary one first. Territories without a code have none.
+	DialCodes []string
+	// Currency is the default ISO 4217 currency, empty for Antarctica.
+	Currency FiatCurrency
+}
+
+// DialCode returns the primary dialing code, or "" if there is none.
+func (c Country) DialCode() string {
+	if len(c.DialCodes) == 0 {
+		return ""
+	}
+
+	return c.DialCodes[0]
+}
+
+func (c Country) clone() Country {
+	c.DialCodes = slices.Clone(c.DialCodes)
+
+	return c
+}
+
+type countryIndex struct {
+	countries []Country
+	byAlpha2  map[string]int
+	byAlpha3  map[string]int
+	byNumeric map[string]int
+	byName    map[string]int
+	byDial    map[string][]int
+}
+
+//nolint:gochecknoglobals // built once on first use, read-only afterwards
+var countryRegistry = sync.OnceValue(func() *countryIndex {
+	countries := countryData()
+	index := &countryIndex{
+		countries: countries,
+		byAlpha2:  make(map[string]int, len(countries)),
+		byAlpha3:  make(map[string]int, len(countries)),
+		byNumeric: make(map[string]int, len(countries)),
+		byName:    make(map[string]int, len(countries)),
+		byDial:    map[string][]int{},
+	}
+
+	for i, country := range countries {
+		index.byAlpha2[string(country.Alpha2)] = i
+		index.byAlpha3[country.Alpha3] = i
+		index.byName[strings.ToLower(country.Name)] = i
+
+		if country.Numeric != "" {
+			index.byNumeric[country.Numeric] = i
+		}
+
+		for _, dialCode := range country.DialCodes {
+			index.byDial[dialCode] = append(index.byDial[dialCode], i)
+		}
+	}
+
+	return index
+})
+
+func (idx *countryIndex) find(index map[string]int, key string) (Country, bool) {
+	i, ok := index[key]
+	if !ok {
+		return Country{}, false //nolint:exhaustruct // not found
+	}
+
+	return idx.countries[i].clone(), true
+}
+
+func normalizeCountryKey(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Countries returns every registry entry.
+func Countries() []Country {
+	countries := countryRegistry().countries
+	result := make([]Country, len(countries))
+
+	for i, country := range countries {
+		result[i] = country.clone()
+	}
+
+	return result
+}
+
+// LookupCountry resolves an alpha-2, alpha-3 or numeric code, ignoring case
+// and surrounding spaces.
+func LookupCountry(code string) (Country, bool) {
+	key := normalizeCountryKey(code)
+
+	switch {
+	case isDigits(key):
+		return CountryByNumeric(key)
+	case len(key) == 2: //nolint:mnd // alpha-2
+		return CountryByAlpha2(key)
+	default:
+		return CountryByAlpha3(key)
+	}
+}
+
+func CountryByAlpha2(code string) (Country, bool) {
+	registry := countryRegistry()
+
+	return registry.find(registry.byAlpha2, normalizeCountryKey(code))
+}
+
+func CountryByAlpha3(code string) (Country, bool) {
+	registry := countryRegistry()
+
+	return registry.find(registry.byAlpha3, normalizeCountryKey(code))
+}
+
+// CountryByNumeric resolves an ISO 3166-1 numeric code; leading zeros may be
+// omitted.
+func CountryByNumeric(code string) (Country, bool) {
+	const numericLength = 3
+
+	key := strings.TrimSpace(code)
+	if len(key) < numericLength {
+		key = strings.Repeat("0", numericLength-len(key)) + key
+	}
+
+	registry := countryRegistry()
+
+	return registry.find(registry.byNumeric, key)
+}
+
+// CountryByName matches the English short name case-insensitively.
+func CountryByName(name string) (Country, bool) {
+	registry := countryRegistry()
+
+	return registry.find(registry.byName, strings.ToLower(strings.TrimSpace(name)))
+}
+
+// CountriesByDialCode returns the countries sharing a dialing code, such as
+// the NANP members for "1". A leading "+" or "00" is ignored.
+func CountriesByDialCode(dialCode string) []Country {
+	key := strings.TrimSpace(dialCode)
+	if trimmed, found := strings.CutPrefix(key, "+"); found {
+		key = trimmed
+	} else {
+		key = strings.TrimPrefix(key, "00")
+	}
+
+	registry := countryRegistry()
+	indexes := registry.byDial[key]
+	result := make([]Country, 0, len(indexes))
+
+	for _, i := range indexes {
+		result = append(result, registry.countries[i].clone())
+	}
+
+	return result
+}
+
+// CountriesByCurrency returns the countries using currency by default.
+func CountriesByCurrency(currency FiatCurrency) []Country {
+	var result []Country
+
+	if currency == "" {
+		return result
+	}
+
+	for _, country := range countryRegistry().countries {
+		if strings.EqualFold(string(country.Currency), string(currency)) {
+			result = append(result, country.clone())
+		}
+	}
+
+	return result
+}
+
+func country(alpha2, alpha3, numeric, name, currency string, dialCodes ...string) Country {
+	return Country{
+		Alpha2:    CountryCode(alpha2),
+		Alpha3:    alpha3,
+		Numeric:   numeric,
+		Name:      name,
+		DialCodes: dialCodes,
+		Currency:  FiatCurrency(currency),
+	}
+}
+
+func countryData() []Country {
+	return []Country{
+		country("AF", "AFG", "004", "Afghanistan", "AFN", "93"),
+		country("AX", "ALA", "248", "Åland Islands", "EUR", "358"),
+		country("AL", "ALB", "008", "Albania", "ALL", "355"),
+		country("DZ", "DZA", "012", "Algeria", "DZD", "213"),
+		country("AS", "ASM", "016", "American Samoa", "USD", "1"),
+		country("AD", "AND", "020", "Andorra", "EUR", "376"),
+		country("AO", "AGO", "024", "Angola", "AOA", "244"),
+		country("AI", "AIA", "660", "Anguilla", "XCD", "1"),
+		country("AQ", "ATA", "010", "Antarctica", "", "672"),
+		country("AG", "ATG", "028", "Antigua and Barbuda", "XCD", "1"),
+		country("AR", "ARG", "032", "Argentina", "ARS", "54"),
+		country("AM", "ARM", "051", "Armenia", "AMD", "374"),
+		country("AW", "ABW", "533", "Aruba", "AWG", "297"),
+		country("AU", "AUS", "036", "Australia", "AUD", "61"),
+		country("AT", "AUT", "040", "Austria", "EUR", "43"),
+		country("AZ", "AZE", "031", "Azerbaijan", "AZN", "994"),
+		country("BS", "BHS", "044", "Bahamas", "BSD", "1"),
+		country("BH", "BHR", "048", "Bahrain", "BHD", "973"),
+		country("BD", "BGD", "050", "Bangladesh", "BDT", "880"),
+		country("BB", "BRB", "052", "Barbados", "BBD", "1"),
+		country("BY", "BLR", "112", "Belarus", "BYN", "375"),
+		country("BE", "BEL", "056", "Belgium", "EUR", "32"),
+		country("BZ", "BLZ", "084", "Belize", "BZD", "501"),
+		country("BJ", "BEN", "204", "Benin", "XOF", "229"),
+		country("BM", "BMU", "060", "Bermuda", "BMD", "1"),
+		country("BT", "BTN", "064", "Bhutan", "BTN", "975"),
+		country("BO", "BOL", "068", "Bolivia", "BOB", "591"),
+		country("BQ", "BES", "535", "Bonaire, Sint Eustatius and Saba", "USD", "599"),
+		country("BA", "BIH", "070", "Bosnia and Herzegovina", "BAM", "387"),
+		country("BW", "BWA", "072", "Botswana", "BWP", "267"),
+		country("BV", "BVT", "074", "Bouvet Island", "NOK"),
+		country("BR", "BRA", "076", "Brazil", "BRL", "55"),
+		country("IO", "IOT", "086", "British Indian Ocean Territory", "USD", "246"),
+		country("BN", "BRN", "096", "Brunei Darussalam", "BND", "673"),
+		country("BG", "BGR", "100", "Bulgaria", "EUR", "359"),
+		country("BF", "BFA", "854", "Burkina Faso", "XOF", "226"),
+		country("BI", "BDI", "108", "Burundi", "BIF", "257"),
+		country("CV", "CPV", "132", "Cabo Verde", "CVE", "238"),
+		country("KH", "KHM", "116", "Cambodia", "KHR", "855"),
+		country("CM", "CMR", "120", "Cameroon", "XAF", "237"),
+		country("CA", "CAN", "124", "Canada", "CAD", "1"),
+		country("KY", "CYM", "136", "Cayman Islands", "KYD", "1"),
+		country("CF", "CAF", "140", "Central African Republic", "XAF", "236"),
+		country("TD", "TCD", "148", "Chad", "XAF", "235"),
+		country("CL", "CHL", "152", "Chile", "CLP", "56"),
+		country("CN", "CHN", "156", "China", "CNY", "86"),
+		country("CX", "CXR", "162", "Christmas Island", "AUD", "61"),
+		country("CC", "CCK", "166", "Cocos (Keeling) Islands", "AUD", "61"),
+		country("CO", "COL", "170", "Colombia", "COP", "57"),
+		country("KM", "COM", "174", "Comoros", "KMF", "269"),
+		country("CG", "COG", "178", "Congo", "XAF", "242"),
+		country("CD", "COD", "180", "Congo, Democratic Republic of the", "CDF", "243"),
+		country("CK", "COK", "184", "Cook Islands", "NZD", "682"),
+		country("CR", "CRI", "188", "Costa Rica", "CRC", "506"),
+		country("CI", "CIV", "384", "Côte d'Ivoire", "XOF", "225"),
+		country("HR", "HRV", "191", "Croatia", "EUR", "385"),
+		country("CU", "CUB", "192", "Cuba", "CUP", "53"),
+		country("CW", "CUW", "531", "Curaçao", "XCG", "599"),
+		country("CY", "CYP", "196", "Cyprus", "EUR", "357"),
+		country("CZ", "CZE", "203", "Czechia", "CZK", "420"),
+		country("DK", "DNK", "208", "Denmark", "DKK", "45"),
+		country("DJ", "DJI", "262", "Djibouti", "DJF", "253"),
+		country("DM", "DMA", "212", "Dominica", "XCD", "1"),
+		country("DO", "DOM", "214", "Dominican Republic", "DOP", "1"),
+		country("EC", "ECU", "218", "Ecuador", "USD", "593"),
+		country("EG", "EGY", "818", "Egypt", "EGP", "20"),
+		country("SV", "SLV", "222", "El Salvador", "USD", "503"),
+		country("GQ", "GNQ", "226", "Equatorial Guinea", "XAF", "240"),
+		country("ER", "ERI", "232", "Eritrea", "ERN", "291"),
+		country("EE", "EST", "233", "Estonia", "EUR", "372"),
+		country("SZ", "SWZ", "748", "Eswatini", "SZL", "268"),
+		country("ET", "ETH", "231", "Ethiopia", "ETB", "251"),
+		country("FK", "FLK", "238", "Falkland Islands (Malvinas)", "FKP", "500"),
+		country("FO", "FRO", "234", "Faroe Islands", "DKK", "298"),
+		country("FJ", "FJI", "242", "Fiji", "FJD", "679"),
+		country("FI", "FIN", "246", "Finland", "EUR", "358"),
+		country("FR", "FRA", "250", "France", "EUR", "33"),
+		country("GF", "GUF", "254", "French Guiana", "EUR", "594"),
+		country("PF", "PYF", "258", "French Polynesia", "XPF", "689"),
+		country("TF", "ATF", "260", "French Southern Territories", "EUR"),
+		country("GA", "GAB", "266", "Gabon", "XAF", "241"),
+		country("GM", "GMB", "270", "Gambia", "GMD", "220"),
+		country("GE", "GEO", "268", "Georgia", "GEL", "995"),
+		country("DE", "DEU", "276", "Germany", "EUR", "49"),
+		country("GH", "GHA", "288", "Ghana", "GHS", "233"),
+		country("GI", "GIB", "292", "Gibraltar", "GIP", "350"),
+		country("GR", "GRC", "300", "Greece", "EUR", "30"),
+		country("GL", "GRL", "304", "Greenland", "DKK", "299"),
+		country("GD", "GRD", "308", "Grenada", "XCD", "1"),
+		country("GP", "GLP", "312", "Guadeloupe", "EUR", "590"),
+		country("GU", "GUM", "316", "Guam", "USD", "1"),
+		country("GT", "GTM", "320", "Guatemala", "GTQ", "502"),
+		country("GG", "GGY", "831", "Guernsey", "GBP", "44"),
+		country("GN", "GIN", "324", "Guinea", "GNF", "224"),
+		country("GW", "GNB", "624", "Guinea-Bissau", "XOF", "245"),
+		country("GY", "GUY", "328", "Guyana", "GYD", "592"),
+		country("HT", "HTI", "332", "Haiti", "HTG", "509"),
+		country("HM", "HMD", "334", "Heard Island and McDonald Islands", "AUD"),
+		country("VA", "VAT", "336", "Holy See", "EUR", "379", "39"),
+		country("HN", "HND", "340", "Honduras", "HNL", "504"),
+		country("HK", "HKG", "344", "Hong Kong", "HKD", "852"),
+		country("HU", "HUN", "348", "Hungary", "HUF", "36"),
+		country("IS", "ISL", "352", "Iceland", "ISK", "354"),
+		country("IN", "IND", "356", "India", "INR", "91"),
+		country("ID", "IDN", "360", "Indonesia", "IDR", "62"),
+		country("IR", "IRN", "364", "Iran", "IRR", "98"),
+		country("IQ", "IRQ", "368", "Iraq", "IQD", "964"),
+		country("IE", "IRL", "372", "Ireland", "EUR", "353"),
+		country("IM", "IMN", "833", "Isle of Man", "GBP", "44"),
+		country("IL", "ISR", "376", "Israel", "ILS", "972"),
+		country("IT", "ITA", "380", "Italy", "EUR", "39"),
+		country("JM", "JAM", "388", "Jamaica", "JMD", "1"),
+		country("JP", "JPN", "392", "Japan", "JPY", "81"),
+		country("JE", "JEY", "832", "Jersey", "GBP", "44"),
+		country("JO", "JOR", "400", "Jordan", "JOD", "962"),
+		country("KZ", "KAZ", "398", "Kazakhstan", "KZT", "7"),
+		country("KE", "KEN", "404", "Kenya", "KES", "254"),
+		country("KI", "KIR", "296", "Kiribati", "AUD", "686"),
+		country("KP", "PRK", "408", "Korea, Democratic People's Republic of", "KPW", "850"),
+		country("KR", "KOR", "410", "Korea, Republic of", "KRW", "82"),
+		country("XK", "XKX", "", "Kosovo", "EUR", "383"),
+		country("KW", "KWT", "414", "Kuwait", "KWD", "965"),
+		country("KG", "KGZ", "417", "Kyrgyzstan", "KGS", "996"),
+		country("LA", "LAO", "418", "Lao People's Democratic Republic", "LAK", "856"),
+		country("LV", "LVA", "428", "Latvia", "EUR", "371"),
+		country("LB", "LBN", "422", "Lebanon", "LBP", "961"),
+		country("LS", "LSO", "426", "Lesotho", "LSL", "266"),
+		country("LR", "LBR", "430", "Liberia", "LRD", "231"),
+		country("LY", "LBY", "434", "Libya", "LYD", "218"),
+		country("LI", "LIE", "438", "Liechtenstein", "CHF", "423"),
+		country("LT", "LTU", "440", "Lithuania", "EUR", "370"),
+		country("LU", "LUX", "442", "Luxembourg", "EUR", "352"),
+		country("MO", "MAC", "446", "Macao", "MOP", "853"),
+		country("MK", "MKD", "807", "North Macedonia", "MKD", "389"),
+		country("MG", "MDG", "450", "Madagascar", "MGA", "261"),
+		country("MW", "MWI", "454", "Malawi", "MWK", "265"),
+		country("MY", "MYS", "458", "Malaysia", "MYR", "60"),
+		country("MV", "MDV", "462", "Maldives", "MVR", "960"),
+		country("ML", "MLI", "466", "Mali", "XOF", "223"),
+		country("MT", "MLT", "470", "Malta", "EUR", "356"),
+		country("MH", "MHL", "584", "Marshall Islands", "USD", "692"),
+		country("MQ", "MTQ", "474", "Martinique", "EUR", "596"),
+		country("MR", "MRT", "478", "Mauritania", "MRU", "222"),
+		country("MU", "MUS", "480", "Mauritius", "MUR", "230"),
+		country("YT", "MYT", "175", "Mayotte", "EUR", "262"),
+		country("MX", "MEX", "484", "Mexico", "MXN", "52"),
+		country("FM", "FSM", "583", "Micronesia", "USD", "691"),
+		country("MD", "MDA", "498", "Moldova", "MDL", "373"),
+		country("MC", "MCO", "492", "Monaco", "EUR", "377"),
+		country("MN", "MNG", "496", "Mongolia", "MNT", "976"),
+		country("ME", "MNE", "499", "Montenegro", "EUR", "382"),
+		country("MS", "MSR", "500", "Montserrat", "XCD", "1"),
+		country("MA", "MAR", "504", "Morocco", "MAD", "212"),
+		country("MZ", "MOZ", "508", "Mozambique", "MZN", "258"),
+		country("MM", "MMR", "104", "Myanmar", "MMK", "95"),
+		country("NA", "NAM", "516", "Namibia", "NAD", "264"),
+		country("NR", "NRU", "520", "Nauru", "AUD", "674"),
+		country("NP", "NPL", "524", "Nepal", "NPR", "977"),
+		country("NL", "NLD", "528", "Netherlands", "EUR", "31"),
+		country("NC", "NCL", "540", "New Caledonia", "XPF", "687"),
+		country("NZ", "NZL", "554", "New Zealand", "NZD", "64"),
+		country("NI", "NIC", "558", "Nicaragua", "NIO", "505"),
+		country("NE", "NER", "562", "Niger", "XOF", "227"),
+		country("NG", "NGA", "566", "Nigeria", "NGN", "234"),
+		country("NU", "NIU", "570", "Niue", "NZD", "683"),
+		country("NF", "NFK", "574", "Norfolk Island", "AUD", "672"),
+		country("MP", "MNP", "580", "Northern Mariana Islands", "USD", "1"),
+		country("NO", "NOR", "578", "Norway", "NOK", "47"),
+		country("OM", "OMN", "512", "Oman", "OMR", "968"),
+		country("PK", "PAK", "586", "Pakistan", "PKR", "92"),
+		country("PW", "PLW", "585", "Palau", "USD", "680"),
+		country("PS", "PSE", "275", "Palestine, State of", "ILS", "970"),
+		country("PA", "PAN", "591", "Panama", "PAB", "507"),
+		country("PG", "PNG", "598", "Papua New Guinea", "PGK", "675"),
+		country("PY", "PRY", "600", "Paraguay", "PYG", "595"),
+		country("PE", "PER", "604", "Peru", "PEN", "51"),
+		country("PH", "PHL", "608", "Philippines", "PHP", "63"),
+		country("PN", "PCN", "612", "Pitcairn", "NZD", "64"),
+		country("PL", "POL", "616", "Poland", "PLN", "48"),
+		country("PT", "PRT", "620", "Portugal", "EUR", "351"),
+		country("PR", "PRI", "630", "Puerto Rico", "USD", "1"),
+		country("QA", "QAT", "634", "Qatar", "QAR", "974"),
+		country("RE", "REU", "638", "Réunion", "EUR", "262"),
+		country("RO", "ROU", "642", "Romania", "RON", "40"),
+		country("RU", "RUS", "643", "Russian Federation", "RUB", "7"),
+		country("RW", "RWA", "646", "Rwanda", "RWF", "250"),
+		country("BL", "BLM", "652", "Saint Barthélemy", "EUR", "590"),
+		country("SH", "SHN", "654", "Saint Helena, Ascension and Tristan da Cunha", "SHP", "290"),
+		country("KN", "KNA", "659", "Saint Kitts and Nevis", "XCD", "1"),
+		country("LC", "LCA", "662", "Saint Lucia", "XCD", "1"),
+		country("MF", "MAF", "663", "Saint Martin (French part)", "EUR", "590"),
+		country("PM", "SPM", "666", "Saint Pierre and Miquelon", "EUR", "508"),
+		country("VC", "VCT", "670", "Saint Vincent and the Grenadines", "XCD", "1"),
+		country("WS", "WSM", "882", "Samoa", "WST", "685"),
+		country("SM", "SMR", "674", "San Marino", "EUR", "378"),
+		country("ST", "STP", "678", "Sao Tome and Principe", "STN", "239"),
+		country("SA", "SAU", "682", "Saudi Arabia", "SAR", "966"),
+		country("SN", "SEN", "686", "Senegal", "XOF", "221"),
+		country("RS", "SRB", "688", "Serbia", "RSD", "381"),
+		country("SC", "SYC", "690", "Seychelles", "SCR", "248"),
+		country("SL", "SLE", "694", "Sierra Leone", "SLE", "232"),
+		country("SG", "SGP", "702", "Singapore", "SGD", "65"),
+		country("SX", "SXM", "534", "Sint Maarten (Dutch part)", "XCG", "1"),
+		country("SK", "SVK", "703", "Slovakia", "EUR", "421"),
+		country("SI", "SVN", "705", "Slovenia", "EUR", "386"),
+		country("SB", "SLB", "090", "Solomon Islands", "SBD", "677"),
+		country("SO", "SOM", "706", "Somalia", "SOS", "252"),
+		country("ZA", "ZAF", "710", "South Africa", "ZAR", "27"),
+		country("GS", "SGS", "239", "South Georgia and the South Sandwich Islands", "GBP", "500"),
+		country("SS", "SSD", "728", "South Sudan", "SSP", "211"),
+		country("ES", "ESP", "724", "Spain", "EUR", "34"),
+		country("LK", "LKA", "144", "Sri Lanka", "LKR", "94"),
+		country("SD", "SDN", "729", "Sudan", "SDG", "249"),
+		country("SR", "SUR", "740", "Suriname", "SRD", "597"),
+		country("SJ", "SJM", "744", "Svalbard and Jan Mayen", "NOK", "47"),
+		country("SE", "SWE", "752", "Sweden", "SEK", "46"),
+		country("CH", "CHE", "756", "Switzerland", "CHF", "41"),
+		country("SY", "SYR", "760", "Syrian Arab Republic", "SYP", "963"),
+		country("TW", "TWN", "158", "Taiwan", "TWD", "886"),
+		country("TJ", "TJK", "762", "Tajikistan", "TJS", "992"),
+		country("TZ", "TZA", "834", "Tanzania", "TZS", "255"),
+		country("TH", "THA", "764", "Thailand", "THB", "66"),
+		country("TL", "TLS", "626", "Timor-Leste", "USD", "670"),
+		country("TG", "TGO", "768", "Togo", "XOF", "228"),
+		country("TK", "TKL", "772", "Tokelau", "NZD", "690"),
+		country("TO", "TON", "776", "Tonga", "TOP", "676"),
+		country("TT", "TTO", "780", "Trinidad and Tobago", "TTD", "1"),
+		country("TN", "TUN", "788", "Tunisia", "TND", "216"),
+		country("TR", "TUR", "792", "Türkiye", "TRY", "90"),
+		country("TM", "TKM", "795", "Turkmenistan", "TMT", "993"),
+		country("TC", "TCA", "796", "Turks and Caicos Islands", "USD", "1"),
+		country("TV", "TUV", "798", "Tuvalu", "AUD", "688"),
+		country("UG", "UGA", "800", "Uganda", "UGX", "256"),
+		country("UA", "UKR", "804", "Ukraine", "UAH", "380"),
+		country("AE", "ARE", "784", "United Arab Emirates", "AED", "971"),
+		country("GB", "GBR", "826", "United Kingdom", "GBP", "44"),
+		country("US", "USA", "840", "United States of America", "USD", "1"),
+		country("UM", "UMI", "581", "United States Minor Outlying Islands", "USD"),
+		country("UY", "URY", "858", "Uruguay", "UYU", "598"),
+		country("UZ", "UZB", "860", "Uzbekistan", "UZS", "998"),
+		country("VU", "VUT", "548", "Vanuatu", "VUV", "678"),
+		country("VE", "VEN", "862", "Venezuela", "VES", "58"),
+		country("VN", "VNM", "704", "Viet Nam", "VND", "84"),
+		country("VG", "VGB", "092", "Virgin Islands (British)", "USD", "1"),
+		country("VI", "VIR", "850", "Virgin Islands (U.S.)", "USD", "1"),
+		country("WF", "WLF", "876", "Wallis and Futuna", "XPF", "681"),
+		country("EH", "ESH", "732", "Western Sahara", "MAD", "212"),
+		country("YE", "YEM", "887", "Yemen", "YER", "967"),
+		country("ZM", "ZMB", "894", "Zambia", "ZMW", "260"),
+		country("ZW", "ZWE", "716", "Zimbabwe", "ZWG", "263"),
+	}
+}
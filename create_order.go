@@ -2,10 +2,7 @@ package goaliniex
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"net/http"
-	"strconv"
 )
 
 type Currency string
@@ -40,6 +37,18 @@ const (
 	OrderStatusFail                    OrderStatus = "FAIL"
 )
 
+// IsFinal reports whether an order in status s can no longer change.
+func (s OrderStatus) IsFinal() bool {
+	switch s {
+	case OrderStatusSuccess, OrderStatusError, OrderStatusFail:
+		return true
+	case OrderStatusAwaitingPayment, OrderStatusPaymentCompleted, OrderStatusProcessingTokenTransfer:
+		return false
+	default:
+		return false
+	}
+}
+
 type CreateOrderRequest struct {
 	Currency          Currency     `json:"currency"`
 	FiatAmount        float64      `json:"fiatAmount"`
@@ -69,25 +78,17 @@ type CreateOrderResponse struct {
 	Signature       string        `json:"signature"`
 }
 
-func (c *Client) CreateOrder(ctx context.Context, req *CreateOrderRequest) (*Response[CreateOrderResponse], error) {
-	signaturePayload := fmt.Sprintf(
-		"%s|%s|%s|%s|%s|%s|%s|%s|%s",
-		c.partnerCode,
-		req.ExternalOrderID,
-		req.Currency,
-		strconv.FormatFloat(req.FiatAmount, 'f', -1, 64),
-		req.BankCode,
-		req.BankAccountNumber,
-		req.Content,
-		req.UserEmail,
-		c.secretKey,
-	)
+func CreateOrderSignaturePayload(partnerCode, secretKey string, req *CreateOrderRequest) (string, error) {
+	return SignaturePayload(OperationCreateOrder, partnerCode, secretKey, req)
+}
 
+func (c *Client) CreateOrder(ctx context.Context, req *CreateOrderRequest) (*Response[CreateOrderResponse], error) {
 	apiRequest := request{
 		Method:      http.MethodPost,
 		Endpoint:    "/api/v2/orders/create-sell-order",
 		Params:      req,
-		SigningData: []byte(signaturePayload),
+		Operation:   OperationCreateOrder,
+		SigningData: nil,
 		Header:      nil,
 		Body:        nil,
 		FullURL:     "",
@@ -99,8 +100,12 @@ func (c *Client) CreateOrder(ctx context.Context, req *CreateOrderRequest) (*Res
 		return nil, err
 	}
 
-	response := new(Response[CreateOrderResponse])
-	if err := json.Unmarshal(rawResponse, response); err != nil {
+	response, err := decodeResponse[CreateOrderResponse](c, apiRequest.Endpoint, rawResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifyResponse(c, OperationCreateOrder, response); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,147 @@
+package goaliniex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrQRLookup             = errors.New("qr code lookup failed")
+	ErrUnsupportedQRCountry = errors.New("no fiat currency for qr country")
+	ErrQRAmountRequired     = errors.New("qr code has no amount and none was given")
+	ErrQRAmountMismatch     = errors.New("amount differs from the qr code amount")
+)
+
+// FiatCurrencyForCountry returns the fiat currency orders to a country's
+// bank accounts are paid in.
+func FiatCurrencyForCountry(country CountryCode) (FiatCurrency, bool) {
+	switch country {
+	case CountryCodeVN:
+		return FiatCurrencyVND, true
+	case CountryCodePH:
+		return FiatCurrencyPHP, true
+	case CountryCodeTH:
+		return FiatCurrencyTHB, true
+	case CountryCodeGE:
+		return FiatCurrencyGEL, true
+	case CountryCodeBR:
+		return FiatCurrencyBRL, true
+	case CountryCodeAR:
+		return FiatCurrencyARS, true
+	case CountryCodePE:
+		return FiatCurrencyPEN, true
+	default:
+		return "", false
+	}
+}
+
+// CreateOrderFromQROptions carries the order fields a QR code does not.
+type CreateOrderFromQROptions struct {
+	Currency         Currency
+	ExternalOrderID  string
+	UserEmail        string
+	UserKYCVerified  bool
+	WebhookSecretKey string
+	// Content defaults to the QR code's purpose, if any.
+	Content    string
+	ExtendInfo any
+	// Amount is required when the QR code carries none. When both are set
+	// they must match unless AllowAmountOverride is true.
+	Amount              float64
+	AllowAmountOverride bool
+	// GateKyc creates the order through CreateOrderForUser, ignoring
+	// UserKYCVerified.
+	GateKyc bool
+}
+
+// QROrder is the outcome of CreateOrderFromQR.
+type QROrder struct {
+	QRCode  *QRCodeInfo
+	Request *CreateOrderRequest
+	Order   *Response[CreateOrderResponse]
+}
+
+// CreateOrderFromQR looks up qrContent with GetQRCodeInfo, maps it onto a
+// CreateOrderRequest and creates the sell order. QRCode and Request are set
+// on the result even when creating the order fails.
+func (c *Client) CreateOrderFromQR(ctx context.Context, qrContent string, opts *CreateOrderFromQROptions) (*QROrder, error) {
+	if opts == nil {
+		return nil, ErrNilRequest
+	}
+
+	lookup, err := c.GetQRCodeInfo(ctx, &GetQRCodeInfoRequest{QRContent: qrContent})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrQRLookup, err)
+	}
+
+	if !lookup.Success || lookup.Data == nil {
+		return nil, fmt.Errorf("%w: %s (errorCode=%d)", ErrQRLookup, lookup.Message, lookup.ErrorCode)
+	}
+
+	result := &QROrder{QRCode: lookup.Data, Request: nil, Order: nil}
+
+	req, err := orderRequestFromQR(lookup.Data, opts)
+	if err != nil {
+		return result, err
+	}
+
+	result.Request = req
+
+	if opts.GateKyc {
+		result.Order, err = c.CreateOrderForUser(ctx, req)
+	} else {
+		result.Order, err = c.CreateOrder(ctx, req)
+	}
+
+	return result, err
+}
+
+func orderRequestFromQR(info *QRCodeInfo, opts *CreateOrderFromQROptions) (*CreateOrderRequest, error) {
+	fiat, ok := FiatCurrencyForCountry(info.CountryCode)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedQRCountry, info.CountryCode)
+	}
+
+	amount, err := qrOrderAmount(info.Amount, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	content := opts.Content
+	if content == "" {
+		content, _ = info.AdditionalData["purpose"].(string)
+	}
+
+	return &CreateOrderRequest{
+		Currency:          opts.Currency,
+		FiatAmount:        amount,
+		FiatCurrency:      fiat,
+		BankCode:          info.BankCode,
+		BankAccountNumber: info.BankAccountNumber,
+		ExternalOrderID:   opts.ExternalOrderID,
+		WebhookSecretKey:  opts.WebhookSecretKey,
+		UserEmail:         opts.UserEmail,
+		UserKYCVerified:   opts.UserKYCVerified,
+		Content:           content,
+		ExtendInfo:        opts.ExtendInfo,
+	}, nil
+}
+
+func qrOrderAmount(qrAmount float64, opts *CreateOrderFromQROptions) (float64, error) {
+	switch {
+	case opts.Amount < 0:
+		return 0, fmt.Errorf("%w: amount %s is negative", ErrInvalidParams, FormatSignatureValue(opts.Amount))
+	case qrAmount <= 0 && opts.Amount == 0:
+		return 0, ErrQRAmountRequired
+	case qrAmount <= 0:
+		return opts.Amount, nil
+	case opts.Amount == 0 || opts.Amount == qrAmount:
+		return qrAmount, nil
+	case opts.AllowAmountOverride:
+		return opts.Amount, nil
+	default:
+		return 0, fmt.Errorf("%w: qr %s, given %s", ErrQRAmountMismatch,
+			FormatSignatureValue(qrAmount), FormatSignatureValue(opts.Amount))
+	}
+}
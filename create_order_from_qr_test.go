@@ -0,0 +1,168 @@
+package goaliniex_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/andyle182810/goaliniex"
+)
+
+func qrLookupBody(country string, amount float64) string {
+	return `{"success":true,"message":"Success","errorCode":0,"data":{"bankAccountNumber":"888812345678",` +
+		`"bankCode":"Techcombank","countryCode":"` + country + `","qrType":"vietqr","amount":` +
+		goaliniex.FormatSignatureValue(amount) + `,"additionalData":{"purpose":"Thanh toan"}}}`
+}
+
+func qrOrderOptions(amount float64) *goaliniex.CreateOrderFromQROptions {
+	return &goaliniex.CreateOrderFromQROptions{
+		Currency:            goaliniex.CurrencyUSDT,
+		ExternalOrderID:     "QR-ORDER-1",
+		UserEmail:           "jane@example.com",
+		UserKYCVerified:     true,
+		WebhookSecretKey:    "",
+		Content:             "",
+		ExtendInfo:          nil,
+		Amount:              amount,
+		AllowAmountOverride: false,
+		GateKyc:             false,
+	}
+}
+
+//nolint:bodyclose // Response bodies closed by client
+func TestClient_CreateOrderFromQR(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		qrAmount float64
+		amount   float64
+		override bool
+		expected float64
+	}{
+		{name: "amount from qr", qrAmount: 50000, amount: 0, override: false, expected: 50000},
+		{name: "amount from options", qrAmount: 0, amount: 75000, override: false, expected: 75000},
+		{name: "matching amounts", qrAmount: 50000, amount: 50000, override: false, expected: 50000},
+		{name: "override", qrAmount: 50000, amount: 60000, override: true, expected: 60000},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := &recordingHTTPClient{
+				response: sequenceResponses(
+					mockResponse(http.StatusOK, qrLookupBody("VN", tc.qrAmount)),
+					mockResponse(http.StatusOK, gateOrderBody),
+				),
+			}
+
+			client, err := newTestClientWithMock(recorder)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			opts := qrOrderOptions(tc.amount)
+			opts.AllowAmountOverride = tc.override
+
+			result, err := client.CreateOrderFromQR(context.Background(), "qr-content", opts)
+			if err != nil {
+				t.Fatalf("CreateOrderFromQR returned error: %v", err)
+			}
+
+			if result.QRCode.BankCode != "Techcombank" || result.Order.Data == nil {
+				t.Errorf("unexpected result %+v", result)
+			}
+
+			body := recorder.lastBody(t)
+			if body["fiatAmount"] != tc.expected || body["fiatCurrency"] != "VND" {
+				t.Errorf("expected %v VND, got %v %v", tc.expected, body["fiatAmount"], body["fiatCurrency"])
+			}
+
+			if body["bankAccountNumber"] != "888812345678" || body["content"] != "Thanh toan" {
+				t.Errorf("unexpected order body %v", body)
+			}
+		})
+	}
+}
+
+//nolint:bodyclose // Response bodies closed by client
+func TestClient_CreateOrderFromQR_Errors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		body     string
+		amount   float64
+		expected error
+	}{
+		{
+			name:     "lookup failure",
+			body:     `{"success":false,"message":"Invalid QR","data":null,"errorCode":400}`,
+			amount:   0,
+			expected: goaliniex.ErrQRLookup,
+		},
+		{name: "unsupported country", body: qrLookupBody("US", 10), amount: 0, expected: goaliniex.ErrUnsupportedQRCountry},
+		{name: "missing amount", body: qrLookupBody("VN", 0), amount: 0, expected: goaliniex.ErrQRAmountRequired},
+		{name: "amount mismatch", body: qrLookupBody("VN", 50000), amount: 1, expected: goaliniex.ErrQRAmountMismatch},
+		{name: "negative amount", body: qrLookupBody("VN", 0), amount: -5, expected: goaliniex.ErrInvalidParams},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := &recordingHTTPClient{
+				response: func() *http.Response { return mockResponse(http.StatusOK, tc.body) },
+			}
+
+			client, err := newTestClientWithMock(recorder)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			_, err = client.CreateOrderFromQR(context.Background(), "qr-content", qrOrderOptions(tc.amount))
+			if !errors.Is(err, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, err)
+			}
+
+			if len(recorder.requests) != 1 {
+				t.Errorf("expected no order to be created, got %d requests", len(recorder.requests))
+			}
+		})
+	}
+
+	client, err := newTestClientWithMock(&mockHTTPClient{response: nil, err: nil})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := client.CreateOrderFromQR(context.Background(), "qr", nil); !errors.Is(err, goaliniex.ErrNilRequest) {
+		t.Errorf("expected ErrNilRequest, got %v", err)
+	}
+}
+
+func TestFiatCurrencyForCountry(t *testing.T) {
+	t.Parallel()
+
+	expected := map[goaliniex.CountryCode]goaliniex.FiatCurrency{
+		goaliniex.CountryCodeVN: goaliniex.FiatCurrencyVND,
+		goaliniex.CountryCodePH: goaliniex.FiatCurrencyPHP,
+		goaliniex.CountryCodeTH: goaliniex.FiatCurrencyTHB,
+		goaliniex.CountryCodeGE: goaliniex.FiatCurrencyGEL,
+		goaliniex.CountryCodeBR: goaliniex.FiatCurrencyBRL,
+		goaliniex.CountryCodeAR: goaliniex.FiatCurrencyARS,
+		goaliniex.CountryCodePE: goaliniex.FiatCurrencyPEN,
+	}
+
+	for country, fiat := range expected {
+		if got, ok := goaliniex.FiatCurrencyForCountry(country); !ok || got != fiat {
+			t.Errorf("%s: expected %s, got %s", country, fiat, got)
+		}
+	}
+
+	if _, ok := goaliniex.FiatCurrencyForCountry("US"); ok {
+		t.Error("expected no currency for US")
+	}
+}
@@ -13,6 +13,10 @@ import (
 func getWebhookSecretKey(t *testing.T) string {
 	t.Helper()
 
+	if offline(t) {
+		return replayWebhookSecretKey
+	}
+
 	webhookSecret := strings.TrimSpace(os.Getenv("ALIX_WEBHOOK_SECRET_KEY"))
 	if webhookSecret == "" {
 		t.Skip("skipping test: ALIX_WEBHOOK_SECRET_KEY not set")
@@ -36,7 +40,7 @@ func TestIntegration_CreateOrder_ValidUSDTVND(t *testing.T) {
 		FiatCurrency:      goaliniex.FiatCurrencyVND,
 		BankCode:          getTestBankCode(t),
 		BankAccountNumber: getTestBankAccountNumber(t),
-		ExternalOrderID:   "test-order-" + time.Now().Format("20060102150405"),
+		ExternalOrderID:   testOrderID(t, "test-order-"),
 		WebhookSecretKey:  webhookSecret,
 		UserEmail:         getTestEmail(t),
 		UserKYCVerified:   true,
@@ -91,7 +95,7 @@ func TestIntegration_CreateOrder_WithKYCVerified(t *testing.T) {
 		FiatCurrency:      goaliniex.FiatCurrencyVND,
 		BankCode:          "970407",
 		BankAccountNumber: "888812345678",
-		ExternalOrderID:   "test-kyc-order-" + time.Now().Format("20060102150405"),
+		ExternalOrderID:   testOrderID(t, "test-kyc-order-"),
 		WebhookSecretKey:  webhookSecret,
 		UserEmail:         getTestEmail(t),
 		UserKYCVerified:   true,
@@ -160,7 +164,7 @@ func TestIntegration_CreateOrder_DifferentFiatCurrencies(t *testing.T) {
 				FiatCurrency:      testCase.fiatCurrency,
 				BankCode:          testCase.bankCode,
 				BankAccountNumber: "123456789",
-				ExternalOrderID:   "test-" + testCase.name + "-" + time.Now().Format("20060102150405"),
+				ExternalOrderID:   testOrderID(t, "test-"+testCase.name+"-"),
 				WebhookSecretKey:  webhookSecret,
 				UserEmail:         getTestEmail(t),
 				UserKYCVerified:   true,
@@ -210,7 +214,7 @@ func TestIntegration_CreateOrder_DifferentCryptoCurrencies(t *testing.T) {
 				FiatCurrency:      goaliniex.FiatCurrencyVND,
 				BankCode:          "970407",
 				BankAccountNumber: "888812345678",
-				ExternalOrderID:   "test-" + string(currency) + "-" + time.Now().Format("20060102150405"),
+				ExternalOrderID:   testOrderID(t, "test-"+string(currency)+"-"),
 				WebhookSecretKey:  webhookSecret,
 				UserEmail:         getTestEmail(t),
 				UserKYCVerified:   true,
@@ -250,7 +254,7 @@ func TestIntegration_CreateOrder_InvalidBankCode(t *testing.T) {
 		FiatCurrency:      goaliniex.FiatCurrencyVND,
 		BankCode:          "INVALID_BANK",
 		BankAccountNumber: "888812345678",
-		ExternalOrderID:   "test-invalid-bank-" + time.Now().Format("20060102150405"),
+		ExternalOrderID:   testOrderID(t, "test-invalid-bank-"),
 		WebhookSecretKey:  webhookSecret,
 		UserEmail:         getTestEmail(t),
 		UserKYCVerified:   true,
@@ -287,7 +291,7 @@ func TestIntegration_CreateOrder_ZeroAmount(t *testing.T) {
 		FiatCurrency:      goaliniex.FiatCurrencyVND,
 		BankCode:          "970407",
 		BankAccountNumber: "888812345678",
-		ExternalOrderID:   "test-zero-amount-" + time.Now().Format("20060102150405"),
+		ExternalOrderID:   testOrderID(t, "test-zero-amount-"),
 		WebhookSecretKey:  webhookSecret,
 		UserEmail:         getTestEmail(t),
 		UserKYCVerified:   true,
@@ -324,7 +328,7 @@ func TestIntegration_CreateOrder_NegativeAmount(t *testing.T) {
 		FiatCurrency:      goaliniex.FiatCurrencyVND,
 		BankCode:          "970407",
 		BankAccountNumber: "888812345678",
-		ExternalOrderID:   "test-negative-amount-" + time.Now().Format("20060102150405"),
+		ExternalOrderID:   testOrderID(t, "test-negative-amount-"),
 		WebhookSecretKey:  webhookSecret,
 		UserEmail:         getTestEmail(t),
 		UserKYCVerified:   true,
@@ -355,7 +359,7 @@ func TestIntegration_CreateOrder_DuplicateExternalOrderID(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
 
-	externalOrderID := "test-duplicate-" + time.Now().Format("20060102150405")
+	externalOrderID := testOrderID(t, "test-duplicate-")
 
 	req := &goaliniex.CreateOrderRequest{
 		Currency:          goaliniex.CurrencyUSDT,
@@ -446,7 +450,7 @@ func TestIntegration_CreateOrder_InvalidEmail(t *testing.T) {
 		FiatCurrency:      goaliniex.FiatCurrencyVND,
 		BankCode:          "970407",
 		BankAccountNumber: "888812345678",
-		ExternalOrderID:   "test-invalid-email-" + time.Now().Format("20060102150405"),
+		ExternalOrderID:   testOrderID(t, "test-invalid-email-"),
 		WebhookSecretKey:  webhookSecret,
 		UserEmail:         "not-an-email",
 		UserKYCVerified:   true,
@@ -483,7 +487,7 @@ func TestIntegration_CreateOrder_ContextTimeout(t *testing.T) {
 		FiatCurrency:      goaliniex.FiatCurrencyVND,
 		BankCode:          "970407",
 		BankAccountNumber: "888812345678",
-		ExternalOrderID:   "test-timeout-" + time.Now().Format("20060102150405"),
+		ExternalOrderID:   testOrderID(t, "test-timeout-"),
 		WebhookSecretKey:  webhookSecret,
 		UserEmail:         getTestEmail(t),
 		UserKYCVerified:   true,
@@ -519,7 +523,7 @@ func TestIntegration_CreateOrder_ContextCancellation(t *testing.T) {
 		FiatCurrency:      goaliniex.FiatCurrencyVND,
 		BankCode:          "970407",
 		BankAccountNumber: "888812345678",
-		ExternalOrderID:   "test-cancel-" + time.Now().Format("20060102150405"),
+		ExternalOrderID:   testOrderID(t, "test-cancel-"),
 		WebhookSecretKey:  webhookSecret,
 		UserEmail:         getTestEmail(t),
 		UserKYCVerified:   true,
@@ -552,7 +556,7 @@ func TestIntegration_CreateOrder_ResponseDataFields(t *testing.T) {
 		FiatCurrency:      goaliniex.FiatCurrencyVND,
 		BankCode:          "970407",
 		BankAccountNumber: "888812345678",
-		ExternalOrderID:   "test-fields-" + time.Now().Format("20060102150405"),
+		ExternalOrderID:   testOrderID(t, "test-fields-"),
 		WebhookSecretKey:  webhookSecret,
 		UserEmail:         getTestEmail(t),
 		UserKYCVerified:   true,
@@ -616,7 +620,7 @@ func TestIntegration_CreateOrder_LongTimeout(t *testing.T) {
 		FiatCurrency:      goaliniex.FiatCurrencyVND,
 		BankCode:          "970407",
 		BankAccountNumber: "888812345678",
-		ExternalOrderID:   "test-long-timeout-" + time.Now().Format("20060102150405"),
+		ExternalOrderID:   testOrderID(t, "test-long-timeout-"),
 		WebhookSecretKey:  webhookSecret,
 		UserEmail:         getTestEmail(t),
 		UserKYCVerified:   true,
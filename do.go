@@ -0,0 +1,74 @@
+package goaliniex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var ErrInvalidCall = errors.New("invalid call")
+
+// Call describes a request to an endpoint the SDK has no method for yet.
+type Call struct {
+	// Method defaults to POST. GET calls send Params as query parameters
+	// and must be Public.
+	Method   string
+	Endpoint string
+	// Params is the request body: a struct with JSON tags or a map.
+	Params any
+	// SigningFields are the JSON fields of Params signed in order, joined by
+	// "|" with the secret key last. FieldPartnerCode stands for the
+	// client's partner code.
+	SigningFields []string
+	// Public calls are sent without partnerCode and signature.
+	Public bool
+}
+
+// Do sends call with the client's signing, partner code, logging and error
+// handling and decodes the data into T.
+func Do[T any](ctx context.Context, c *Client, call Call) (*Response[T], error) {
+	method := call.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	switch {
+	case call.Endpoint == "":
+		return nil, fmt.Errorf("%w: endpoint is required", ErrInvalidCall)
+	case !call.Public && method == http.MethodGet:
+		return nil, fmt.Errorf("%w: signed calls must not use GET", ErrInvalidCall)
+	case !call.Public && len(call.SigningFields) == 0:
+		return nil, fmt.Errorf("%w: signed calls need SigningFields", ErrInvalidCall)
+	}
+
+	apiRequest := request{
+		Method:      method,
+		Endpoint:    call.Endpoint,
+		Params:      call.Params,
+		Operation:   "",
+		SigningData: nil,
+		Header:      nil,
+		Body:        nil,
+		FullURL:     "",
+		Public:      call.Public,
+	}
+
+	if !call.Public {
+		spec := SignatureSpec{Fields: call.SigningFields, Secret: SecretLast}
+
+		payload, err := spec.Payload(c.partnerCode, c.secretKey, call.Params)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrRequestSign, err)
+		}
+
+		apiRequest.SigningData = []byte(payload)
+	}
+
+	rawResponse, err := c.execute(ctx, &apiRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeResponse[T](c, call.Endpoint, rawResponse)
+}
@@ -0,0 +1,206 @@
+package goaliniex_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/andyle182810/goaliniex"
+	"github.com/andyle182810/goaliniex/aliniextest"
+	"github.com/andyle182810/goaliniex/signer"
+)
+
+type refundRequest struct {
+	ExternalOrderID string  `json:"externalOrderId"`
+	Amount          float64 `json:"amount"`
+}
+
+type refund struct {
+	RefundID string `json:"refundId"`
+	Status   string `json:"status"`
+}
+
+func TestDo_SignedPost(t *testing.T) {
+	t.Parallel()
+
+	httpClient := &recordingHTTPClient{
+		response: func() *http.Response {
+			return mockResponse(http.StatusOK, //nolint:bodyclose // Response body closed by client
+				`{"success":true,"message":"Success","errorCode":0,"data":{"refundId":"R-1","status":"PENDING"}}`)
+		},
+	}
+
+	client, err := newTestClientWithMock(httpClient)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	resp, err := goaliniex.Do[refund](context.Background(), client, goaliniex.Call{
+		Method:        "",
+		Endpoint:      "/api/v2/orders/refund",
+		Params:        &refundRequest{ExternalOrderID: "ORDER-1", Amount: 12.5},
+		SigningFields: []string{goaliniex.FieldPartnerCode, "externalOrderId", "amount"},
+		Public:        false,
+	})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+
+	if resp.Data.RefundID != "R-1" || resp.Data.Status != "PENDING" {
+		t.Errorf("unexpected data: %+v", resp.Data)
+	}
+
+	request := httpClient.requests[0]
+	if request.Method != http.MethodPost || request.URL.Path != "/api/v2/orders/refund" {
+		t.Errorf("request = %s %s", request.Method, request.URL)
+	}
+
+	body := httpClient.lastBody(t)
+	if body["partnerCode"] != "TEST_PARTNER" || body["externalOrderId"] != "ORDER-1" {
+		t.Errorf("unexpected body: %v", body)
+	}
+
+	signature, _ := body["signature"].(string)
+
+	err = signer.Verify(testPublicKey(t), []byte("TEST_PARTNER|ORDER-1|12.5|TEST_SECRET"), signature)
+	if err != nil {
+		t.Errorf("signature does not cover the signing fields: %v", err)
+	}
+}
+
+func TestDo_PublicGet(t *testing.T) {
+	t.Parallel()
+
+	httpClient := &recordingHTTPClient{
+		response: func() *http.Response {
+			return mockResponse(http.StatusOK, //nolint:bodyclose // Response body closed by client
+				`{"success":true,"message":"Success","errorCode":0,"data":["VND","PHP"]}`)
+		},
+	}
+
+	client, err := newTestClientWithMock(httpClient)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	resp, err := goaliniex.Do[[]string](context.Background(), client, goaliniex.Call{
+		Method:        http.MethodGet,
+		Endpoint:      "/api/v2/public/fiat-currencies",
+		Params:        map[string]any{"country": "VN"},
+		SigningFields: nil,
+		Public:        true,
+	})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+
+	if len(*resp.Data) != 2 {
+		t.Errorf("unexpected data: %v", *resp.Data)
+	}
+
+	request := httpClient.requests[0]
+	if request.Method != http.MethodGet || request.URL.RawQuery != "country=VN" {
+		t.Errorf("request = %s %s", request.Method, request.URL)
+	}
+}
+
+func TestDo_InvalidCall(t *testing.T) {
+	t.Parallel()
+
+	client, err := newTestClientWithMock(&mockHTTPClient{response: nil, err: errConnectionRefused})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	testCases := []struct {
+		name string
+		call goaliniex.Call
+		want error
+	}{
+		{
+			name: "missing endpoint",
+			call: goaliniex.Call{Method: "", Endpoint: "", Params: nil, SigningFields: nil, Public: true},
+			want: goaliniex.ErrInvalidCall,
+		},
+		{
+			name: "signed GET",
+			call: goaliniex.Call{
+				Method: http.MethodGet, Endpoint: "/x", Params: nil,
+				SigningFields: []string{goaliniex.FieldPartnerCode}, Public: false,
+			},
+			want: goaliniex.ErrInvalidCall,
+		},
+		{
+			name: "signed without fields",
+			call: goaliniex.Call{Method: "", Endpoint: "/x", Params: nil, SigningFields: nil, Public: false},
+			want: goaliniex.ErrInvalidCall,
+		},
+		{
+			name: "unknown signing field",
+			call: goaliniex.Call{
+				Method: "", Endpoint: "/x", Params: &refundRequest{ExternalOrderID: "A", Amount: 1},
+				SigningFields: []string{"refundId"}, Public: false,
+			},
+			want: goaliniex.ErrRequestSign,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := goaliniex.Do[refund](context.Background(), client, testCase.call)
+			if !errors.Is(err, testCase.want) {
+				t.Errorf("expected %v, got %v", testCase.want, err)
+			}
+		})
+	}
+}
+
+// TestDo_MatchesServerSignature calls a wrapped endpoint through Do to show
+// the signature matches what the server expects.
+func TestDo_MatchesServerSignature(t *testing.T) {
+	t.Parallel()
+
+	privateKey, publicKey, err := aliniextest.GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %v", err)
+	}
+
+	server, err := aliniextest.NewServer(aliniextest.Config{
+		PartnerCode: "TEST_PARTNER",
+		SecretKey:   "TEST_SECRET",
+		PublicKey:   publicKey,
+		Algorithm:   "",
+		ResponseKey: nil,
+		Balances:    map[goaliniex.Currency]float64{goaliniex.CurrencyBTC: 0.25},
+		OrderTTL:    0,
+		Now:         nil,
+	})
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+
+	t.Cleanup(server.Close)
+
+	client, err := server.NewClient(privateKey)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	resp, err := goaliniex.Do[goaliniex.WalletBalance](context.Background(), client, goaliniex.Call{
+		Method:        http.MethodPost,
+		Endpoint:      aliniextest.EndpointWalletBalance,
+		Params:        map[string]any{"currency": "BTC"},
+		SigningFields: []string{goaliniex.FieldPartnerCode, "currency"},
+		Public:        false,
+	})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+
+	if !resp.Success || resp.Data.Balance != 0.25 {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
@@ -0,0 +1,137 @@
+package goaliniex
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// EMVCo merchant-presented QR top-level tags.
+const (
+	emvTagPayloadFormat     = "00"
+	emvTagPointOfInitiation = "01"
+	emvTagMerchantAccountLo = 26
+	emvTagMerchantAccountHi = 51
+	emvTagMerchantCategory  = "52"
+	emvTagCurrency          = "53"
+	emvTagAmount            = "54"
+	emvTagCountryCode       = "58"
+	emvTagMerchantName      = "59"
+	emvTagMerchantCity      = "60"
+	emvTagPostalCode        = "61"
+	emvTagAdditionalData    = "62"
+	emvTagCRC               = "63"
+
+	emvPayloadFormat = "01"
+	emvStaticQR      = "11"
+	emvDynamicQR     = "12"
+
+	emvIDLength     = 2
+	emvLengthDigits = 2
+	emvCRCLength    = 4
+)
+
+var (
+	ErrInvalidQRCode     = errors.New("invalid emvco qr payload")
+	ErrQRCodeChecksum    = errors.New("emvco qr checksum mismatch")
+	ErrUnsupportedQRCode = errors.New("unsupported qr code format")
+)
+
+type emvField struct {
+	id    string
+	value string
+}
+
+type emvFields []emvField
+
+func (f emvFields) get(id string) string {
+	for _, field := range f {
+		if field.id == id {
+			return field.value
+		}
+	}
+
+	return ""
+}
+
+// parseEMVFields decodes a sequence of ID/length/value records.
+func parseEMVFields(data string) (emvFields, error) {
+	var fields emvFields
+
+	for pos := 0; pos < len(data); {
+		if pos+emvIDLength+emvLengthDigits > len(data) {
+			return nil, fmt.Errorf("%w: truncated field header at offset %d", ErrInvalidQRCode, pos)
+		}
+
+		id := data[pos : pos+emvIDLength]
+		lengthText := data[pos+emvIDLength : pos+emvIDLength+emvLengthDigits]
+
+		length, err := strconv.Atoi(lengthText)
+		if err != nil || !isDigits(id) || !isDigits(lengthText) {
+			return nil, fmt.Errorf("%w: malformed field header %q at offset %d", ErrInvalidQRCode, data[pos:pos+4], pos)
+		}
+
+		start := pos + emvIDLength + emvLengthDigits
+		if start+length > len(data) {
+			return nil, fmt.Errorf("%w: field %s overruns payload", ErrInvalidQRCode, id)
+		}
+
+		fields = append(fields, emvField{id: id, value: data[start : start+length]})
+		pos = start + length
+	}
+
+	return fields, nil
+}
+
+// emvCRC computes the CRC-16/CCITT-FALSE checksum EMVCo uses for tag 63.
+func emvCRC(data string) string {
+	const (
+		polynomial = 0x1021
+		initial    = 0xFFFF
+		topBit     = 0x8000
+	)
+
+	crc := uint16(initial)
+
+	for i := range len(data) {
+		crc ^= uint16(data[i]) << 8
+
+		for range 8 {
+			if crc&topBit != 0 {
+				crc = crc<<1 ^ polynomial
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return fmt.Sprintf("%04X", crc)
+}
+
+// decodeEMVPayload parses a merchant-presented payload and validates its
+// format indicator and trailing CRC.
+func decodeEMVPayload(content string) (emvFields, error) {
+	content = strings.TrimSpace(content)
+
+	if !strings.HasPrefix(content, emvTagPayloadFormat+"02"+emvPayloadFormat) {
+		return nil, ErrUnsupportedQRCode
+	}
+
+	fields, err := parseEMVFields(content)
+	if err != nil {
+		return nil, err
+	}
+
+	last := fields[len(fields)-1]
+	if last.id != emvTagCRC || len(last.value) != emvCRCLength {
+		return nil, fmt.Errorf("%w: missing crc field", ErrInvalidQRCode)
+	}
+
+	expected := emvCRC(content[:len(content)-emvCRCLength])
+	if !strings.EqualFold(last.value, expected) {
+		return nil, fmt.Errorf("%w: got %s, want %s", ErrQRCodeChecksum, last.value, expected)
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,129 @@
+package experimental
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/andyle182810/goaliniex"
+)
+
+// EndpointCreateBuyOrder is the assumed path of the buy order endpoint.
+const EndpointCreateBuyOrder = "/api/v2/orders/create-buy-order"
+
+var ErrInvalidBuyOrder = errors.New("invalid buy order")
+
+// CreateBuyOrderRequest creates an on-ramp order: the user pays FiatAmount
+// to the bank account in the response and receives Currency on Network at
+// WalletAddress.
+type CreateBuyOrderRequest struct {
+	Currency         goaliniex.Currency     `json:"currency"`
+	Network          goaliniex.Network      `json:"network"`
+	FiatAmount       float64                `json:"fiatAmount"`
+	FiatCurrency     goaliniex.FiatCurrency `json:"fiatCurrency"`
+	WalletAddress    string                 `json:"walletAddress"`
+	ExternalOrderID  string                 `json:"externalOrderId"`
+	WebhookSecretKey string                 `json:"webhookSecretKey"`
+	UserEmail        string                 `json:"userEmail"`
+	UserKYCVerified  bool                   `json:"userKycVerified"`
+	ExtendInfo       any                    `json:"extendInfo"`
+}
+
+// CreateBuyOrderResponse mirrors goaliniex.OrderDetails. BankTransfer is the
+// account the user pays into and TokenTransfer the delivery to the user's
+// wallet.
+type CreateBuyOrderResponse struct {
+	ExternalOrderID string                  `json:"externalOrderId"`
+	Type            goaliniex.OrderType     `json:"type"`
+	FiatAmount      float64                 `json:"fiatAmount"`
+	PaidAmount      float64                 `json:"paidAmount"`
+	TokenTransfer   goaliniex.TokenTransfer `json:"tokenTransfer"`
+	BankTransfer    goaliniex.BankTransfer  `json:"bankTransfer"`
+	Fees            goaliniex.Fees          `json:"fees"`
+	Status          goaliniex.OrderStatus   `json:"status"`
+	Descriptions    string                  `json:"descriptions"`
+	CreatedAt       string                  `json:"createdAt"`
+	ExpiresAt       string                  `json:"expiresAt"`
+	Signature       string                  `json:"signature"`
+}
+
+// ExpiresAtTime parses ExpiresAt.
+func (o *CreateBuyOrderResponse) ExpiresAtTime() (time.Time, error) {
+	return goaliniex.ParseOrderTime(o.ExpiresAt)
+}
+
+// createBuyOrderFields are the signed fields, assumed to follow create-order.
+func createBuyOrderFields() []string {
+	return []string{
+		goaliniex.FieldPartnerCode,
+		"externalOrderId",
+		"currency",
+		"network",
+		"fiatAmount",
+		"walletAddress",
+		"userEmail",
+	}
+}
+
+func CreateBuyOrderSignaturePayload(partnerCode, secretKey string, req *CreateBuyOrderRequest) (string, error) {
+	spec := goaliniex.SignatureSpec{Fields: createBuyOrderFields(), Secret: goaliniex.SecretLast}
+
+	return spec.Payload(partnerCode, secretKey, req)
+}
+
+// Validate checks the order before it is sent, most importantly that the
+// wallet address matches the network, since tokens sent to a malformed
+// address are lost. Errors wrap ErrInvalidBuyOrder and, for the address,
+// goaliniex.ErrInvalidWalletAddress.
+func (r *CreateBuyOrderRequest) Validate() error {
+	if r == nil {
+		return goaliniex.ErrNilRequest
+	}
+
+	var errs []error
+
+	if strings.TrimSpace(r.ExternalOrderID) == "" {
+		errs = append(errs, fmt.Errorf("%w: externalOrderId: %w", ErrInvalidBuyOrder, goaliniex.ErrMissingField))
+	}
+
+	if r.FiatAmount <= 0 {
+		errs = append(errs, fmt.Errorf("%w: fiatAmount must be positive, got %v", ErrInvalidBuyOrder, r.FiatAmount))
+	}
+
+	if r.FiatCurrency == "" {
+		errs = append(errs, fmt.Errorf("%w: fiatCurrency: %w", ErrInvalidBuyOrder, goaliniex.ErrMissingField))
+	}
+
+	if err := r.Network.ValidateCurrency(r.Currency); err != nil {
+		errs = append(errs, fmt.Errorf("%w: %w", ErrInvalidBuyOrder, err))
+	}
+
+	if slices.Contains(goaliniex.Networks(), r.Network) {
+		if err := r.Network.ValidateAddress(r.WalletAddress); err != nil {
+			errs = append(errs, fmt.Errorf("%w: walletAddress: %w", ErrInvalidBuyOrder, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// CreateBuyOrder validates and creates a buy order through c. Track it with
+// GetOrderDetails like a sell order.
+func CreateBuyOrder(
+	ctx context.Context, c *goaliniex.Client, req *CreateBuyOrderRequest,
+) (*goaliniex.Response[CreateBuyOrderResponse], error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	return goaliniex.Do[CreateBuyOrderResponse](ctx, c, goaliniex.Call{
+		Method:        "",
+		Endpoint:      EndpointCreateBuyOrder,
+		Params:        req,
+		SigningFields: createBuyOrderFields(),
+		Public:        false,
+	})
+}
@@ -0,0 +1,160 @@
+package experimental_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/andyle182810/goaliniex"
+	"github.com/andyle182810/goaliniex/aliniextest"
+	"github.com/andyle182810/goaliniex/experimental"
+)
+
+const (
+	testTronAddress = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	testEVMAddress  = "0xdAC17F958D2ee523a2206206994597C13D831ec7"
+)
+
+func buyOrderRequest() *experimental.CreateBuyOrderRequest {
+	return &experimental.CreateBuyOrderRequest{
+		Currency:         goaliniex.CurrencyUSDT,
+		Network:          goaliniex.NetworkTRC20,
+		FiatAmount:       520000,
+		FiatCurrency:     goaliniex.FiatCurrencyVND,
+		WalletAddress:    testTronAddress,
+		ExternalOrderID:  "BUY-1",
+		WebhookSecretKey: "",
+		UserEmail:        "user@example.com",
+		UserKYCVerified:  true,
+		ExtendInfo:       nil,
+	}
+}
+
+func TestCreateBuyOrder(t *testing.T) {
+	t.Parallel()
+
+	server, client := newFakeServer(t)
+
+	resp, err := experimental.CreateBuyOrder(context.Background(), client, buyOrderRequest())
+	if err != nil {
+		t.Fatalf("CreateBuyOrder: %v", err)
+	}
+
+	if !resp.Success {
+		t.Fatalf("request failed: %+v", resp)
+	}
+
+	if resp.Data.Type != goaliniex.OrderTypeBuy || resp.Data.TokenTransfer.WalletAddress != testTronAddress {
+		t.Errorf("unexpected order: %+v", resp.Data)
+	}
+
+	if calls := server.Calls(aliniextest.EndpointCreateBuyOrder); calls != 1 {
+		t.Errorf("buy order calls = %d, want 1", calls)
+	}
+}
+
+func TestCreateBuyOrderSignaturePayload(t *testing.T) {
+	t.Parallel()
+
+	req := buyOrderRequest()
+	req.WebhookSecretKey = "whsec"
+
+	payload, err := experimental.CreateBuyOrderSignaturePayload("P", "S", req)
+	if err != nil {
+		t.Fatalf("CreateBuyOrderSignaturePayload: %v", err)
+	}
+
+	if want := "P|BUY-1|USDT|TRC20|520000|" + testTronAddress + "|user@example.com|S"; payload != want {
+		t.Errorf("payload = %q, want %q", payload, want)
+	}
+}
+
+func TestCreateBuyOrder_RejectsBadAddressBeforeSending(t *testing.T) {
+	t.Parallel()
+
+	server, client := newFakeServer(t)
+
+	req := buyOrderRequest()
+	req.WalletAddress = testEVMAddress
+
+	_, err := experimental.CreateBuyOrder(context.Background(), client, req)
+	if !errors.Is(err, goaliniex.ErrInvalidWalletAddress) || !errors.Is(err, experimental.ErrInvalidBuyOrder) {
+		t.Fatalf("expected ErrInvalidWalletAddress, got %v", err)
+	}
+
+	if calls := server.Calls(aliniextest.EndpointCreateBuyOrder); calls != 0 {
+		t.Error("request sent despite invalid address")
+	}
+}
+
+func TestCreateBuyOrderRequest_Validate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		modify func(r *experimental.CreateBuyOrderRequest)
+		want   error
+	}{
+		{name: "valid", modify: func(_ *experimental.CreateBuyOrderRequest) {}, want: nil},
+		{
+			name: "ETH on ERC20",
+			modify: func(r *experimental.CreateBuyOrderRequest) {
+				r.Currency, r.Network, r.WalletAddress = goaliniex.CurrencyETH, goaliniex.NetworkERC20, testEVMAddress
+			},
+			want: nil,
+		},
+		{
+			name:   "missing order id",
+			modify: func(r *experimental.CreateBuyOrderRequest) { r.ExternalOrderID = " " },
+			want:   goaliniex.ErrMissingField,
+		},
+		{
+			name:   "zero amount",
+			modify: func(r *experimental.CreateBuyOrderRequest) { r.FiatAmount = 0 },
+			want:   experimental.ErrInvalidBuyOrder,
+		},
+		{
+			name:   "missing fiat currency",
+			modify: func(r *experimental.CreateBuyOrderRequest) { r.FiatCurrency = "" },
+			want:   goaliniex.ErrMissingField,
+		},
+		{
+			name:   "unknown network",
+			modify: func(r *experimental.CreateBuyOrderRequest) { r.Network = "SOLANA" },
+			want:   goaliniex.ErrUnknownNetwork,
+		},
+		{
+			name:   "currency not on network",
+			modify: func(r *experimental.CreateBuyOrderRequest) { r.Currency = goaliniex.CurrencyBTC },
+			want:   goaliniex.ErrUnsupportedNetwork,
+		},
+		{
+			name:   "empty address",
+			modify: func(r *experimental.CreateBuyOrderRequest) { r.WalletAddress = "" },
+			want:   goaliniex.ErrInvalidWalletAddress,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := buyOrderRequest()
+			tc.modify(req)
+
+			err := req.Validate()
+
+			switch {
+			case tc.want == nil && err != nil:
+				t.Errorf("expected valid request, got %v", err)
+			case tc.want != nil && !errors.Is(err, tc.want):
+				t.Errorf("expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+
+	var nilRequest *experimental.CreateBuyOrderRequest
+	if err := nilRequest.Validate(); !errors.Is(err, goaliniex.ErrNilRequest) {
+		t.Errorf("expected ErrNilRequest, got %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package experimental
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/andyle182810/goaliniex"
+)
+
+// EndpointCancelOrder is the assumed path of the cancel order endpoint.
+const EndpointCancelOrder = "/api/v2/orders/cancel"
+
+// Assumed statuses of cancelled orders and of orders that ran out of time.
+// goaliniex.OrderStatus.IsFinal does not know them; use IsFinal.
+const (
+	OrderStatusCancelled goaliniex.OrderStatus = "CANCELLED"
+	OrderStatusExpired   goaliniex.OrderStatus = "EXPIRED"
+)
+
+// ErrCancelUnavailable reports a cancellation refused for a reason that may
+// pass, such as rate limiting or a server error.
+var ErrCancelUnavailable = errors.New("order cancellation unavailable")
+
+// IsFinal is goaliniex.OrderStatus.IsFinal extended with the assumed
+// cancelled and expired statuses.
+func IsFinal(status goaliniex.OrderStatus) bool {
+	return status.IsFinal() || status == OrderStatusCancelled || status == OrderStatusExpired
+}
+
+type CancelOrderRequest struct {
+	ExternalOrderID string `json:"externalOrderId"`
+	// Reason is recorded with the cancellation and may be empty.
+	Reason string `json:"reason"`
+}
+
+// cancelOrderFields are the signed fields, assumed to follow order details.
+func cancelOrderFields() []string {
+	return []string{goaliniex.FieldPartnerCode, "externalOrderId", "reason"}
+}
+
+func CancelOrderSignaturePayload(partnerCode, secretKey string, req *CancelOrderRequest) (string, error) {
+	spec := goaliniex.SignatureSpec{Fields: cancelOrderFields(), Secret: goaliniex.SecretLast}
+
+	return spec.Payload(partnerCode, secretKey, req)
+}
+
+// CancelOrder cancels an order that is still AWAITING_PAYMENT through c. The
+// returned order is expected to have status OrderStatusCancelled; orders past
+// that point are refused with success false.
+func CancelOrder(
+	ctx context.Context, c *goaliniex.Client, req *CancelOrderRequest,
+) (*goaliniex.Response[goaliniex.OrderDetails], error) {
+	if req == nil {
+		return nil, goaliniex.ErrNilRequest
+	}
+
+	if strings.TrimSpace(req.ExternalOrderID) == "" {
+		return nil, fmt.Errorf("externalOrderId: %w", goaliniex.ErrMissingField)
+	}
+
+	return goaliniex.Do[goaliniex.OrderDetails](ctx, c, goaliniex.Call{
+		Method:        "",
+		Endpoint:      EndpointCancelOrder,
+		Params:        req,
+		SigningFields: cancelOrderFields(),
+		Public:        false,
+	})
+}
+
+// NewOrderCanceller returns a goaliniex.OrderCanceller for an ExpirySweeper
+// that cancels through CancelOrder with reason.
+//
+// Replies with success false are final refusals wrapping
+// goaliniex.ErrOrderCancelFailed, except error codes 429 and 5xx, which
+// wrap ErrCancelUnavailable and are retried. HTTP 4xx statuses other than
+// 408 and 429 are final too; transport errors and other statuses are
+// retried.
+func NewOrderCanceller(c *goaliniex.Client, reason string) goaliniex.OrderCanceller {
+	return func(ctx context.Context, externalOrderID string) error {
+		var meta goaliniex.ResponseMeta
+
+		resp, err := CancelOrder(goaliniex.WithResponseMeta(ctx, &meta), c, &CancelOrderRequest{
+			ExternalOrderID: externalOrderID,
+			Reason:          reason,
+		})
+
+		switch {
+		case err != nil && isPermanentStatus(meta.StatusCode):
+			return fmt.Errorf("%w: %w", goaliniex.ErrOrderCancelFailed, err)
+		case err != nil:
+			return err
+		case resp.Success:
+			return nil
+		case isTransientCode(resp.ErrorCode):
+			return fmt.Errorf("%w: code=%d message=%s", ErrCancelUnavailable, resp.ErrorCode, resp.Message)
+		default:
+			return fmt.Errorf("%w: code=%d message=%s", goaliniex.ErrOrderCancelFailed, resp.ErrorCode, resp.Message)
+		}
+	}
+}
+
+func isPermanentStatus(statusCode int) bool {
+	return statusCode >= http.StatusBadRequest && statusCode < http.StatusInternalServerError &&
+		statusCode != http.StatusRequestTimeout && statusCode != http.StatusTooManyRequests
+}
+
+func isTransientCode(errorCode int) bool {
+	return errorCode == http.StatusTooManyRequests ||
+		(errorCode >= http.StatusInternalServerError && errorCode < 600)
+}
@@ -0,0 +1,160 @@
+package experimental_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/andyle182810/goaliniex"
+	"github.com/andyle182810/goaliniex/aliniextest"
+	"github.com/andyle182810/goaliniex/experimental"
+)
+
+func TestCancelOrder(t *testing.T) {
+	t.Parallel()
+
+	server, client := newFakeServer(t)
+	createOrders(t, client, 1, "user@example.com")
+
+	resp, err := experimental.CancelOrder(context.Background(), client,
+		&experimental.CancelOrderRequest{ExternalOrderID: "ORDER-1", Reason: "expired"})
+	if err != nil {
+		t.Fatalf("CancelOrder: %v", err)
+	}
+
+	if !resp.Success || resp.Data.Status != experimental.OrderStatusCancelled || resp.Data.Descriptions != "expired" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+
+	if order, _ := server.Order("ORDER-1"); !experimental.IsFinal(order.Status) {
+		t.Errorf("ORDER-1 status = %s, want final", order.Status)
+	}
+
+	_, err = experimental.CancelOrder(context.Background(), client,
+		&experimental.CancelOrderRequest{ExternalOrderID: " ", Reason: ""})
+	if !errors.Is(err, goaliniex.ErrMissingField) {
+		t.Errorf("expected ErrMissingField, got %v", err)
+	}
+}
+
+func TestCancelOrderSignaturePayload(t *testing.T) {
+	t.Parallel()
+
+	payload, err := experimental.CancelOrderSignaturePayload("P", "S",
+		&experimental.CancelOrderRequest{ExternalOrderID: "ext-001", Reason: "expired"})
+	if err != nil {
+		t.Fatalf("CancelOrderSignaturePayload: %v", err)
+	}
+
+	if payload != "P|ext-001|expired|S" {
+		t.Errorf("payload = %q", payload)
+	}
+}
+
+func TestNewOrderCanceller(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		fault     *aliniextest.Fault
+		paid      bool
+		wantErr   error
+		permanent bool
+	}{
+		{name: "cancelled", fault: nil, paid: false, wantErr: nil, permanent: false},
+		{name: "paid order", fault: nil, paid: true, wantErr: goaliniex.ErrOrderCancelFailed, permanent: true},
+		{
+			name:      "server error",
+			fault:     &aliniextest.Fault{StatusCode: http.StatusInternalServerError, Message: "", ErrorCode: 0, Times: 1},
+			paid:      false,
+			wantErr:   goaliniex.ErrUnexpectedStatus,
+			permanent: false,
+		},
+		{
+			name:      "unknown endpoint",
+			fault:     &aliniextest.Fault{StatusCode: http.StatusNotFound, Message: "", ErrorCode: 0, Times: 1},
+			paid:      false,
+			wantErr:   goaliniex.ErrOrderCancelFailed,
+			permanent: true,
+		},
+		{
+			name:      "busy",
+			fault:     &aliniextest.Fault{StatusCode: 0, Message: "try later", ErrorCode: http.StatusServiceUnavailable, Times: 1},
+			paid:      false,
+			wantErr:   experimental.ErrCancelUnavailable,
+			permanent: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, client := newFakeServer(t)
+			createOrders(t, client, 1, "user@example.com")
+
+			if tc.paid {
+				if err := server.SetOrderStatus("ORDER-1", goaliniex.OrderStatusPaymentCompleted); err != nil {
+					t.Fatalf("SetOrderStatus: %v", err)
+				}
+			}
+
+			if tc.fault != nil {
+				server.FailNext(aliniextest.EndpointCancelOrder, *tc.fault)
+			}
+
+			err := experimental.NewOrderCanceller(client, "expired")(context.Background(), "ORDER-1")
+			if !errors.Is(err, tc.wantErr) || (tc.wantErr == nil && err != nil) {
+				t.Fatalf("err = %v, want %v", err, tc.wantErr)
+			}
+
+			if permanent := errors.Is(err, goaliniex.ErrOrderCancelFailed); permanent != tc.permanent {
+				t.Errorf("permanent = %v, want %v", permanent, tc.permanent)
+			}
+		})
+	}
+}
+
+func TestNewOrderCanceller_ExpirySweeper(t *testing.T) {
+	t.Parallel()
+
+	server, client := newFakeServer(t)
+	createOrders(t, client, 1, "user@example.com")
+
+	server.FailNext(aliniextest.EndpointCancelOrder, aliniextest.Fault{
+		StatusCode: http.StatusInternalServerError,
+		Message:    "",
+		ErrorCode:  0,
+		Times:      1,
+	})
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	sweeper := goaliniex.NewExpirySweeper(&goaliniex.ExpirySweeperOptions{
+		Interval:     0,
+		Grace:        0,
+		Cancel:       experimental.NewOrderCanceller(client, "expired"),
+		MaxAttempts:  0,
+		RetryBackoff: time.Minute,
+		OnExpired:    nil,
+		Now:          func() time.Time { return now },
+	})
+
+	sweeper.Track("ORDER-1", now.Add(-time.Minute))
+
+	if expired := sweeper.Sweep(context.Background()); len(expired) != 1 || !expired[0].Retrying {
+		t.Fatalf("first sweep = %+v", expired)
+	}
+
+	now = now.Add(time.Minute)
+
+	if expired := sweeper.Sweep(context.Background()); len(expired) != 1 || !expired[0].Cancelled {
+		t.Fatalf("second sweep = %+v", expired)
+	}
+
+	if order, _ := server.Order("ORDER-1"); order.Status != experimental.OrderStatusCancelled {
+		t.Errorf("ORDER-1 status = %s", order.Status)
+	}
+}
@@ -0,0 +1,9 @@
+// Package experimental calls Aliniex endpoints that the published API does
+// not document yet: buy orders, order listing and cancellation.
+//
+// Their paths, signed fields and response shapes are assumptions. Calls may
+// fail with 404 or signature errors against the live API, responses are not
+// signature-checked, and everything here may change or be removed without
+// notice. Each call moves into goaliniex once Aliniex documents its
+// endpoint.
+package experimental
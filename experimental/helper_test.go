@@ -0,0 +1,88 @@
+package experimental_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/andyle182810/goaliniex"
+	"github.com/andyle182810/goaliniex/aliniextest"
+)
+
+// newFakeServer starts an aliniextest server, which serves the assumed
+// experimental endpoints, and returns a client for it.
+func newFakeServer(t *testing.T) (*aliniextest.Server, *goaliniex.Client) {
+	t.Helper()
+
+	privateKey, publicKey, err := aliniextest.GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %v", err)
+	}
+
+	server, err := aliniextest.NewServer(aliniextest.Config{
+		PartnerCode: "TEST_PARTNER",
+		SecretKey:   "TEST_SECRET",
+		PublicKey:   publicKey,
+		Algorithm:   "",
+		ResponseKey: nil,
+		Balances:    map[goaliniex.Currency]float64{goaliniex.CurrencyUSDT: 1000},
+		OrderTTL:    0,
+		Now:         nil,
+	})
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+
+	t.Cleanup(server.Close)
+
+	client, err := server.NewClient(privateKey)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	return server, client
+}
+
+type httpClientFunc func(req *http.Request) *http.Response
+
+func (f httpClientFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req), nil
+}
+
+// newMockClient returns a client whose requests are answered by respond.
+func newMockClient(t *testing.T, respond httpClientFunc) *goaliniex.Client {
+	t.Helper()
+
+	privateKey, _, err := aliniextest.GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %v", err)
+	}
+
+	client, err := goaliniex.NewClient("https://sandbox.alixpay.com", "TEST_PARTNER", "TEST_SECRET", privateKey,
+		goaliniex.WithHTTPClient(respond))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	return client
+}
+
+func jsonResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		Status:           http.StatusText(statusCode),
+		StatusCode:       statusCode,
+		Proto:            "HTTP/1.1",
+		ProtoMajor:       1,
+		ProtoMinor:       1,
+		Header:           http.Header{"Content-Type": []string{"application/json"}},
+		Body:             io.NopCloser(strings.NewReader(body)),
+		ContentLength:    int64(len(body)),
+		TransferEncoding: nil,
+		Close:            false,
+		Uncompressed:     false,
+		Trailer:          nil,
+		Request:          nil,
+		TLS:              nil,
+	}
+}
@@ -0,0 +1,374 @@
+package experimental
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/andyle182810/goaliniex"
+)
+
+const (
+	// EndpointListOrders is the assumed path of the order listing endpoint.
+	EndpointListOrders = "/api/v2/orders/list"
+
+	// MaxOrderPageSize is the largest page ListOrders accepts.
+	MaxOrderPageSize = 100
+
+	defaultOrderPageInterval = 200 * time.Millisecond
+	defaultOrderRetryBackoff = time.Second
+	defaultOrderMaxRetries   = 3
+)
+
+var (
+	ErrInvalidOrderQuery = errors.New("invalid order query")
+	ErrOrderListFailed   = errors.New("order listing failed")
+)
+
+// ListOrdersRequest filters and pages the partner's orders. Empty filters
+// match everything and are left out of the request. Dates are RFC 3339 and
+// bound CreatedAt inclusively.
+type ListOrdersRequest struct {
+	Status    goaliniex.OrderStatus `json:"status,omitempty"`
+	Type      goaliniex.OrderType   `json:"type,omitempty"`
+	Currency  goaliniex.Currency    `json:"currency,omitempty"`
+	UserEmail string                `json:"userEmail,omitempty"`
+	FromDate  string                `json:"fromDate,omitempty"`
+	ToDate    string                `json:"toDate,omitempty"`
+	// Page is 1-based; zero means the first page.
+	Page int `json:"page,omitempty"`
+	// PageSize defaults to the server's page size when zero.
+	PageSize int `json:"pageSize,omitempty"`
+}
+
+// OrderPage is one page of ListOrders results, newest orders last.
+type OrderPage struct {
+	Items    []goaliniex.OrderDetails `json:"items"`
+	Page     int                      `json:"page"`
+	PageSize int                      `json:"pageSize"`
+	Total    int                      `json:"total"`
+	HasMore  bool                     `json:"hasMore"`
+}
+
+// listOrdersFields are the assumed signed fields. Fields left out of the
+// request sign as empty.
+func listOrdersFields() []string {
+	return []string{
+		goaliniex.FieldPartnerCode,
+		"status",
+		"type",
+		"currency",
+		"userEmail",
+		"fromDate",
+		"toDate",
+		"page",
+		"pageSize",
+	}
+}
+
+func ListOrdersSignaturePayload(partnerCode, secretKey string, req *ListOrdersRequest) (string, error) {
+	params, err := req.params()
+	if err != nil {
+		return "", err
+	}
+
+	spec := goaliniex.SignatureSpec{Fields: listOrdersFields(), Secret: goaliniex.SecretLast}
+
+	return spec.Payload(partnerCode, secretKey, params)
+}
+
+// params returns the request as sent, so omitted fields are also left out of
+// the signature.
+func (r *ListOrdersRequest) params() (map[string]any, error) {
+	if r == nil {
+		return nil, goaliniex.ErrNilRequest
+	}
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var params map[string]any
+	if err := json.Unmarshal(data, &params); err != nil {
+		return nil, err
+	}
+
+	return params, nil
+}
+
+// Validate checks the page bounds and date formats.
+func (r *ListOrdersRequest) Validate() error {
+	if r == nil {
+		return goaliniex.ErrNilRequest
+	}
+
+	if r.Page < 0 || r.PageSize < 0 || r.PageSize > MaxOrderPageSize {
+		return fmt.Errorf("%w: page %d, page size %d (max %d)", ErrInvalidOrderQuery, r.Page, r.PageSize, MaxOrderPageSize)
+	}
+
+	var from, to time.Time
+
+	for _, date := range []struct {
+		name  string
+		value string
+		out   *time.Time
+	}{{"fromDate", r.FromDate, &from}, {"toDate", r.ToDate, &to}} {
+		if date.value == "" {
+			continue
+		}
+
+		parsed, err := time.Parse(time.RFC3339, date.value)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrInvalidOrderQuery, date.name, err)
+		}
+
+		*date.out = parsed
+	}
+
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return fmt.Errorf("%w: toDate is before fromDate", ErrInvalidOrderQuery)
+	}
+
+	return nil
+}
+
+// ListOrders returns one page of orders through c. Use Orders to walk every
+// page.
+func ListOrders(ctx context.Context, c *goaliniex.Client, req *ListOrdersRequest) (*goaliniex.Response[OrderPage], error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	params, err := req.params()
+	if err != nil {
+		return nil, err
+	}
+
+	return goaliniex.Do[OrderPage](ctx, c, goaliniex.Call{
+		Method:        "",
+		Endpoint:      EndpointListOrders,
+		Params:        params,
+		SigningFields: listOrdersFields(),
+		Public:        false,
+	})
+}
+
+type ListOrdersOptions struct {
+	// PageInterval is the minimum delay between page requests. Defaults to
+	// 200ms; a negative value disables it.
+	PageInterval time.Duration
+	// MaxRetries is how many times a page rejected with HTTP 429 is
+	// retried. Defaults to 3; a negative value fails on the first.
+	MaxRetries int
+	// RetryBackoff is the wait before a retry when the server sends no
+	// Retry-After header. It doubles on each retry. Defaults to 1s.
+	RetryBackoff time.Duration
+	// Limit stops the iteration after this many orders; zero means all.
+	Limit int
+}
+
+func (o *ListOrdersOptions) withDefaults() ListOrdersOptions {
+	opts := ListOrdersOptions{
+		PageInterval: defaultOrderPageInterval,
+		MaxRetries:   defaultOrderMaxRetries,
+		RetryBackoff: defaultOrderRetryBackoff,
+		Limit:        0,
+	}
+
+	if o == nil {
+		return opts
+	}
+
+	if o.PageInterval != 0 {
+		opts.PageInterval = max(o.PageInterval, 0)
+	}
+
+	if o.MaxRetries != 0 {
+		opts.MaxRetries = max(o.MaxRetries, 0)
+	}
+
+	if o.RetryBackoff > 0 {
+		opts.RetryBackoff = o.RetryBackoff
+	}
+
+	opts.Limit = max(o.Limit, 0)
+
+	return opts
+}
+
+// OrderIterator walks the pages of a ListOrders query, fetching each page
+// only when the previous one is used up:
+//
+//	it := experimental.Orders(client, &experimental.ListOrdersRequest{Status: goaliniex.OrderStatusSuccess}, nil)
+//	for it.Next(ctx) {
+//		order := it.Order()
+//	}
+//	if err := it.Err(); err != nil { ... }
+//
+// Stopping early is safe; no further requests are made.
+type OrderIterator struct {
+	client *goaliniex.Client
+	req    ListOrdersRequest
+	opts   ListOrdersOptions
+
+	items     []goaliniex.OrderDetails
+	index     int
+	current   *goaliniex.OrderDetails
+	count     int
+	lastPage  bool
+	lastFetch time.Time
+	err       error
+}
+
+// Orders returns an iterator over the orders matching req, starting at
+// req.Page.
+func Orders(c *goaliniex.Client, req *ListOrdersRequest, opts *ListOrdersOptions) *OrderIterator {
+	var query ListOrdersRequest
+	if req != nil {
+		query = *req
+	}
+
+	query.Page = max(query.Page, 1)
+
+	return &OrderIterator{
+		client:    c,
+		req:       query,
+		opts:      opts.withDefaults(),
+		items:     nil,
+		index:     0,
+		current:   nil,
+		count:     0,
+		lastPage:  false,
+		lastFetch: time.Time{},
+		err:       nil,
+	}
+}
+
+// Next advances to the next order, fetching a page when needed. It returns
+// false when the orders are exhausted, the limit is reached or a request
+// fails; check Err to tell them apart.
+func (it *OrderIterator) Next(ctx context.Context) bool {
+	it.current = nil
+
+	if it.err != nil || (it.opts.Limit > 0 && it.count >= it.opts.Limit) {
+		return false
+	}
+
+	for it.index >= len(it.items) {
+		if it.lastPage {
+			return false
+		}
+
+		if err := it.nextPage(ctx); err != nil {
+			it.err = err
+
+			return false
+		}
+	}
+
+	it.current = &it.items[it.index]
+	it.index++
+	it.count++
+
+	return true
+}
+
+// Order returns the order Next moved to.
+func (it *OrderIterator) Order() *goaliniex.OrderDetails {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *OrderIterator) Err() error {
+	return it.err
+}
+
+func (it *OrderIterator) nextPage(ctx context.Context) error {
+	if !it.lastFetch.IsZero() {
+		if err := sleepContext(ctx, it.opts.PageInterval-time.Since(it.lastFetch)); err != nil {
+			return err
+		}
+	}
+
+	page, err := it.fetch(ctx)
+	it.lastFetch = time.Now()
+
+	if err != nil {
+		return err
+	}
+
+	it.items = page.Items
+	it.index = 0
+	it.lastPage = !page.HasMore || len(page.Items) == 0
+	it.req.Page++
+
+	return nil
+}
+
+// fetch requests the current page, retrying while the server answers 429.
+func (it *OrderIterator) fetch(ctx context.Context) (*OrderPage, error) {
+	backoff := it.opts.RetryBackoff
+
+	for attempt := 1; ; attempt++ {
+		var meta goaliniex.ResponseMeta
+
+		response, err := ListOrders(goaliniex.WithResponseMeta(ctx, &meta), it.client, &it.req)
+
+		if outer := goaliniex.ResponseMetaFrom(ctx); outer != nil {
+			meta.Attempts = attempt
+			*outer = meta
+		}
+
+		switch {
+		case err == nil && (!response.Success || response.Data == nil):
+			return nil, fmt.Errorf("%w: page %d: %s (errorCode=%d)",
+				ErrOrderListFailed, it.req.Page, response.Message, response.ErrorCode)
+		case err == nil:
+			return response.Data, nil
+		case meta.StatusCode != http.StatusTooManyRequests || attempt > it.opts.MaxRetries:
+			return nil, err
+		}
+
+		if err := sleepContext(ctx, retryAfter(meta.Header, backoff)); err != nil {
+			return nil, err
+		}
+
+		backoff *= 2
+	}
+}
+
+// retryAfter reads a Retry-After header in seconds or as an HTTP date,
+// falling back to fallback.
+func retryAfter(header http.Header, fallback time.Duration) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return fallback
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0)
+	}
+
+	return fallback
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
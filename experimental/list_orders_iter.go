@@ -0,0 +1,27 @@
+//go:build go1.23
+
+package experimental
+
+import (
+	"context"
+	"iter"
+
+	"github.com/andyle182810/goaliniex"
+)
+
+// All returns the remaining orders as a sequence for range-over-func. A
+// failure is yielded once as a nil order with the error, ending the
+// sequence; breaking out of the loop stops fetching pages.
+func (it *OrderIterator) All(ctx context.Context) iter.Seq2[*goaliniex.OrderDetails, error] {
+	return func(yield func(*goaliniex.OrderDetails, error) bool) {
+		for it.Next(ctx) {
+			if !yield(it.Order(), nil) {
+				return
+			}
+		}
+
+		if err := it.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+//go:build go1.23
+
+package experimental_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/andyle182810/goaliniex/aliniextest"
+	"github.com/andyle182810/goaliniex/experimental"
+)
+
+func TestOrderIterator_All(t *testing.T) {
+	t.Parallel()
+
+	server, client := newFakeServer(t)
+	createOrders(t, client, 5, "user@example.com")
+
+	var ids []string
+
+	//nolint:exhaustruct // filters left empty
+	it := experimental.Orders(client, &experimental.ListOrdersRequest{PageSize: 2}, fastPaging(0))
+
+	for order, err := range it.All(context.Background()) {
+		if err != nil {
+			t.Fatalf("All: %v", err)
+		}
+
+		ids = append(ids, order.ExternalOrderID)
+		if len(ids) == 3 {
+			break
+		}
+	}
+
+	if len(ids) != 3 || ids[2] != "ORDER-3" {
+		t.Errorf("ids = %v", ids)
+	}
+
+	if calls := server.Calls(aliniextest.EndpointListOrders); calls != 2 {
+		t.Errorf("list calls = %d, want 2 after breaking out", calls)
+	}
+}
+
+func TestOrderIterator_AllYieldsError(t *testing.T) {
+	t.Parallel()
+
+	server, client := newFakeServer(t)
+	server.FailNext(aliniextest.EndpointListOrders, aliniextest.Fault{
+		StatusCode: 0, Message: "maintenance", ErrorCode: 503, Times: 1,
+	})
+
+	var errs []error
+
+	for order, err := range experimental.Orders(client, nil, fastPaging(0)).All(context.Background()) {
+		if order != nil {
+			t.Errorf("unexpected order %s", order.ExternalOrderID)
+		}
+
+		errs = append(errs, err)
+	}
+
+	if len(errs) != 1 || !errors.Is(errs[0], experimental.ErrOrderListFailed) {
+		t.Errorf("errs = %v", errs)
+	}
+}
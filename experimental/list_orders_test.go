@@ -0,0 +1,315 @@
+package experimental_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/andyle182810/goaliniex"
+	"github.com/andyle182810/goaliniex/aliniextest"
+	"github.com/andyle182810/goaliniex/experimental"
+)
+
+// fastPaging disables the page interval and shortens rate limit backoff.
+func fastPaging(limit int) *experimental.ListOrdersOptions {
+	return &experimental.ListOrdersOptions{PageInterval: -1, MaxRetries: 0, RetryBackoff: time.Millisecond, Limit: limit}
+}
+
+// createOrders creates sell orders ORDER-1..ORDER-n for email.
+func createOrders(t *testing.T, client *goaliniex.Client, n int, email string) {
+	t.Helper()
+
+	for i := 1; i <= n; i++ {
+		_, err := client.CreateOrder(context.Background(), &goaliniex.CreateOrderRequest{
+			Currency:          goaliniex.CurrencyUSDT,
+			FiatAmount:        260000,
+			FiatCurrency:      goaliniex.FiatCurrencyVND,
+			BankCode:          "970436",
+			BankAccountNumber: "0123456789",
+			ExternalOrderID:   fmt.Sprintf("ORDER-%d", i),
+			WebhookSecretKey:  "",
+			UserEmail:         email,
+			UserKYCVerified:   true,
+			Content:           "",
+			ExtendInfo:        nil,
+		})
+		if err != nil {
+			t.Fatalf("CreateOrder: %v", err)
+		}
+	}
+}
+
+func orderIDs(t *testing.T, it *experimental.OrderIterator) []string {
+	t.Helper()
+
+	var ids []string
+
+	for it.Next(context.Background()) {
+		ids = append(ids, it.Order().ExternalOrderID)
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatalf("iteration failed: %v", err)
+	}
+
+	return ids
+}
+
+func TestListOrders(t *testing.T) {
+	t.Parallel()
+
+	server, client := newFakeServer(t)
+	createOrders(t, client, 3, "user@example.com")
+
+	if err := server.SetOrderStatus("ORDER-2", goaliniex.OrderStatusSuccess); err != nil {
+		t.Fatalf("SetOrderStatus: %v", err)
+	}
+
+	resp, err := experimental.ListOrders(context.Background(), client, &experimental.ListOrdersRequest{
+		Status:    goaliniex.OrderStatusAwaitingPayment,
+		Type:      "",
+		Currency:  "",
+		UserEmail: "USER@example.com",
+		FromDate:  "",
+		ToDate:    "",
+		Page:      1,
+		PageSize:  1,
+	})
+	if err != nil {
+		t.Fatalf("ListOrders: %v", err)
+	}
+
+	page := resp.Data
+	if len(page.Items) != 1 || page.Items[0].ExternalOrderID != "ORDER-1" || page.Total != 2 || !page.HasMore {
+		t.Errorf("unexpected page: %+v", page)
+	}
+}
+
+func TestListOrders_OmitsZeroPaging(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]any
+
+	client := newMockClient(t, func(req *http.Request) *http.Response {
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+
+		return jsonResponse(http.StatusOK, `{"success":true,"data":{"items":[],"page":1,"pageSize":20,"total":0,"hasMore":false}}`)
+	})
+
+	//nolint:exhaustruct // zero query
+	if _, err := experimental.ListOrders(context.Background(), client, &experimental.ListOrdersRequest{}); err != nil {
+		t.Fatalf("ListOrders: %v", err)
+	}
+
+	if _, sent := body["pageSize"]; sent || len(body) != 2 {
+		t.Errorf("body = %v, want only partnerCode and signature", body)
+	}
+
+	//nolint:exhaustruct // zero query
+	payload, err := experimental.ListOrdersSignaturePayload("P", "S", &experimental.ListOrdersRequest{})
+	if err != nil || payload != "P|||||||||S" {
+		t.Errorf("payload = %q, %v", payload, err)
+	}
+}
+
+func TestListOrdersSignaturePayload(t *testing.T) {
+	t.Parallel()
+
+	payload, err := experimental.ListOrdersSignaturePayload("P", "S", &experimental.ListOrdersRequest{
+		Status:    goaliniex.OrderStatusSuccess,
+		Type:      goaliniex.OrderTypeSell,
+		Currency:  "",
+		UserEmail: "a@b.c",
+		FromDate:  "2025-01-01T00:00:00Z",
+		ToDate:    "",
+		Page:      2,
+		PageSize:  50,
+	})
+	if err != nil {
+		t.Fatalf("ListOrdersSignaturePayload: %v", err)
+	}
+
+	if want := "P|SUCCESS|SELL||a@b.c|2025-01-01T00:00:00Z||2|50|S"; payload != want {
+		t.Errorf("payload = %q, want %q", payload, want)
+	}
+}
+
+func TestOrderIterator_FollowsPages(t *testing.T) {
+	t.Parallel()
+
+	server, client := newFakeServer(t)
+	createOrders(t, client, 5, "user@example.com")
+
+	//nolint:exhaustruct // filters left empty
+	it := experimental.Orders(client, &experimental.ListOrdersRequest{PageSize: 2}, fastPaging(0))
+
+	ids := orderIDs(t, it)
+	if fmt.Sprint(ids) != "[ORDER-1 ORDER-2 ORDER-3 ORDER-4 ORDER-5]" {
+		t.Errorf("ids = %v", ids)
+	}
+
+	if calls := server.Calls(aliniextest.EndpointListOrders); calls != 3 {
+		t.Errorf("list calls = %d, want 3", calls)
+	}
+
+	if it.Next(context.Background()) || it.Order() != nil {
+		t.Error("exhausted iterator advanced")
+	}
+}
+
+func TestOrderIterator_LimitStopsFetching(t *testing.T) {
+	t.Parallel()
+
+	server, client := newFakeServer(t)
+	createOrders(t, client, 5, "user@example.com")
+
+	//nolint:exhaustruct // filters left empty
+	ids := orderIDs(t, experimental.Orders(client, &experimental.ListOrdersRequest{PageSize: 2}, fastPaging(3)))
+	if len(ids) != 3 {
+		t.Errorf("ids = %v, want 3", ids)
+	}
+
+	if calls := server.Calls(aliniextest.EndpointListOrders); calls != 2 {
+		t.Errorf("list calls = %d, want 2", calls)
+	}
+}
+
+func TestOrderIterator_RetriesRateLimit(t *testing.T) {
+	t.Parallel()
+
+	server, client := newFakeServer(t)
+	createOrders(t, client, 1, "user@example.com")
+	server.FailNext(aliniextest.EndpointListOrders, aliniextest.Fault{
+		StatusCode: http.StatusTooManyRequests, Message: "slow down", ErrorCode: 0, Times: 2,
+	})
+
+	var meta goaliniex.ResponseMeta
+
+	it := experimental.Orders(client, nil, fastPaging(0))
+	if !it.Next(goaliniex.WithResponseMeta(context.Background(), &meta)) {
+		t.Fatalf("Next failed: %v", it.Err())
+	}
+
+	if meta.Attempts != 3 || meta.StatusCode != http.StatusOK {
+		t.Errorf("meta attempts = %d, status = %d", meta.Attempts, meta.StatusCode)
+	}
+}
+
+func TestOrderIterator_GivesUpAfterRetries(t *testing.T) {
+	t.Parallel()
+
+	server, client := newFakeServer(t)
+	server.FailNext(aliniextest.EndpointListOrders, aliniextest.Fault{
+		StatusCode: http.StatusTooManyRequests, Message: "slow down", ErrorCode: 0, Times: 2,
+	})
+
+	opts := fastPaging(0)
+	opts.MaxRetries = 1
+
+	it := experimental.Orders(client, nil, opts)
+	if it.Next(context.Background()) {
+		t.Fatal("expected iteration to stop")
+	}
+
+	if !errors.Is(it.Err(), goaliniex.ErrUnexpectedStatus) {
+		t.Errorf("expected ErrUnexpectedStatus, got %v", it.Err())
+	}
+
+	if calls := server.Calls(aliniextest.EndpointListOrders); calls != 2 {
+		t.Errorf("list calls = %d, want 2", calls)
+	}
+}
+
+func TestOrderIterator_HonorsRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	client := newMockClient(t, func(_ *http.Request) *http.Response {
+		calls++
+		if calls == 1 {
+			resp := jsonResponse(http.StatusTooManyRequests, `{}`)
+			resp.Header.Set("Retry-After", "0")
+
+			return resp
+		}
+
+		return jsonResponse(http.StatusOK, `{"success":true,"data":{"items":[],"page":1,"pageSize":20,"total":0,"hasMore":false}}`)
+	})
+
+	opts := fastPaging(0)
+	opts.RetryBackoff = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	it := experimental.Orders(client, nil, opts)
+	if it.Next(ctx) || it.Err() != nil {
+		t.Fatalf("expected an empty listing, got err %v", it.Err())
+	}
+
+	if calls != 2 {
+		t.Errorf("requests = %d, want 2", calls)
+	}
+}
+
+func TestOrderIterator_FailedResponse(t *testing.T) {
+	t.Parallel()
+
+	server, client := newFakeServer(t)
+	server.FailNext(aliniextest.EndpointListOrders, aliniextest.Fault{
+		StatusCode: 0, Message: "maintenance", ErrorCode: 503, Times: 1,
+	})
+
+	it := experimental.Orders(client, nil, fastPaging(0))
+	if it.Next(context.Background()) || !errors.Is(it.Err(), experimental.ErrOrderListFailed) {
+		t.Errorf("expected ErrOrderListFailed, got %v", it.Err())
+	}
+}
+
+func TestListOrdersRequest_Validate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		req   experimental.ListOrdersRequest
+		valid bool
+	}{
+		{name: "empty", req: experimental.ListOrdersRequest{}, valid: true}, //nolint:exhaustruct // zero query
+		{
+			name: "date range",
+			req: experimental.ListOrdersRequest{ //nolint:exhaustruct // dates only
+				FromDate: "2025-01-01T00:00:00Z",
+				ToDate:   "2025-02-01T00:00:00+07:00",
+			},
+			valid: true,
+		},
+		{name: "negative page", req: experimental.ListOrdersRequest{Page: -1}, valid: false},          //nolint:exhaustruct // page only
+		{name: "page too large", req: experimental.ListOrdersRequest{PageSize: 101}, valid: false},    //nolint:exhaustruct // size only
+		{name: "bad date", req: experimental.ListOrdersRequest{FromDate: "2025-01-01"}, valid: false}, //nolint:exhaustruct // date only
+		{
+			name: "reversed range",
+			req: experimental.ListOrdersRequest{ //nolint:exhaustruct // dates only
+				FromDate: "2025-02-01T00:00:00Z",
+				ToDate:   "2025-01-01T00:00:00Z",
+			},
+			valid: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.req.Validate()
+
+		switch {
+		case tc.valid && err != nil:
+			t.Errorf("%s: expected valid, got %v", tc.name, err)
+		case !tc.valid && !errors.Is(err, experimental.ErrInvalidOrderQuery):
+			t.Errorf("%s: expected ErrInvalidOrderQuery, got %v", tc.name, err)
+		}
+	}
+}
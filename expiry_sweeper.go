@@ -0,0 +1,286 @@
+package goaliniex
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	defaultExpirySweepInterval = time.Minute
+	defaultCancelMaxAttempts   = 5
+	maxCancelRetryDelay        = time.Hour
+)
+
+var ErrOrderCancelFailed = errors.New("order cancellation failed")
+
+// OrderCanceller cancels an order. An error wrapping ErrOrderCancelFailed is
+// a final refusal, such as for an order paid in the meantime; any other
+// error is retried.
+type OrderCanceller func(ctx context.Context, externalOrderID string) error
+
+// ExpiredOrder reports a tracked order whose ExpiresAt has passed.
+type ExpiredOrder struct {
+	ExternalOrderID string
+	ExpiresAt       time.Time
+	// Cancelled reports that the cancellation succeeded.
+	Cancelled bool
+	// Err is the cancellation failure of this attempt.
+	Err error
+	// Attempts counts the cancellations tried so far, including this one.
+	Attempts int
+	// Retrying reports that the cancellation failed for a reason other than
+	// ErrOrderCancelFailed and that the order stays tracked for another
+	// attempt. It is false once MaxAttempts is reached.
+	Retrying bool
+}
+
+type ExpirySweeperOptions struct {
+	// Interval is the delay between sweeps in Run. Defaults to 1m.
+	Interval time.Duration
+	// Grace is added to ExpiresAt before an order counts as expired, to
+	// allow for clock skew and late payment callbacks.
+	Grace time.Duration
+	// Cancel, when set, is called for each expired order.
+	Cancel OrderCanceller
+	// MaxAttempts bounds the cancellations tried per order before it is
+	// untracked and reported as failed. Defaults to 5.
+	MaxAttempts int
+	// RetryBackoff is the wait before retrying a failed cancellation. It
+	// doubles on each attempt, up to an hour. Defaults to Interval.
+	RetryBackoff time.Duration
+	// OnExpired is called for each expired order after any cancellation.
+	OnExpired func(order ExpiredOrder)
+	// Now overrides the clock.
+	Now func() time.Time
+}
+
+func (o *ExpirySweeperOptions) withDefaults() ExpirySweeperOptions {
+	opts := ExpirySweeperOptions{
+		Interval:     defaultExpirySweepInterval,
+		Grace:        0,
+		Cancel:       nil,
+		MaxAttempts:  defaultCancelMaxAttempts,
+		RetryBackoff: 0,
+		OnExpired:    nil,
+		Now:          time.Now,
+	}
+
+	if o != nil {
+		if o.Interval > 0 {
+			opts.Interval = o.Interval
+		}
+
+		if o.MaxAttempts > 0 {
+			opts.MaxAttempts = o.MaxAttempts
+		}
+
+		if o.Now != nil {
+			opts.Now = o.Now
+		}
+
+		opts.Grace = max(o.Grace, 0)
+		opts.Cancel = o.Cancel
+		opts.RetryBackoff = max(o.RetryBackoff, 0)
+		opts.OnExpired = o.OnExpired
+	}
+
+	if opts.RetryBackoff == 0 {
+		opts.RetryBackoff = opts.Interval
+	}
+
+	return opts
+}
+
+// trackedOrder is an order being watched and its failed cancellations.
+type trackedOrder struct {
+	expiresAt time.Time
+	attempts  int
+	retryAt   time.Time
+}
+
+// ExpirySweeper tracks orders awaiting payment and reports, and optionally
+// cancels, those still tracked after their ExpiresAt. Untrack orders once
+// they are paid. It is safe for concurrent use.
+type ExpirySweeper struct {
+	opts ExpirySweeperOptions
+
+	mu     sync.Mutex
+	orders map[string]*trackedOrder
+	// sweeping holds the orders a Sweep is cancelling, so concurrent sweeps
+	// do not cancel them twice.
+	sweeping map[string]bool
+}
+
+// NewExpirySweeper returns a sweeper that cancels through opts.Cancel.
+func NewExpirySweeper(opts *ExpirySweeperOptions) *ExpirySweeper {
+	return &ExpirySweeper{
+		opts:     opts.withDefaults(),
+		mu:       sync.Mutex{},
+		orders:   map[string]*trackedOrder{},
+		sweeping: map[string]bool{},
+	}
+}
+
+// Track starts watching an order, replacing any earlier expiry for it and
+// resetting its failed cancellations.
+func (s *ExpirySweeper) Track(externalOrderID string, expiresAt time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.orders[externalOrderID] = &trackedOrder{expiresAt: expiresAt, attempts: 0, retryAt: time.Time{}}
+}
+
+// TrackOrder tracks order if it is awaiting payment.
+func (s *ExpirySweeper) TrackOrder(order *OrderDetails) error {
+	if order.Status != OrderStatusAwaitingPayment {
+		return nil
+	}
+
+	expiresAt, err := order.ExpiresAtTime()
+	if err != nil {
+		return fmt.Errorf("order %s: %w", order.ExternalOrderID, err)
+	}
+
+	s.Track(order.ExternalOrderID, expiresAt)
+
+	return nil
+}
+
+// Untrack stops watching an order, e.g. once its payment arrives.
+func (s *ExpirySweeper) Untrack(externalOrderID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.orders, externalOrderID)
+}
+
+// Tracked returns the IDs of the orders being watched, sorted.
+func (s *ExpirySweeper) Tracked() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]string, 0, len(s.orders))
+	for id := range s.orders {
+		ids = append(ids, id)
+	}
+
+	slices.Sort(ids)
+
+	return ids
+}
+
+// Sweep reports every expired order, cancels it when Cancel is set and
+// returns them ordered by expiry. Orders are untracked once reported, or with
+// Cancel once cancelled, refused or failed MaxAttempts times; other failures
+// are retried after RetryBackoff.
+func (s *ExpirySweeper) Sweep(ctx context.Context) []ExpiredOrder {
+	expired := s.takeExpired()
+
+	for i := range expired {
+		if s.opts.Cancel != nil {
+			err := s.opts.Cancel(ctx, expired[i].ExternalOrderID)
+			expired[i].Attempts++
+			expired[i].Err = err
+			expired[i].Cancelled = err == nil
+			expired[i].Retrying = err != nil && !errors.Is(err, ErrOrderCancelFailed) &&
+				expired[i].Attempts < s.opts.MaxAttempts
+		}
+
+		s.finish(expired[i])
+
+		if s.opts.OnExpired != nil {
+			s.opts.OnExpired(expired[i])
+		}
+	}
+
+	return expired
+}
+
+// Run sweeps every Interval until ctx is done, then returns ctx.Err().
+func (s *ExpirySweeper) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.Sweep(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ExpirySweeper) takeExpired() []ExpiredOrder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.opts.Now()
+
+	var expired []ExpiredOrder
+
+	for id, order := range s.orders {
+		if s.sweeping[id] || now.Before(order.expiresAt.Add(s.opts.Grace)) || now.Before(order.retryAt) {
+			continue
+		}
+
+		s.sweeping[id] = true
+		expired = append(expired, ExpiredOrder{
+			ExternalOrderID: id,
+			ExpiresAt:       order.expiresAt,
+			Cancelled:       false,
+			Err:             nil,
+			Attempts:        order.attempts,
+			Retrying:        false,
+		})
+	}
+
+	slices.SortFunc(expired, func(a, b ExpiredOrder) int {
+		if c := a.ExpiresAt.Compare(b.ExpiresAt); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.ExternalOrderID, b.ExternalOrderID)
+	})
+
+	return expired
+}
+
+// finish untracks a swept order, or schedules its next attempt when it is
+// retrying. Orders tracked again with a new expiry while being cancelled are
+// left alone.
+func (s *ExpirySweeper) finish(order ExpiredOrder) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sweeping, order.ExternalOrderID)
+
+	tracked, ok := s.orders[order.ExternalOrderID]
+
+	switch {
+	case !ok || !tracked.expiresAt.Equal(order.ExpiresAt):
+	case order.Retrying:
+		tracked.attempts = order.Attempts
+		tracked.retryAt = s.opts.Now().Add(s.retryDelay(order.Attempts))
+	default:
+		delete(s.orders, order.ExternalOrderID)
+	}
+}
+
+// retryDelay is RetryBackoff doubled for each attempt after the first, up to
+// maxCancelRetryDelay.
+func (s *ExpirySweeper) retryDelay(attempts int) time.Duration {
+	delay := s.opts.RetryBackoff
+
+	for i := 1; i < attempts && delay < maxCancelRetryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxCancelRetryDelay)
+}
@@ -0,0 +1,262 @@
+package goaliniex_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/andyle182810/goaliniex"
+)
+
+var errCancelTimeout = errors.New("cancel timed out")
+
+// stubCanceller records the orders it is asked to cancel and answers with
+// the next queued error for each, nil once the queue is empty.
+type stubCanceller struct {
+	calls  []string
+	errors map[string][]error
+}
+
+func (c *stubCanceller) cancel(_ context.Context, externalOrderID string) error {
+	c.calls = append(c.calls, externalOrderID)
+
+	queued := c.errors[externalOrderID]
+	if len(queued) == 0 {
+		return nil
+	}
+
+	c.errors[externalOrderID] = queued[1:]
+
+	return queued[0]
+}
+
+func TestExpirySweeper_Sweep(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	canceller := &stubCanceller{
+		calls:  nil,
+		errors: map[string][]error{"ORDER-3": {fmt.Errorf("%w: paid", goaliniex.ErrOrderCancelFailed)}},
+	}
+
+	var reported []string
+
+	sweeper := goaliniex.NewExpirySweeper(&goaliniex.ExpirySweeperOptions{
+		Interval:     0,
+		Grace:        0,
+		Cancel:       canceller.cancel,
+		MaxAttempts:  0,
+		RetryBackoff: 0,
+		OnExpired:    func(order goaliniex.ExpiredOrder) { reported = append(reported, order.ExternalOrderID) },
+		Now:          func() time.Time { return now },
+	})
+
+	sweeper.Track("ORDER-1", now.Add(-time.Minute))
+	sweeper.Track("ORDER-2", now.Add(time.Minute))
+	sweeper.Track("ORDER-3", now.Add(-2*time.Minute))
+
+	expired := sweeper.Sweep(context.Background())
+	if len(expired) != 2 || expired[0].ExternalOrderID != "ORDER-3" || expired[1].ExternalOrderID != "ORDER-1" {
+		t.Fatalf("expired = %+v", expired)
+	}
+
+	if expired[0].Cancelled || expired[0].Retrying || !errors.Is(expired[0].Err, goaliniex.ErrOrderCancelFailed) {
+		t.Errorf("refused order: %+v", expired[0])
+	}
+
+	if !expired[1].Cancelled || expired[1].Err != nil || expired[1].Attempts != 1 {
+		t.Errorf("abandoned order: %+v", expired[1])
+	}
+
+	if fmt.Sprint(canceller.calls) != "[ORDER-3 ORDER-1]" {
+		t.Errorf("cancelled = %v", canceller.calls)
+	}
+
+	if fmt.Sprint(reported) != "[ORDER-3 ORDER-1]" {
+		t.Errorf("reported = %v", reported)
+	}
+
+	if tracked := sweeper.Tracked(); fmt.Sprint(tracked) != "[ORDER-2]" {
+		t.Errorf("tracked = %v", tracked)
+	}
+}
+
+func TestExpirySweeper_GraceAndUntrack(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	sweeper := goaliniex.NewExpirySweeper(&goaliniex.ExpirySweeperOptions{
+		Interval:     0,
+		Grace:        2 * time.Minute,
+		Cancel:       nil,
+		MaxAttempts:  0,
+		RetryBackoff: 0,
+		OnExpired:    nil,
+		Now:          func() time.Time { return now },
+	})
+
+	sweeper.Track("IN-GRACE", now.Add(-time.Minute))
+	sweeper.Track("PAID", now.Add(-time.Hour))
+	sweeper.Untrack("PAID")
+
+	if expired := sweeper.Sweep(context.Background()); len(expired) != 0 {
+		t.Errorf("expired = %+v", expired)
+	}
+
+	now = now.Add(time.Minute)
+
+	expired := sweeper.Sweep(context.Background())
+	if len(expired) != 1 || expired[0].ExternalOrderID != "IN-GRACE" || expired[0].Cancelled || expired[0].Err != nil {
+		t.Errorf("expired = %+v", expired)
+	}
+}
+
+func TestExpirySweeper_TrackOrder(t *testing.T) {
+	t.Parallel()
+
+	sweeper := goaliniex.NewExpirySweeper(nil)
+
+	//nolint:exhaustruct // only the tracked fields matter
+	orders := []goaliniex.OrderDetails{
+		{ExternalOrderID: "OPEN", Status: goaliniex.OrderStatusAwaitingPayment, ExpiresAt: "2025-01-02T03:19:05Z"},
+		{ExternalOrderID: "DONE", Status: goaliniex.OrderStatusSuccess, ExpiresAt: "2025-01-02T03:19:05Z"},
+	}
+
+	for i := range orders {
+		if err := sweeper.TrackOrder(&orders[i]); err != nil {
+			t.Fatalf("TrackOrder(%s): %v", orders[i].ExternalOrderID, err)
+		}
+	}
+
+	if tracked := sweeper.Tracked(); fmt.Sprint(tracked) != "[OPEN]" {
+		t.Errorf("tracked = %v", tracked)
+	}
+
+	//nolint:exhaustruct // only the tracked fields matter
+	bad := goaliniex.OrderDetails{ExternalOrderID: "BAD", Status: goaliniex.OrderStatusAwaitingPayment, ExpiresAt: "soon"}
+	if err := sweeper.TrackOrder(&bad); !errors.Is(err, goaliniex.ErrInvalidOrderTime) {
+		t.Errorf("expected ErrInvalidOrderTime, got %v", err)
+	}
+}
+
+func TestExpirySweeper_Run(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sweeper := goaliniex.NewExpirySweeper(&goaliniex.ExpirySweeperOptions{
+		Interval:     time.Millisecond,
+		Grace:        0,
+		Cancel:       nil,
+		MaxAttempts:  0,
+		RetryBackoff: 0,
+		OnExpired:    func(goaliniex.ExpiredOrder) { cancel() },
+		Now:          nil,
+	})
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		sweeper.Track("ORDER-1", time.Now())
+	}()
+
+	if err := sweeper.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Run = %v, want context.Canceled", err)
+	}
+}
+
+func TestExpirySweeper_RetriesWithBackoff(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	canceller := &stubCanceller{
+		calls:  nil,
+		errors: map[string][]error{"ORDER-1": {errCancelTimeout, errCancelTimeout}},
+	}
+
+	sweeper := goaliniex.NewExpirySweeper(&goaliniex.ExpirySweeperOptions{
+		Interval:     0,
+		Grace:        0,
+		Cancel:       canceller.cancel,
+		MaxAttempts:  0,
+		RetryBackoff: time.Minute,
+		OnExpired:    nil,
+		Now:          func() time.Time { return now },
+	})
+
+	sweeper.Track("ORDER-1", now.Add(-time.Minute))
+
+	expired := sweeper.Sweep(context.Background())
+	if len(expired) != 1 || expired[0].Cancelled || !expired[0].Retrying || expired[0].Attempts != 1 ||
+		!errors.Is(expired[0].Err, errCancelTimeout) {
+		t.Fatalf("first sweep = %+v", expired)
+	}
+
+	// The second attempt waits RetryBackoff, the third twice that.
+	for _, wait := range []time.Duration{time.Minute, 2 * time.Minute} {
+		now = now.Add(wait - time.Second)
+
+		if expired := sweeper.Sweep(context.Background()); len(expired) != 0 {
+			t.Fatalf("swept before backoff: %+v", expired)
+		}
+
+		now = now.Add(time.Second)
+
+		if expired = sweeper.Sweep(context.Background()); len(expired) != 1 {
+			t.Fatalf("swept after backoff: %+v", expired)
+		}
+	}
+
+	if !expired[0].Cancelled || expired[0].Retrying || expired[0].Attempts != 3 {
+		t.Errorf("last sweep = %+v", expired)
+	}
+
+	if tracked := sweeper.Tracked(); len(tracked) != 0 {
+		t.Errorf("tracked = %v", tracked)
+	}
+}
+
+func TestExpirySweeper_GivesUpAfterMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	canceller := &stubCanceller{
+		calls:  nil,
+		errors: map[string][]error{"ORDER-1": {errCancelTimeout, errCancelTimeout, errCancelTimeout}},
+	}
+
+	var reported []goaliniex.ExpiredOrder
+
+	sweeper := goaliniex.NewExpirySweeper(&goaliniex.ExpirySweeperOptions{
+		Interval:     0,
+		Grace:        0,
+		Cancel:       canceller.cancel,
+		MaxAttempts:  2,
+		RetryBackoff: time.Minute,
+		OnExpired:    func(order goaliniex.ExpiredOrder) { reported = append(reported, order) },
+		Now:          func() time.Time { return now },
+	})
+
+	sweeper.Track("ORDER-1", now.Add(-time.Minute))
+
+	for range 3 {
+		sweeper.Sweep(context.Background())
+
+		now = now.Add(time.Hour)
+	}
+
+	if len(canceller.calls) != 2 || len(reported) != 2 {
+		t.Fatalf("calls = %v, reported = %+v", canceller.calls, reported)
+	}
+
+	if last := reported[1]; last.Retrying || last.Cancelled || last.Attempts != 2 || !errors.Is(last.Err, errCancelTimeout) {
+		t.Errorf("last report = %+v", last)
+	}
+
+	if tracked := sweeper.Tracked(); len(tracked) != 0 {
+		t.Errorf("tracked = %v", tracked)
+	}
+}
@@ -2,9 +2,6 @@ package goaliniex
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 type KycStatus string
@@ -20,6 +17,7 @@ type KycInformationRequest struct {
 	UserEmail string `json:"userEmail"`
 }
 
+// Deprecated: Use Kyc.
 type KycInformation struct {
 	FirstName        string    `json:"firstName"`
 	LastName         string    `json:"lastName"`
@@ -40,34 +38,11 @@ type KycInformation struct {
 	RejectReason     string    `json:"rejectReason"`
 }
 
-func (c *Client) GetKycInformation(ctx context.Context, req *KycInformationRequest) (*Response[KycInformation], error) {
-	signaturePayload := fmt.Sprintf(
-		"%s|%s|%s",
-		c.partnerCode,
-		req.UserEmail,
-		c.secretKey,
-	)
-
-	apiRequest := request{
-		Method:      http.MethodPost,
-		Endpoint:    "/api/v2/user/get-kyc-information",
-		Params:      req,
-		SigningData: []byte(signaturePayload),
-		Header:      nil,
-		Body:        nil,
-		FullURL:     "",
-		Public:      false,
-	}
-
-	rawResponse, err := c.execute(ctx, &apiRequest)
-	if err != nil {
-		return nil, err
-	}
-
-	response := new(Response[KycInformation])
-	if err := json.Unmarshal(rawResponse, response); err != nil {
-		return nil, err
-	}
+func GetKycInformationSignaturePayload(partnerCode, secretKey string, req *KycInformationRequest) (string, error) {
+	return SignaturePayload(OperationGetKycInformation, partnerCode, secretKey, req)
+}
 
-	return response, nil
+// Deprecated: Use GetKyc, which returns typed gender, ID type and dates.
+func (c *Client) GetKycInformation(ctx context.Context, req *KycInformationRequest) (*Response[KycInformation], error) {
+	return getKyc[KycInformation](ctx, c, OperationGetKycInformation, req)
 }
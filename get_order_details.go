@@ -2,8 +2,6 @@ package goaliniex
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"net/http"
 )
 
@@ -13,7 +11,7 @@ type GetOrderDetailsRequest struct {
 
 type TokenTransfer struct {
 	Currency      Currency `json:"currency"`
-	Network       string   `json:"network"`
+	Network       Network  `json:"network"`
 	Price         float64  `json:"price"`
 	Amount        float64  `json:"amount"`
 	WalletAddress string   `json:"walletAddress"`
@@ -51,19 +49,17 @@ type OrderDetails struct {
 	Signature       string        `json:"signature"`
 }
 
-func (c *Client) GetOrderDetails(ctx context.Context, req *GetOrderDetailsRequest) (*Response[OrderDetails], error) {
-	signaturePayload := fmt.Sprintf(
-		"%s|%s|%s",
-		c.partnerCode,
-		req.ExternalOrderID,
-		c.secretKey,
-	)
+func GetOrderDetailsSignaturePayload(partnerCode, secretKey string, req *GetOrderDetailsRequest) (string, error) {
+	return SignaturePayload(OperationGetOrderDetails, partnerCode, secretKey, req)
+}
 
+func (c *Client) GetOrderDetails(ctx context.Context, req *GetOrderDetailsRequest) (*Response[OrderDetails], error) {
 	apiRequest := request{
 		Method:      http.MethodPost,
 		Endpoint:    "/api/v2/orders/details",
 		Params:      req,
-		SigningData: []byte(signaturePayload),
+		Operation:   OperationGetOrderDetails,
+		SigningData: nil,
 		Header:      nil,
 		Body:        nil,
 		FullURL:     "",
@@ -75,8 +71,12 @@ func (c *Client) GetOrderDetails(ctx context.Context, req *GetOrderDetailsReques
 		return nil, err
 	}
 
-	response := new(Response[OrderDetails])
-	if err := json.Unmarshal(rawResponse, response); err != nil {
+	response, err := decodeResponse[OrderDetails](c, apiRequest.Endpoint, rawResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifyResponse(c, OperationGetOrderDetails, response); err != nil {
 		return nil, err
 	}
 
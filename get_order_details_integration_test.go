@@ -60,7 +60,7 @@ func TestIntegration_GetOrderDetails_NonExistentOrder(t *testing.T) {
 	defer cancel()
 
 	req := &goaliniex.GetOrderDetailsRequest{
-		ExternalOrderID: "non-existent-order-" + time.Now().Format("20060102150405"),
+		ExternalOrderID: testOrderID(t, "non-existent-order-"),
 	}
 
 	resp, err := client.GetOrderDetails(ctx, req)
@@ -114,7 +114,7 @@ func TestIntegration_GetOrderDetails_ResponseDataFields(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
 
-	externalOrderID := "test-fields-detail-" + time.Now().Format("20060102150405")
+	externalOrderID := testOrderID(t, "test-fields-detail-")
 	createReq := &goaliniex.CreateOrderRequest{
 		Currency:          goaliniex.CurrencyUSDT,
 		FiatAmount:        100000,
@@ -179,7 +179,7 @@ func TestIntegration_GetOrderDetails_TokenTransferFields(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
 
-	externalOrderID := "test-token-transfer-" + time.Now().Format("20060102150405")
+	externalOrderID := testOrderID(t, "test-token-transfer-")
 	createReq := &goaliniex.CreateOrderRequest{
 		Currency:          goaliniex.CurrencyUSDT,
 		FiatAmount:        100000,
@@ -239,7 +239,7 @@ func TestIntegration_GetOrderDetails_BankTransferFields(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
 
-	externalOrderID := "test-bank-transfer-" + time.Now().Format("20060102150405")
+	externalOrderID := testOrderID(t, "test-bank-transfer-")
 	createReq := &goaliniex.CreateOrderRequest{
 		Currency:          goaliniex.CurrencyUSDT,
 		FiatAmount:        100000,
@@ -296,7 +296,7 @@ func TestIntegration_GetOrderDetails_ContextTimeout(t *testing.T) {
 	defer cancel()
 
 	req := &goaliniex.GetOrderDetailsRequest{
-		ExternalOrderID: "test-timeout-" + time.Now().Format("20060102150405"),
+		ExternalOrderID: testOrderID(t, "test-timeout-"),
 	}
 
 	_, err := client.GetOrderDetails(ctx, req)
@@ -321,7 +321,7 @@ func TestIntegration_GetOrderDetails_ContextCancellation(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	req := &goaliniex.GetOrderDetailsRequest{
-		ExternalOrderID: "test-cancel-" + time.Now().Format("20060102150405"),
+		ExternalOrderID: testOrderID(t, "test-cancel-"),
 	}
 
 	cancel()
@@ -353,7 +353,7 @@ func TestIntegration_GetOrderDetails_DifferentCryptoCurrencies(t *testing.T) {
 		t.Run(string(currency), func(t *testing.T) {
 			t.Parallel()
 
-			externalOrderID := "test-" + string(currency) + "-details-" + time.Now().Format("20060102150405")
+			externalOrderID := testOrderID(t, "test-"+string(currency)+"-details-")
 			createReq := &goaliniex.CreateOrderRequest{
 				Currency:          currency,
 				FiatAmount:        100000,
@@ -2,7 +2,6 @@ package goaliniex
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -15,6 +14,7 @@ const (
 	QRTypePIX           QRType = "pix"
 	QRTypeQR3           QRType = "qr3"
 	QRTypePayWithCrypto QRType = "paywithcrypto"
+	QRTypePromptPay     QRType = "promptpay"
 )
 
 type CountryCode string
@@ -40,7 +40,8 @@ type QRCodeInfo struct {
 	CountryCode       CountryCode    `json:"countryCode"`
 	QRType            QRType         `json:"qrType"`
 	AdditionalData    map[string]any `json:"additionalData"`
-	Amount            float64        `json:"amount"`
+	// Amount is absent for static QR codes.
+	Amount float64 `json:"amount,omitempty"`
 }
 
 func (c *Client) GetQRCodeInfo(ctx context.Context, req *GetQRCodeInfoRequest) (*Response[QRCodeInfo], error) {
@@ -48,6 +49,7 @@ func (c *Client) GetQRCodeInfo(ctx context.Context, req *GetQRCodeInfoRequest) (
 		Method:      http.MethodGet,
 		Endpoint:    "/api/v2/public/get-qr-code-info",
 		Params:      req,
+		Operation:   "",
 		SigningData: nil,
 		Header:      nil,
 		Body:        nil,
@@ -60,8 +62,8 @@ func (c *Client) GetQRCodeInfo(ctx context.Context, req *GetQRCodeInfoRequest) (
 		return nil, err
 	}
 
-	response := new(Response[QRCodeInfo])
-	if err := json.Unmarshal(rawResponse, response); err != nil {
+	response, err := decodeResponse[QRCodeInfo](c, apiRequest.Endpoint, rawResponse)
+	if err != nil {
 		return nil, err
 	}
 
@@ -2,15 +2,14 @@ package goaliniex
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
+// Deprecated: Use KycInformationRequest with GetKyc.
 type GetUserKycRequest struct {
 	UserEmail string `json:"userEmail"`
 }
 
+// Deprecated: Use Kyc.
 type UserKycData struct {
 	FirstName        string `json:"firstName"`
 	LastName         string `json:"lastName"`
@@ -31,34 +30,15 @@ type UserKycData struct {
 	RejectReason     string `json:"rejectReason,omitempty"`
 }
 
-func (c *Client) GetUserKyc(ctx context.Context, req *GetUserKycRequest) (*Response[UserKycData], error) {
-	signaturePayload := fmt.Sprintf(
-		"%s|%s|%s",
-		c.partnerCode,
-		req.UserEmail,
-		c.secretKey,
-	)
-
-	apiRequest := request{
-		Method:      http.MethodPost,
-		Endpoint:    "/api/v2/user/get-kyc-information",
-		Params:      req,
-		SigningData: []byte(signaturePayload),
-		Header:      nil,
-		Body:        nil,
-		FullURL:     "",
-		Public:      false,
-	}
-
-	rawResponse, err := c.execute(ctx, &apiRequest)
-	if err != nil {
-		return nil, err
-	}
+func GetUserKycSignaturePayload(partnerCode, secretKey string, req *GetUserKycRequest) (string, error) {
+	return SignaturePayload(OperationGetUserKyc, partnerCode, secretKey, req)
+}
 
-	response := new(Response[UserKycData])
-	if err := json.Unmarshal(rawResponse, response); err != nil {
-		return nil, err
+// Deprecated: Use GetKyc, which returns typed status, gender, ID type and dates.
+func (c *Client) GetUserKyc(ctx context.Context, req *GetUserKycRequest) (*Response[UserKycData], error) {
+	if req == nil {
+		return nil, ErrNilRequest
 	}
 
-	return response, nil
+	return getKyc[UserKycData](ctx, c, OperationGetUserKyc, &KycInformationRequest{UserEmail: req.UserEmail})
 }
@@ -2,8 +2,6 @@ package goaliniex
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"net/http"
 )
 
@@ -17,19 +15,17 @@ type WalletBalance struct {
 	Signature string   `json:"signature"`
 }
 
-func (c *Client) GetWalletBalance(ctx context.Context, req *GetWalletBalanceRequest) (*Response[WalletBalance], error) {
-	signaturePayload := fmt.Sprintf(
-		"%s|%s|%s",
-		c.partnerCode,
-		req.Currency,
-		c.secretKey,
-	)
+func GetWalletBalanceSignaturePayload(partnerCode, secretKey string, req *GetWalletBalanceRequest) (string, error) {
+	return SignaturePayload(OperationGetWalletBalance, partnerCode, secretKey, req)
+}
 
+func (c *Client) GetWalletBalance(ctx context.Context, req *GetWalletBalanceRequest) (*Response[WalletBalance], error) {
 	apiRequest := request{
 		Method:      http.MethodPost,
 		Endpoint:    "/api/v2/wallet/balance",
 		Params:      req,
-		SigningData: []byte(signaturePayload),
+		Operation:   OperationGetWalletBalance,
+		SigningData: nil,
 		Header:      nil,
 		Body:        nil,
 		FullURL:     "",
@@ -41,8 +37,12 @@ func (c *Client) GetWalletBalance(ctx context.Context, req *GetWalletBalanceRequ
 		return nil, err
 	}
 
-	response := new(Response[WalletBalance])
-	if err := json.Unmarshal(rawResponse, response); err != nil {
+	response, err := decodeResponse[WalletBalance](c, apiRequest.Endpoint, rawResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifyResponse(c, OperationGetWalletBalance, response); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,94 @@
+package goaliniex
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// keccak256Rate is the sponge rate of Keccak-256 in bytes.
+const keccak256Rate = 136
+
+// keccakRoundConstants are the iota step constants of Keccak-f[1600].
+func keccakRoundConstants() [24]uint64 {
+	return [24]uint64{
+		0x0000000000000001, 0x0000000000008082, 0x800000000000808a, 0x8000000080008000,
+		0x000000000000808b, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+		0x000000000000008a, 0x0000000000000088, 0x0000000080008009, 0x000000008000000a,
+		0x000000008000808b, 0x800000000000008b, 0x8000000000008089, 0x8000000000008003,
+		0x8000000000008002, 0x8000000000000080, 0x000000000000800a, 0x800000008000000a,
+		0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+	}
+}
+
+// keccakRho and keccakPi drive the combined rho and pi steps: lane
+// keccakPi[i] receives the previous lane rotated by keccakRho[i].
+func keccakRho() [24]int {
+	return [24]int{1, 3, 6, 10, 15, 21, 28, 36, 45, 55, 2, 14, 27, 41, 56, 8, 25, 43, 62, 18, 39, 61, 20, 44}
+}
+
+func keccakPi() [24]int {
+	return [24]int{10, 7, 11, 17, 18, 3, 5, 16, 8, 21, 24, 4, 15, 23, 19, 13, 12, 2, 20, 14, 22, 9, 6, 1}
+}
+
+// keccak256 is the original Keccak-256 used by Ethereum, which differs
+// from SHA3-256 in its padding.
+func keccak256(data []byte) [32]byte {
+	var state [25]uint64
+
+	padded := make([]byte, (len(data)/keccak256Rate+1)*keccak256Rate)
+	copy(padded, data)
+	padded[len(data)] = 0x01
+	padded[len(padded)-1] |= 0x80
+
+	for block := padded; len(block) > 0; block = block[keccak256Rate:] {
+		for i := range keccak256Rate / 8 {
+			state[i] ^= binary.LittleEndian.Uint64(block[i*8:])
+		}
+
+		keccakF1600(&state)
+	}
+
+	var digest [32]byte
+	for i := range 4 {
+		binary.LittleEndian.PutUint64(digest[i*8:], state[i])
+	}
+
+	return digest
+}
+
+func keccakF1600(a *[25]uint64) {
+	rho, pi := keccakRho(), keccakPi()
+
+	for _, roundConstant := range keccakRoundConstants() {
+		var c [5]uint64
+
+		// Theta.
+		for x := range 5 {
+			c[x] = a[x] ^ a[x+5] ^ a[x+10] ^ a[x+15] ^ a[x+20]
+		}
+
+		for x := range 5 {
+			d := c[(x+4)%5] ^ bits.RotateLeft64(c[(x+1)%5], 1)
+			for y := 0; y < 25; y += 5 {
+				a[y+x] ^= d
+			}
+		}
+
+		// Rho and pi.
+		current := a[1]
+		for i := range 24 {
+			current, a[pi[i]] = a[pi[i]], bits.RotateLeft64(current, rho[i])
+		}
+
+		// Chi.
+		for y := 0; y < 25; y += 5 {
+			row := [5]uint64{a[y], a[y+1], a[y+2], a[y+3], a[y+4]}
+			for x := range 5 {
+				a[y+x] = row[x] ^ (^row[(x+1)%5] & row[(x+2)%5])
+			}
+		}
+
+		// Iota.
+		a[0] ^= roundConstant
+	}
+}
@@ -0,0 +1,138 @@
+package goaliniex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// Date is a calendar date as sent by Aliniex. The original text is kept in
+// Raw so values in an unexpected format are not lost; Time is zero when the
+// value could not be parsed.
+type Date struct {
+	Time time.Time
+	Raw  string
+}
+
+func ParseDate(value string) Date {
+	for _, layout := range []string{KycDateLayout, time.RFC3339} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return Date{Time: parsed, Raw: value}
+		}
+	}
+
+	return Date{Time: time.Time{}, Raw: value}
+}
+
+func (d Date) String() string {
+	if d.Raw == "" && !d.Time.IsZero() {
+		return d.Time.Format(KycDateLayout)
+	}
+
+	return d.Raw
+}
+
+func (d Date) IsZero() bool {
+	return d.Time.IsZero()
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var value *string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	if value == nil {
+		*d = Date{Time: time.Time{}, Raw: ""}
+
+		return nil
+	}
+
+	*d = ParseDate(*value)
+
+	return nil
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// Kyc is the KYC record returned by the get-kyc-information endpoint.
+type Kyc struct {
+	FirstName        string    `json:"firstName"`
+	LastName         string    `json:"lastName"`
+	DateOfBirth      Date      `json:"dateOfBirth"`
+	Gender           Gender    `json:"gender"`
+	Nationality      string    `json:"nationality"`
+	IDType           IDType    `json:"idType"`
+	NationalID       string    `json:"nationalId"`
+	IssueDate        Date      `json:"issueDate"`
+	ExpiryDate       Date      `json:"expiryDate"`
+	Address          string    `json:"address"`
+	FrontIDImage     string    `json:"frontIdImage"`
+	BackIDImage      string    `json:"backIdImage"`
+	HoldIDImage      string    `json:"holdIdImage"`
+	PhoneNumber      string    `json:"phoneNumber"`
+	PhoneCountryCode string    `json:"phoneCountryCode"`
+	KycStatus        KycStatus `json:"kycStatus"`
+	RejectReason     string    `json:"rejectReason,omitempty"`
+}
+
+func (k *Kyc) IsVerified() bool {
+	return k.KycStatus == KycStatusVerified
+}
+
+// normalize maps the gender and ID type spellings Aliniex sends, such as "F"
+// or "id_card", to the Gender and IDType constants. It is applied to
+// responses only; decoding a Gender or IDType elsewhere keeps the raw value.
+func (k *Kyc) normalize() {
+	k.Gender = ParseGender(string(k.Gender))
+	k.IDType = ParseIDType(string(k.IDType))
+}
+
+func (c *Client) GetKyc(ctx context.Context, req *KycInformationRequest) (*Response[Kyc], error) {
+	response, err := getKyc[Kyc](ctx, c, OperationGetKycInformation, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Data != nil {
+		response.Data.normalize()
+	}
+
+	return response, nil
+}
+
+// getKyc fetches the KYC record decoded into T, signing as op. The
+// deprecated methods decode into their own string structs so they keep the
+// raw wire values.
+func getKyc[T any](ctx context.Context, c *Client, op Operation, req *KycInformationRequest) (*Response[T], error) {
+	apiRequest := request{
+		Method:      http.MethodPost,
+		Endpoint:    "/api/v2/user/get-kyc-information",
+		Params:      req,
+		Operation:   op,
+		SigningData: nil,
+		Header:      nil,
+		Body:        nil,
+		FullURL:     "",
+		Public:      false,
+	}
+
+	rawResponse, err := c.execute(ctx, &apiRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := decodeResponse[T](c, apiRequest.Endpoint, rawResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifyResponse(c, op, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,261 @@
+package goaliniex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultKycCacheTTL = time.Minute
+
+	kycNotFoundErrorCode     = 404
+	kycUserNotFoundErrorCode = 1001
+)
+
+var ErrOrderRefused = errors.New("order refused")
+
+// OrderAction is the outcome of the KYC gate for a single order.
+type OrderAction string
+
+const (
+	// OrderActionAllow sends the order to Aliniex.
+	OrderActionAllow OrderAction = "ALLOW"
+	// OrderActionRefuse rejects the order with ErrOrderRefused.
+	OrderActionRefuse OrderAction = "REFUSE"
+	// OrderActionRoute hands the order to KycGatePolicy.Route instead.
+	OrderActionRoute OrderAction = "ROUTE"
+)
+
+// OrderDecision records why CreateOrderForUser allowed, refused or routed an
+// order. It is passed to KycGatePolicy.OnDecision for auditing.
+type OrderDecision struct {
+	ExternalOrderID string
+	UserEmail       string
+	FiatCurrency    FiatCurrency
+	FiatAmount      float64
+	KycStatus       KycStatus
+	KycVerified     bool
+	// CachedKyc reports whether the KYC status came from the cache.
+	CachedKyc bool
+	// Limit is the unverified threshold that applied, or 0 if none did.
+	Limit     float64
+	Action    OrderAction
+	Reason    string
+	DecidedAt time.Time
+}
+
+// OrderRouter handles orders the gate routes away from CreateOrder, for
+// example to a KYC-first flow or a manual review queue.
+type OrderRouter func(
+	ctx context.Context,
+	req *CreateOrderRequest,
+	decision OrderDecision,
+) (*Response[CreateOrderResponse], error)
+
+// KycGatePolicy configures CreateOrderForUser.
+type KycGatePolicy struct {
+	// CacheTTL is how long a looked-up KYC status is reused. Defaults to one
+	// minute; a negative value disables caching.
+	CacheTTL time.Duration
+	// UnverifiedLimits caps the fiat amount of orders from users whose KYC
+	// is not verified. Currencies without an entry are not capped.
+	UnverifiedLimits map[FiatCurrency]float64
+	// OverLimitAction is applied to orders above the limit. Defaults to
+	// OrderActionRefuse; OrderActionRoute requires Route.
+	OverLimitAction OrderAction
+	Route           OrderRouter
+	// OnDecision is called once for every order, before it is sent,
+	// refused or routed.
+	OnDecision func(decision OrderDecision)
+}
+
+type kycCacheEntry struct {
+	status  KycStatus
+	expires time.Time
+}
+
+type kycGate struct {
+	policy KycGatePolicy
+	mu     sync.Mutex
+	cache  map[string]kycCacheEntry
+}
+
+func newKycGate(policy KycGatePolicy) *kycGate {
+	if policy.CacheTTL == 0 {
+		policy.CacheTTL = defaultKycCacheTTL
+	}
+
+	if policy.OverLimitAction != OrderActionAllow && policy.OverLimitAction != OrderActionRoute {
+		policy.OverLimitAction = OrderActionRefuse
+	}
+
+	return &kycGate{
+		policy: policy,
+		mu:     sync.Mutex{},
+		cache:  make(map[string]kycCacheEntry),
+	}
+}
+
+// WithKycGate sets the policy used by CreateOrderForUser.
+func WithKycGate(policy KycGatePolicy) Option {
+	return func(c *Client) {
+		c.kycGate = newKycGate(policy)
+	}
+}
+
+func kycCacheKey(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func (g *kycGate) cached(email string, now time.Time) (KycStatus, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	entry, ok := g.cache[kycCacheKey(email)]
+	if !ok || !now.Before(entry.expires) {
+		return "", false
+	}
+
+	return entry.status, true
+}
+
+func (g *kycGate) store(email string, status KycStatus, now time.Time) {
+	if g.policy.CacheTTL < 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.cache[kycCacheKey(email)] = kycCacheEntry{status: status, expires: now.Add(g.policy.CacheTTL)}
+}
+
+// InvalidateKycCache drops the cached KYC status for email, e.g. after a
+// KYC webhook reports a change.
+func (c *Client) InvalidateKycCache(email string) {
+	c.kycGate.mu.Lock()
+	defer c.kycGate.mu.Unlock()
+
+	delete(c.kycGate.cache, kycCacheKey(email))
+}
+
+// isKycNotFound reports whether errorCode is Aliniex's "User not found"
+// reply to a KYC lookup. Both codes appear in its sample responses.
+func isKycNotFound(errorCode int) bool {
+	return errorCode == kycNotFoundErrorCode || errorCode == kycUserNotFoundErrorCode
+}
+
+// kycStatus returns the user's KYC status, from the cache when fresh. A user
+// Aliniex has no KYC record for is reported as KycStatusNone; other failed
+// lookups are returned as ErrKycLookupError and not cached.
+func (c *Client) kycStatus(ctx context.Context, email string, now time.Time) (KycStatus, bool, error) {
+	if status, ok := c.kycGate.cached(email, now); ok {
+		return status, true, nil
+	}
+
+	response, err := c.GetKyc(ctx, &KycInformationRequest{UserEmail: email})
+	if err != nil {
+		return "", false, fmt.Errorf("%w: %w", ErrKycLookupError, err)
+	}
+
+	if !response.Success && !isKycNotFound(response.ErrorCode) {
+		return "", false, fmt.Errorf("%w: %s (errorCode=%d)", ErrKycLookupError, response.Message, response.ErrorCode)
+	}
+
+	status := KycStatusNone
+	if response.Success && response.Data != nil && response.Data.KycStatus != "" {
+		status = response.Data.KycStatus
+	}
+
+	c.kycGate.store(email, status, now)
+
+	return status, false, nil
+}
+
+func (g *kycGate) decide(req *CreateOrderRequest, status KycStatus, cached bool, now time.Time) OrderDecision {
+	decision := OrderDecision{
+		ExternalOrderID: req.ExternalOrderID,
+		UserEmail:       req.UserEmail,
+		FiatCurrency:    req.FiatCurrency,
+		FiatAmount:      req.FiatAmount,
+		KycStatus:       status,
+		KycVerified:     status == KycStatusVerified,
+		CachedKyc:       cached,
+		Limit:           0,
+		Action:          OrderActionAllow,
+		Reason:          "",
+		DecidedAt:       now,
+	}
+
+	if decision.KycVerified {
+		decision.Reason = "kyc verified"
+
+		return decision
+	}
+
+	limit, capped := g.policy.UnverifiedLimits[req.FiatCurrency]
+	if !capped {
+		decision.Reason = "no unverified limit for " + string(req.FiatCurrency)
+
+		return decision
+	}
+
+	decision.Limit = limit
+
+	if req.FiatAmount <= limit {
+		decision.Reason = "within unverified limit"
+
+		return decision
+	}
+
+	decision.Action = g.policy.OverLimitAction
+	decision.Reason = fmt.Sprintf("%s %s exceeds unverified limit %s",
+		FormatSignatureValue(req.FiatAmount), req.FiatCurrency, FormatSignatureValue(limit))
+
+	return decision
+}
+
+// CreateOrderForUser looks up the user's KYC status, sets UserKYCVerified
+// accordingly and applies the client's KycGatePolicy before creating the
+// order. The caller's request is not modified. Refused orders return an
+// error wrapping ErrOrderRefused.
+func (c *Client) CreateOrderForUser(ctx context.Context, req *CreateOrderRequest) (*Response[CreateOrderResponse], error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+
+	now := time.Now()
+
+	status, cached, err := c.kycStatus(ctx, req.UserEmail, now)
+	if err != nil {
+		return nil, err
+	}
+
+	gated := *req
+	gated.UserKYCVerified = status == KycStatusVerified
+
+	decision := c.kycGate.decide(&gated, status, cached, now)
+
+	if decision.Action == OrderActionRoute && c.kycGate.policy.Route == nil {
+		decision.Action = OrderActionRefuse
+		decision.Reason += "; no router configured"
+	}
+
+	if c.kycGate.policy.OnDecision != nil {
+		c.kycGate.policy.OnDecision(decision)
+	}
+
+	switch decision.Action {
+	case OrderActionRefuse:
+		return nil, fmt.Errorf("%w: %s", ErrOrderRefused, decision.Reason)
+	case OrderActionRoute:
+		return c.kycGate.policy.Route(ctx, &gated, decision)
+	case OrderActionAllow:
+	}
+
+	return c.CreateOrder(ctx, &gated)
+}
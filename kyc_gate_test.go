@@ -0,0 +1,302 @@
+package goaliniex_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andyle182810/goaliniex"
+)
+
+const gateOrderBody = `{"success":true,"message":"Success","errorCode":0,` +
+	`"data":{"externalOrderId":"ORDER-1","fiatAmount":500000,"status":"AWAITING_PAYMENT"}}`
+
+func gateOrderRequest(amount float64) *goaliniex.CreateOrderRequest {
+	return &goaliniex.CreateOrderRequest{
+		Currency:          goaliniex.CurrencyUSDT,
+		FiatAmount:        amount,
+		FiatCurrency:      goaliniex.FiatCurrencyVND,
+		BankCode:          "970422",
+		BankAccountNumber: "0123456789",
+		ExternalOrderID:   "ORDER-1",
+		WebhookSecretKey:  "",
+		UserEmail:         "Jane@Example.com",
+		UserKYCVerified:   true,
+		Content:           "",
+		ExtendInfo:        nil,
+	}
+}
+
+func gatePolicy(decisions *[]goaliniex.OrderDecision) goaliniex.KycGatePolicy {
+	return goaliniex.KycGatePolicy{
+		CacheTTL:         time.Minute,
+		UnverifiedLimits: map[goaliniex.FiatCurrency]float64{goaliniex.FiatCurrencyVND: 1_000_000},
+		OverLimitAction:  goaliniex.OrderActionRefuse,
+		Route:            nil,
+		OnDecision: func(decision goaliniex.OrderDecision) {
+			*decisions = append(*decisions, decision)
+		},
+	}
+}
+
+//nolint:bodyclose // Response bodies closed by client
+func TestClient_CreateOrderForUser_SetsVerifiedFlag(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		status   goaliniex.KycStatus
+		amount   float64
+		verified bool
+	}{
+		{name: "verified", status: goaliniex.KycStatusVerified, amount: 5_000_000, verified: true},
+		{name: "unverified within limit", status: goaliniex.KycStatusProcessing, amount: 500_000, verified: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var decisions []goaliniex.OrderDecision
+
+			recorder := &recordingHTTPClient{
+				response: sequenceResponses(
+					mockResponse(http.StatusOK, kycStatusBody(tc.status, "")),
+					mockResponse(http.StatusOK, gateOrderBody),
+				),
+			}
+
+			client, err := newTestClientWithMock(recorder, goaliniex.WithKycGate(gatePolicy(&decisions)))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			req := gateOrderRequest(tc.amount)
+
+			resp, err := client.CreateOrderForUser(context.Background(), req)
+			if err != nil {
+				t.Fatalf("CreateOrderForUser returned error: %v", err)
+			}
+
+			if resp.Data == nil || resp.Data.ExternalOrderID != "ORDER-1" {
+				t.Errorf("unexpected response %+v", resp)
+			}
+
+			if got := recorder.lastBody(t)["userKycVerified"]; got != tc.verified {
+				t.Errorf("expected userKycVerified=%v, got %v", tc.verified, got)
+			}
+
+			if !req.UserKYCVerified {
+				t.Error("caller's request was modified")
+			}
+
+			if len(decisions) != 1 || decisions[0].Action != goaliniex.OrderActionAllow {
+				t.Errorf("expected one allow decision, got %+v", decisions)
+			}
+		})
+	}
+}
+
+//nolint:bodyclose // Response bodies closed by client
+func TestClient_CreateOrderForUser_RefusesOverLimit(t *testing.T) {
+	t.Parallel()
+
+	var decisions []goaliniex.OrderDecision
+
+	recorder := &recordingHTTPClient{
+		response: func() *http.Response {
+			return mockResponse(http.StatusOK, kycStatusBody(goaliniex.KycStatusNone, ""))
+		},
+	}
+
+	client, err := newTestClientWithMock(recorder, goaliniex.WithKycGate(gatePolicy(&decisions)))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = client.CreateOrderForUser(context.Background(), gateOrderRequest(2_000_000))
+	if !errors.Is(err, goaliniex.ErrOrderRefused) {
+		t.Fatalf("expected ErrOrderRefused, got %v", err)
+	}
+
+	if len(recorder.requests) != 1 || !strings.HasSuffix(recorder.requests[0].URL.Path, "/get-kyc-information") {
+		t.Errorf("expected only the KYC lookup to be sent, got %d requests", len(recorder.requests))
+	}
+
+	decision := decisions[0]
+	if decision.Action != goaliniex.OrderActionRefuse || decision.Limit != 1_000_000 || decision.KycVerified {
+		t.Errorf("unexpected decision %+v", decision)
+	}
+}
+
+//nolint:bodyclose // Response bodies closed by client
+func TestClient_CreateOrderForUser_RoutesOverLimit(t *testing.T) {
+	t.Parallel()
+
+	var decisions []goaliniex.OrderDecision
+
+	recorder := &recordingHTTPClient{
+		response: func() *http.Response {
+			return mockResponse(http.StatusOK, kycStatusBody(goaliniex.KycStatusRejected, "blurry"))
+		},
+	}
+
+	policy := gatePolicy(&decisions)
+	policy.OverLimitAction = goaliniex.OrderActionRoute
+
+	var routed *goaliniex.CreateOrderRequest
+
+	policy.Route = func(
+		_ context.Context,
+		req *goaliniex.CreateOrderRequest,
+		_ goaliniex.OrderDecision,
+	) (*goaliniex.Response[goaliniex.CreateOrderResponse], error) {
+		routed = req
+
+		return &goaliniex.Response[goaliniex.CreateOrderResponse]{
+			Success: true, Message: "routed", Data: nil, ErrorCode: 0,
+		}, nil
+	}
+
+	client, err := newTestClientWithMock(recorder, goaliniex.WithKycGate(policy))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	resp, err := client.CreateOrderForUser(context.Background(), gateOrderRequest(2_000_000))
+	if err != nil {
+		t.Fatalf("CreateOrderForUser returned error: %v", err)
+	}
+
+	if resp.Message != "routed" || routed == nil || routed.UserKYCVerified {
+		t.Errorf("expected unverified order to be routed, got %+v", resp)
+	}
+
+	if decisions[0].Action != goaliniex.OrderActionRoute || decisions[0].KycStatus != goaliniex.KycStatusRejected {
+		t.Errorf("unexpected decision %+v", decisions[0])
+	}
+}
+
+//nolint:bodyclose // Response bodies closed by client
+func TestClient_CreateOrderForUser_CachesKycStatus(t *testing.T) {
+	t.Parallel()
+
+	var decisions []goaliniex.OrderDecision
+
+	recorder := &recordingHTTPClient{
+		response: sequenceResponses(
+			mockResponse(http.StatusOK, kycStatusBody(goaliniex.KycStatusVerified, "")),
+			mockResponse(http.StatusOK, gateOrderBody),
+			mockResponse(http.StatusOK, gateOrderBody),
+			mockResponse(http.StatusOK, kycStatusBody(goaliniex.KycStatusVerified, "")),
+			mockResponse(http.StatusOK, gateOrderBody),
+		),
+	}
+
+	client, err := newTestClientWithMock(recorder, goaliniex.WithKycGate(gatePolicy(&decisions)))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	for range 2 {
+		if _, err := client.CreateOrderForUser(context.Background(), gateOrderRequest(10)); err != nil {
+			t.Fatalf("CreateOrderForUser returned error: %v", err)
+		}
+	}
+
+	if len(recorder.requests) != 3 {
+		t.Fatalf("expected the second order to reuse the cached status, got %d requests", len(recorder.requests))
+	}
+
+	if decisions[0].CachedKyc || !decisions[1].CachedKyc {
+		t.Errorf("unexpected cache flags %v, %v", decisions[0].CachedKyc, decisions[1].CachedKyc)
+	}
+
+	client.InvalidateKycCache("jane@example.com")
+
+	if _, err := client.CreateOrderForUser(context.Background(), gateOrderRequest(10)); err != nil {
+		t.Fatalf("CreateOrderForUser returned error: %v", err)
+	}
+
+	if len(recorder.requests) != 5 || decisions[2].CachedKyc {
+		t.Errorf("expected a fresh lookup after invalidation, got %d requests", len(recorder.requests))
+	}
+}
+
+//nolint:bodyclose // Response bodies closed by client
+func TestClient_CreateOrderForUser_CachesOnlyUserNotFound(t *testing.T) {
+	t.Parallel()
+
+	const (
+		serverError = `{"success":false,"message":"Internal error","data":null,"errorCode":500}`
+		notFound    = `{"success":false,"message":"User not found","data":null,"errorCode":1001}`
+	)
+
+	var decisions []goaliniex.OrderDecision
+
+	recorder := &recordingHTTPClient{
+		response: sequenceResponses(
+			mockResponse(http.StatusOK, serverError),
+			mockResponse(http.StatusOK, kycStatusBody(goaliniex.KycStatusVerified, "")),
+			mockResponse(http.StatusOK, gateOrderBody),
+		),
+	}
+
+	client, err := newTestClientWithMock(recorder, goaliniex.WithKycGate(gatePolicy(&decisions)))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = client.CreateOrderForUser(context.Background(), gateOrderRequest(2_000_000))
+	if !errors.Is(err, goaliniex.ErrKycLookupError) || len(decisions) != 0 {
+		t.Fatalf("expected ErrKycLookupError without a decision, got %v", err)
+	}
+
+	// The failure was not cached, so the verified user is looked up again.
+	if _, err := client.CreateOrderForUser(context.Background(), gateOrderRequest(2_000_000)); err != nil {
+		t.Fatalf("CreateOrderForUser returned error: %v", err)
+	}
+
+	if len(decisions) != 1 || decisions[0].CachedKyc || !decisions[0].KycVerified {
+		t.Errorf("expected a fresh verified decision, got %+v", decisions)
+	}
+
+	recorder.response = func() *http.Response { return mockResponse(http.StatusOK, notFound) }
+
+	client.InvalidateKycCache("jane@example.com")
+
+	for range 2 {
+		_, err = client.CreateOrderForUser(context.Background(), gateOrderRequest(2_000_000))
+		if !errors.Is(err, goaliniex.ErrOrderRefused) {
+			t.Fatalf("expected ErrOrderRefused, got %v", err)
+		}
+	}
+
+	if last := decisions[len(decisions)-1]; last.KycStatus != goaliniex.KycStatusNone || !last.CachedKyc {
+		t.Errorf("expected a cached NONE status for an unknown user, got %+v", last)
+	}
+}
+
+//nolint:bodyclose // Response bodies closed by client
+func TestClient_CreateOrderForUser_LookupFailure(t *testing.T) {
+	t.Parallel()
+
+	client, err := newTestClientWithMock(&recordingHTTPClient{
+		response: func() *http.Response { return mockResponse(http.StatusInternalServerError, "boom") },
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = client.CreateOrderForUser(context.Background(), gateOrderRequest(10))
+	if !errors.Is(err, goaliniex.ErrKycLookupError) {
+		t.Errorf("expected ErrKycLookupError, got %v", err)
+	}
+
+	if _, err := client.CreateOrderForUser(context.Background(), nil); !errors.Is(err, goaliniex.ErrNilRequest) {
+		t.Errorf("expected ErrNilRequest, got %v", err)
+	}
+}
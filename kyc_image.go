@@ -0,0 +1,543 @@
+package goaliniex
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"os"
+)
+
+const (
+	MimeTypeJPEG = "image/jpeg"
+	MimeTypePNG  = "image/png"
+
+	defaultImageMaxBytes    = 2 << 20
+	defaultImageMaxInput    = 20 << 20
+	defaultImageMaxWidth    = 2048
+	defaultImageMaxHeight   = 2048
+	defaultImageMaxPixels   = 50_000_000
+	defaultImageJPEGQuality = 90
+	maxImageJPEGQuality     = 100
+	minImageJPEGQuality     = 50
+	imageQualityStep        = 10
+	imageDownscaleFactor    = 0.75
+)
+
+var (
+	ErrUnsupportedImageType = errors.New("unsupported image type")
+	ErrImageDecode          = errors.New("failed to decode image")
+	ErrImageEncode          = errors.New("failed to encode image")
+	ErrImageTooLarge        = errors.New("image exceeds size limit")
+	ErrImageTooSmall        = errors.New("image below minimum dimensions")
+)
+
+// ImageOptions bounds the images accepted for KYC documents. Zero values fall
+// back to the defaults of DefaultImageOptions.
+type ImageOptions struct {
+	// MaxBytes is the largest encoded image that is returned. Larger images
+	// are re-encoded at lower quality and then downscaled until they fit.
+	MaxBytes int
+	// MaxInputBytes caps how much is read from a reader or file.
+	MaxInputBytes int
+	// MaxWidth and MaxHeight bound the output dimensions; larger images are
+	// downscaled preserving the aspect ratio.
+	MaxWidth  int
+	MaxHeight int
+	// MaxPixels rejects images whose declared width times height exceeds it,
+	// before they are decoded into memory.
+	MaxPixels int
+	// MinWidth and MinHeight reject images too small to be legible.
+	MinWidth  int
+	MinHeight int
+	// JPEGQuality is the starting quality used when a JPEG is re-encoded.
+	JPEGQuality int
+}
+
+func DefaultImageOptions() ImageOptions {
+	return ImageOptions{
+		MaxBytes:      defaultImageMaxBytes,
+		MaxInputBytes: defaultImageMaxInput,
+		MaxWidth:      defaultImageMaxWidth,
+		MaxHeight:     defaultImageMaxHeight,
+		MaxPixels:     defaultImageMaxPixels,
+		MinWidth:      0,
+		MinHeight:     0,
+		JPEGQuality:   defaultImageJPEGQuality,
+	}
+}
+
+func (o *ImageOptions) withDefaults() ImageOptions {
+	opts := DefaultImageOptions()
+	if o == nil {
+		return opts
+	}
+
+	if o.MaxBytes > 0 {
+		opts.MaxBytes = o.MaxBytes
+	}
+
+	if o.MaxInputBytes > 0 {
+		opts.MaxInputBytes = o.MaxInputBytes
+	}
+
+	if o.MaxWidth > 0 {
+		opts.MaxWidth = o.MaxWidth
+	}
+
+	if o.MaxHeight > 0 {
+		opts.MaxHeight = o.MaxHeight
+	}
+
+	if o.MaxPixels > 0 {
+		opts.MaxPixels = o.MaxPixels
+	}
+
+	if o.JPEGQuality > 0 {
+		opts.JPEGQuality = min(o.JPEGQuality, maxImageJPEGQuality)
+	}
+
+	opts.MinWidth = o.MinWidth
+	opts.MinHeight = o.MinHeight
+
+	return opts
+}
+
+// ImageDataURIFromFile reads a JPEG or PNG file and returns it as a data URI
+// suitable for SubmitKycRequest.FrontIDImage and friends.
+func ImageDataURIFromFile(path string, opts *ImageOptions) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	return ImageDataURIFromReader(file, opts)
+}
+
+// ImageDataURIFromReader is like ImageDataURI but reads the image from r.
+func ImageDataURIFromReader(r io.Reader, opts *ImageOptions) (string, error) {
+	limits := opts.withDefaults()
+
+	data, err := io.ReadAll(io.LimitReader(r, int64(limits.MaxInputBytes)+1))
+	if err != nil {
+		return "", err
+	}
+
+	if len(data) > limits.MaxInputBytes {
+		return "", fmt.Errorf("%w: input larger than %d bytes", ErrImageTooLarge, limits.MaxInputBytes)
+	}
+
+	return ImageDataURI(data, &limits)
+}
+
+// ImageDataURI sniffs the image type, strips EXIF and other metadata, applies
+// the EXIF orientation, downscales or recompresses the image to fit opts and
+// returns a "data:<mime>;base64,..." URI.
+func ImageDataURI(data []byte, opts *ImageOptions) (string, error) {
+	limits := opts.withDefaults()
+
+	if len(data) > limits.MaxInputBytes {
+		return "", fmt.Errorf("%w: input larger than %d bytes", ErrImageTooLarge, limits.MaxInputBytes)
+	}
+
+	mimeType := http.DetectContentType(data)
+	if mimeType != MimeTypeJPEG && mimeType != MimeTypePNG {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedImageType, mimeType)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrImageDecode, err)
+	}
+
+	if int64(config.Width)*int64(config.Height) > int64(limits.MaxPixels) {
+		return "", fmt.Errorf("%w: %dx%d exceeds %d pixels",
+			ErrImageTooLarge, config.Width, config.Height, limits.MaxPixels)
+	}
+
+	orientation := 1
+	if mimeType == MimeTypeJPEG {
+		orientation = jpegOrientation(data)
+	}
+
+	width, height := config.Width, config.Height
+	if orientation >= 5 { //nolint:mnd // orientations 5-8 swap the axes
+		width, height = height, width
+	}
+
+	if width < limits.MinWidth || height < limits.MinHeight {
+		return "", fmt.Errorf("%w: %dx%d, want at least %dx%d",
+			ErrImageTooSmall, width, height, limits.MinWidth, limits.MinHeight)
+	}
+
+	stripped, err := stripImageMetadata(mimeType, data)
+	if err != nil {
+		return "", err
+	}
+
+	fits := width <= limits.MaxWidth && height <= limits.MaxHeight && len(stripped) <= limits.MaxBytes
+	if fits && orientation == 1 {
+		return encodeDataURI(mimeType, stripped), nil
+	}
+
+	encoded, err := reencodeImage(mimeType, data, orientation, &limits)
+	if err != nil {
+		return "", err
+	}
+
+	return encodeDataURI(mimeType, encoded), nil
+}
+
+func encodeDataURI(mimeType string, data []byte) string {
+	return "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+func reencodeImage(mimeType string, data []byte, orientation int, limits *ImageOptions) ([]byte, error) {
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrImageDecode, err)
+	}
+
+	img := orientImage(toNRGBA(decoded), orientation)
+	img = fitImage(img, limits.MaxWidth, limits.MaxHeight)
+
+	for {
+		encoded, fits, err := encodeWithinLimit(mimeType, img, limits)
+		if err != nil {
+			return nil, err
+		}
+
+		if fits {
+			return encoded, nil
+		}
+
+		bounds := img.Bounds()
+		nextWidth := int(float64(bounds.Dx()) * imageDownscaleFactor)
+		nextHeight := int(float64(bounds.Dy()) * imageDownscaleFactor)
+
+		if nextWidth < max(limits.MinWidth, 1) || nextHeight < max(limits.MinHeight, 1) {
+			return nil, fmt.Errorf("%w: cannot fit %d bytes without going below minimum dimensions",
+				ErrImageTooLarge, limits.MaxBytes)
+		}
+
+		img = resizeImage(img, nextWidth, nextHeight)
+	}
+}
+
+// encodeWithinLimit reports false when img cannot be encoded within
+// limits.MaxBytes at its current size.
+func encodeWithinLimit(mimeType string, img image.Image, limits *ImageOptions) ([]byte, bool, error) {
+	var buf bytes.Buffer
+
+	if mimeType == MimeTypePNG {
+		encoder := png.Encoder{CompressionLevel: png.BestCompression, BufferPool: nil}
+		if err := encoder.Encode(&buf, img); err != nil {
+			return nil, false, fmt.Errorf("%w: %w", ErrImageEncode, err)
+		}
+
+		return buf.Bytes(), buf.Len() <= limits.MaxBytes, nil
+	}
+
+	for quality := limits.JPEGQuality; ; quality -= imageQualityStep {
+		quality = max(quality, minImageJPEGQuality)
+
+		buf.Reset()
+
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+			return nil, false, fmt.Errorf("%w: %w", ErrImageEncode, err)
+		}
+
+		if buf.Len() <= limits.MaxBytes {
+			return buf.Bytes(), true, nil
+		}
+
+		if quality == minImageJPEGQuality {
+			return nil, false, nil
+		}
+	}
+}
+
+func toNRGBA(img image.Image) *image.NRGBA {
+	if nrgba, ok := img.(*image.NRGBA); ok && nrgba.Rect.Min == (image.Point{}) {
+		return nrgba
+	}
+
+	bounds := img.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, bounds.Min, draw.Src)
+
+	return dst
+}
+
+func fitImage(img *image.NRGBA, maxWidth, maxHeight int) *image.NRGBA {
+	width, height := img.Rect.Dx(), img.Rect.Dy()
+	if width <= maxWidth && height <= maxHeight {
+		return img
+	}
+
+	scale := min(float64(maxWidth)/float64(width), float64(maxHeight)/float64(height))
+
+	return resizeImage(img, max(int(float64(width)*scale), 1), max(int(float64(height)*scale), 1))
+}
+
+// resizeImage downscales src with an area-averaging (box) filter.
+func resizeImage(src *image.NRGBA, width, height int) *image.NRGBA {
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	srcWidth, srcHeight := src.Rect.Dx(), src.Rect.Dy()
+
+	for y := range height {
+		y0 := y * srcHeight / height
+		y1 := max((y+1)*srcHeight/height, y0+1)
+
+		for x := range width {
+			x0 := x * srcWidth / width
+			x1 := max((x+1)*srcWidth/width, x0+1)
+
+			var sum [4]int
+
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					for c := range 4 {
+						sum[c] += int(row[sx*4+c])
+					}
+				}
+			}
+
+			count := (y1 - y0) * (x1 - x0)
+			offset := y*dst.Stride + x*4
+
+			for c := range 4 {
+				dst.Pix[offset+c] = uint8(sum[c] / count) //nolint:gosec // average of uint8 values
+			}
+		}
+	}
+
+	return dst
+}
+
+// orientImage applies an EXIF orientation (1-8) so the pixels are upright.
+func orientImage(src *image.NRGBA, orientation int) *image.NRGBA {
+	if orientation < 2 || orientation > 8 { //nolint:mnd // EXIF orientations are 1-8
+		return src
+	}
+
+	width, height := src.Rect.Dx(), src.Rect.Dy()
+	dstWidth, dstHeight := width, height
+
+	if orientation >= 5 { //nolint:mnd // orientations 5-8 swap the axes
+		dstWidth, dstHeight = height, width
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+
+	for y := range height {
+		for x := range width {
+			dx, dy := orientedPoint(orientation, x, y, width, height)
+			copy(dst.Pix[dy*dst.Stride+dx*4:dy*dst.Stride+dx*4+4], src.Pix[y*src.Stride+x*4:y*src.Stride+x*4+4])
+		}
+	}
+
+	return dst
+}
+
+//nolint:mnd // EXIF orientation values
+func orientedPoint(orientation, x, y, width, height int) (int, int) {
+	switch orientation {
+	case 2:
+		return width - 1 - x, y
+	case 3:
+		return width - 1 - x, height - 1 - y
+	case 4:
+		return x, height - 1 - y
+	case 5:
+		return y, x
+	case 6:
+		return height - 1 - y, x
+	case 7:
+		return height - 1 - y, width - 1 - x
+	case 8:
+		return y, width - 1 - x
+	default:
+		return x, y
+	}
+}
+
+func stripImageMetadata(mimeType string, data []byte) ([]byte, error) {
+	if mimeType == MimeTypePNG {
+		return stripPNGMetadata(data)
+	}
+
+	return stripJPEGMetadata(data)
+}
+
+const (
+	jpegMarkerPrefix = 0xFF
+	jpegMarkerSOI    = 0xD8
+	jpegMarkerSOS    = 0xDA
+	jpegMarkerAPP0   = 0xE0
+	jpegMarkerAPP1   = 0xE1
+	jpegMarkerAPP15  = 0xEF
+	jpegMarkerCOM    = 0xFE
+)
+
+// stripJPEGMetadata drops APP1-APP15 (EXIF, XMP, ICC, ...) and comment
+// segments while keeping the JFIF header and the compressed image data
+// untouched.
+func stripJPEGMetadata(data []byte) ([]byte, error) {
+	if len(data) < 4 || data[0] != jpegMarkerPrefix || data[1] != jpegMarkerSOI {
+		return nil, fmt.Errorf("%w: missing JPEG start marker", ErrImageDecode)
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:2]...)
+	pos := 2
+
+	for pos+4 <= len(data) {
+		if data[pos] != jpegMarkerPrefix {
+			return nil, fmt.Errorf("%w: malformed JPEG segment", ErrImageDecode)
+		}
+
+		marker := data[pos+1]
+		if marker == jpegMarkerSOS {
+			break
+		}
+
+		length := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		end := pos + 2 + length
+
+		if length < 2 || end > len(data) {
+			return nil, fmt.Errorf("%w: truncated JPEG segment", ErrImageDecode)
+		}
+
+		if !isJPEGMetadataMarker(marker) {
+			out = append(out, data[pos:end]...)
+		}
+
+		pos = end
+	}
+
+	return append(out, data[pos:]...), nil
+}
+
+func isJPEGMetadataMarker(marker byte) bool {
+	return (marker >= jpegMarkerAPP1 && marker <= jpegMarkerAPP15) || marker == jpegMarkerCOM
+}
+
+// jpegOrientation returns the EXIF orientation of a JPEG, or 1 when absent.
+func jpegOrientation(data []byte) int {
+	pos := 2
+
+	for pos+4 <= len(data) && data[pos] == jpegMarkerPrefix {
+		marker := data[pos+1]
+		if marker == jpegMarkerSOS {
+			break
+		}
+
+		length := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		end := pos + 2 + length
+
+		if length < 2 || end > len(data) {
+			break
+		}
+
+		segment := data[pos+4 : end]
+		if marker == jpegMarkerAPP1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+
+		pos = end
+	}
+
+	return 1
+}
+
+const exifOrientationTag = 0x0112
+
+//nolint:mnd // TIFF header layout
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+
+	entries := int(order.Uint16(tiff[ifd : ifd+2]))
+
+	for i := range entries {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+
+		if order.Uint16(tiff[entry:entry+2]) == exifOrientationTag {
+			value := int(order.Uint16(tiff[entry+8 : entry+10]))
+			if value >= 1 && value <= 8 {
+				return value
+			}
+
+			return 1
+		}
+	}
+
+	return 1
+}
+
+const pngSignature = "\x89PNG\r\n\x1a\n"
+
+// stripPNGMetadata drops textual, EXIF and timestamp chunks.
+func stripPNGMetadata(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, []byte(pngSignature)) {
+		return nil, fmt.Errorf("%w: missing PNG signature", ErrImageDecode)
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, pngSignature...)
+	pos := len(pngSignature)
+
+	for pos < len(data) {
+		if pos+8 > len(data) {
+			return nil, fmt.Errorf("%w: truncated PNG chunk", ErrImageDecode)
+		}
+
+		length := int(binary.BigEndian.Uint32(data[pos : pos+4]))
+		chunkType := string(data[pos+4 : pos+8])
+		end := pos + 12 + length
+
+		if length < 0 || end > len(data) {
+			return nil, fmt.Errorf("%w: truncated PNG chunk", ErrImageDecode)
+		}
+
+		switch chunkType {
+		case "eXIf", "tEXt", "zTXt", "iTXt", "tIME":
+		default:
+			out = append(out, data[pos:end]...)
+		}
+
+		pos = end
+	}
+
+	return out, nil
+}
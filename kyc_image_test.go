@@ -0,0 +1,367 @@
+package goaliniex_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andyle182810/goaliniex"
+)
+
+func testImage(width, height int, noisy bool) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	rng := rand.New(rand.NewPCG(1, 2)) //nolint:gosec // deterministic test data
+
+	for y := range height {
+		for x := range width {
+			pixel := color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255} //nolint:gosec // wraps intentionally
+			if noisy {
+				pixel = color.NRGBA{R: uint8(rng.IntN(256)), G: uint8(rng.IntN(256)), B: uint8(rng.IntN(256)), A: 255}
+			}
+
+			img.SetNRGBA(x, y, pixel)
+		}
+	}
+
+	return img
+}
+
+func encodeTestJPEG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatalf("encode jpeg: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func encodeTestPNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+// withEXIFOrientation inserts an APP1 EXIF segment carrying only the
+// orientation tag right after the JPEG SOI marker.
+func withEXIFOrientation(data []byte, orientation uint16) []byte {
+	tiff := make([]byte, 0, 26)
+	tiff = append(tiff, 'I', 'I', 42, 0)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 8)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 3)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+
+	out := make([]byte, 0, len(data)+len(segment)+4)
+	out = append(out, data[:2]...)
+	out = append(out, 0xFF, 0xE1)
+	out = binary.BigEndian.AppendUint16(out, uint16(len(segment)+2)) //nolint:gosec // small segment
+	out = append(out, segment...)
+
+	return append(out, data[2:]...)
+}
+
+func decodeDataURI(t *testing.T, uri string) (string, image.Image, []byte) {
+	t.Helper()
+
+	header, payload, found := strings.Cut(uri, ",")
+	if !found {
+		t.Fatalf("malformed data URI %q", uri)
+	}
+
+	mimeType, ok := strings.CutSuffix(strings.TrimPrefix(header, "data:"), ";base64")
+	if !ok {
+		t.Fatalf("data URI is not base64: %q", header)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		t.Fatalf("decode base64: %v", err)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("decode image: %v", err)
+	}
+
+	return mimeType, img, raw
+}
+
+func TestImageDataURI_SmallJPEGPassesThrough(t *testing.T) {
+	t.Parallel()
+
+	data := encodeTestJPEG(t, testImage(64, 32, false))
+
+	uri, err := goaliniex.ImageDataURI(data, nil)
+	if err != nil {
+		t.Fatalf("ImageDataURI returned error: %v", err)
+	}
+
+	if uri != "data:image/jpeg;base64,"+base64.StdEncoding.EncodeToString(data) {
+		t.Error("expected unchanged JPEG without metadata to be encoded as-is")
+	}
+}
+
+func TestImageDataURI_PNG(t *testing.T) {
+	t.Parallel()
+
+	uri, err := goaliniex.ImageDataURI(encodeTestPNG(t, testImage(40, 20, false)), nil)
+	if err != nil {
+		t.Fatalf("ImageDataURI returned error: %v", err)
+	}
+
+	mimeType, img, _ := decodeDataURI(t, uri)
+	if mimeType != goaliniex.MimeTypePNG {
+		t.Errorf("expected %s, got %s", goaliniex.MimeTypePNG, mimeType)
+	}
+
+	if img.Bounds().Dx() != 40 || img.Bounds().Dy() != 20 {
+		t.Errorf("unexpected dimensions %v", img.Bounds())
+	}
+}
+
+func TestImageDataURI_StripsEXIFAndAppliesOrientation(t *testing.T) {
+	t.Parallel()
+
+	data := withEXIFOrientation(encodeTestJPEG(t, testImage(60, 20, false)), 6)
+
+	uri, err := goaliniex.ImageDataURI(data, nil)
+	if err != nil {
+		t.Fatalf("ImageDataURI returned error: %v", err)
+	}
+
+	_, img, raw := decodeDataURI(t, uri)
+	if bytes.Contains(raw, []byte("Exif")) {
+		t.Error("EXIF segment was not stripped")
+	}
+
+	if img.Bounds().Dx() != 20 || img.Bounds().Dy() != 60 {
+		t.Errorf("expected rotated 20x60 image, got %v", img.Bounds())
+	}
+}
+
+func TestImageDataURI_StripsEXIFWithoutReencoding(t *testing.T) {
+	t.Parallel()
+
+	original := encodeTestJPEG(t, testImage(30, 30, false))
+
+	uri, err := goaliniex.ImageDataURI(withEXIFOrientation(original, 1), nil)
+	if err != nil {
+		t.Fatalf("ImageDataURI returned error: %v", err)
+	}
+
+	_, _, raw := decodeDataURI(t, uri)
+	if !bytes.Equal(raw, original) {
+		t.Error("expected only the EXIF segment to be removed")
+	}
+}
+
+func TestImageDataURI_StripsPNGTextChunks(t *testing.T) {
+	t.Parallel()
+
+	data := encodeTestPNG(t, testImage(8, 8, false))
+
+	// Insert a tEXt chunk after IHDR (8-byte signature + 25-byte IHDR chunk).
+	text := []byte("Comment\x00secret location")
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(text))) //nolint:gosec // small chunk
+	chunk = append(chunk, "tEXt"...)
+	chunk = append(chunk, text...)
+	chunk = append(chunk, 0, 0, 0, 0)
+
+	withText := append(append(append([]byte{}, data[:33]...), chunk...), data[33:]...)
+
+	uri, err := goaliniex.ImageDataURI(withText, nil)
+	if err != nil {
+		t.Fatalf("ImageDataURI returned error: %v", err)
+	}
+
+	_, _, raw := decodeDataURI(t, uri)
+	if bytes.Contains(raw, []byte("secret location")) {
+		t.Error("tEXt chunk was not stripped")
+	}
+}
+
+func TestImageDataURI_DownscalesOversizedImages(t *testing.T) {
+	t.Parallel()
+
+	opts := &goaliniex.ImageOptions{ //nolint:exhaustruct // defaults for the rest
+		MaxWidth:  100,
+		MaxHeight: 100,
+	}
+
+	uri, err := goaliniex.ImageDataURI(encodeTestJPEG(t, testImage(400, 200, false)), opts)
+	if err != nil {
+		t.Fatalf("ImageDataURI returned error: %v", err)
+	}
+
+	_, img, _ := decodeDataURI(t, uri)
+	if img.Bounds().Dx() != 100 || img.Bounds().Dy() != 50 {
+		t.Errorf("expected 100x50, got %v", img.Bounds())
+	}
+}
+
+func TestImageDataURI_CompressesToMaxBytes(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		data func(t *testing.T) []byte
+	}{
+		{name: "jpeg", data: func(t *testing.T) []byte { t.Helper(); return encodeTestJPEG(t, testImage(300, 300, true)) }},
+		{name: "png", data: func(t *testing.T) []byte { t.Helper(); return encodeTestPNG(t, testImage(300, 300, true)) }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := &goaliniex.ImageOptions{MaxBytes: 40 << 10} //nolint:exhaustruct // defaults for the rest
+
+			uri, err := goaliniex.ImageDataURI(tc.data(t), opts)
+			if err != nil {
+				t.Fatalf("ImageDataURI returned error: %v", err)
+			}
+
+			_, _, raw := decodeDataURI(t, uri)
+			if len(raw) > opts.MaxBytes {
+				t.Errorf("expected at most %d bytes, got %d", opts.MaxBytes, len(raw))
+			}
+		})
+	}
+}
+
+// withJPEGDimensions returns a copy of data whose SOF0 header declares
+// width x height, leaving the scan data untouched.
+func withJPEGDimensions(t *testing.T, data []byte, width, height uint16) []byte {
+	t.Helper()
+
+	patched := bytes.Clone(data)
+
+	sof := bytes.Index(patched, []byte{0xFF, 0xC0})
+	if sof < 0 || sof+9 > len(patched) {
+		t.Fatal("no SOF0 marker in JPEG")
+	}
+
+	binary.BigEndian.PutUint16(patched[sof+5:], height)
+	binary.BigEndian.PutUint16(patched[sof+7:], width)
+
+	return patched
+}
+
+func TestImageDataURI_Errors(t *testing.T) {
+	t.Parallel()
+
+	jpegData := encodeTestJPEG(t, testImage(20, 20, false))
+
+	testCases := []struct {
+		name     string
+		data     []byte
+		opts     *goaliniex.ImageOptions
+		expected error
+	}{
+		{
+			name:     "unsupported type",
+			data:     []byte("GIF89a not really"),
+			opts:     nil,
+			expected: goaliniex.ErrUnsupportedImageType,
+		},
+		{
+			name:     "corrupt jpeg",
+			data:     jpegData[:20],
+			opts:     nil,
+			expected: goaliniex.ErrImageDecode,
+		},
+		{
+			name:     "too small",
+			data:     jpegData,
+			opts:     &goaliniex.ImageOptions{MinWidth: 100, MinHeight: 100}, //nolint:exhaustruct // defaults
+			expected: goaliniex.ErrImageTooSmall,
+		},
+		{
+			name:     "input too large",
+			data:     jpegData,
+			opts:     &goaliniex.ImageOptions{MaxInputBytes: 10}, //nolint:exhaustruct // defaults
+			expected: goaliniex.ErrImageTooLarge,
+		},
+		{
+			name:     "too many pixels",
+			data:     jpegData,
+			opts:     &goaliniex.ImageOptions{MaxPixels: 399}, //nolint:exhaustruct // defaults
+			expected: goaliniex.ErrImageTooLarge,
+		},
+		{
+			name:     "header declares huge dimensions",
+			data:     withJPEGDimensions(t, jpegData, 0xFFFF, 0xFFFF),
+			opts:     nil,
+			expected: goaliniex.ErrImageTooLarge,
+		},
+		{
+			name: "cannot fit without going below minimum",
+			data: encodeTestPNG(t, testImage(100, 100, true)),
+			//nolint:exhaustruct // defaults
+			opts:     &goaliniex.ImageOptions{MaxBytes: 100, MinWidth: 90, MinHeight: 90},
+			expected: goaliniex.ErrImageTooLarge,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := goaliniex.ImageDataURI(tc.data, tc.opts)
+			if !errors.Is(err, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestImageDataURIFromFileAndReader(t *testing.T) {
+	t.Parallel()
+
+	data := encodeTestPNG(t, testImage(10, 10, false))
+	path := filepath.Join(t.TempDir(), "front.png")
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	fromFile, err := goaliniex.ImageDataURIFromFile(path, nil)
+	if err != nil {
+		t.Fatalf("ImageDataURIFromFile returned error: %v", err)
+	}
+
+	fromReader, err := goaliniex.ImageDataURIFromReader(bytes.NewReader(data), nil)
+	if err != nil {
+		t.Fatalf("ImageDataURIFromReader returned error: %v", err)
+	}
+
+	if fromFile != fromReader {
+		t.Error("expected file and reader helpers to agree")
+	}
+
+	if !strings.HasPrefix(fromFile, "data:image/png;base64,") {
+		t.Errorf("unexpected data URI prefix: %.30s", fromFile)
+	}
+}
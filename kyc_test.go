@@ -0,0 +1,235 @@
+package goaliniex_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andyle182810/goaliniex"
+)
+
+const kycResponseBody = `{
+	"success": true,
+	"message": "Success",
+	"data": {
+		"firstName": "Jane",
+		"lastName": "Smith",
+		"dateOfBirth": "1985-05-15",
+		"gender": "F",
+		"nationality": "VN",
+		"idType": "id_card",
+		"nationalId": "987654321",
+		"issueDate": "2019-06-01T00:00:00Z",
+		"expiryDate": "01/06/2029",
+		"address": "456 Oak Ave",
+		"frontIdImage": "front",
+		"backIdImage": "back",
+		"holdIdImage": "hold",
+		"phoneNumber": "0987654321",
+		"phoneCountryCode": "84",
+		"kycStatus": "VERIFIED",
+		"rejectReason": ""
+	},
+	"errorCode": 0
+}`
+
+func newKycTestClient(t *testing.T) *goaliniex.Client {
+	t.Helper()
+
+	client, err := newTestClientWithMock(&recordingHTTPClient{
+		response: func() *http.Response {
+			return mockResponse(http.StatusOK, kycResponseBody) //nolint:bodyclose // Response body closed by client
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	return client
+}
+
+func TestClient_GetKyc_TypedFields(t *testing.T) {
+	t.Parallel()
+
+	resp, err := newKycTestClient(t).GetKyc(context.Background(), &goaliniex.KycInformationRequest{
+		UserEmail: "jane@example.com",
+	})
+	if err != nil {
+		t.Fatalf("GetKyc returned error: %v", err)
+	}
+
+	kyc := resp.Data
+
+	if kyc.Gender != goaliniex.GenderFemale {
+		t.Errorf("expected gender=%s, got %s", goaliniex.GenderFemale, kyc.Gender)
+	}
+
+	if kyc.IDType != goaliniex.IDTypeIDCard {
+		t.Errorf("expected idType=%s, got %s", goaliniex.IDTypeIDCard, kyc.IDType)
+	}
+
+	if !kyc.IsVerified() {
+		t.Errorf("expected verified status, got %s", kyc.KycStatus)
+	}
+
+	if !kyc.DateOfBirth.Time.Equal(time.Date(1985, time.May, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected dateOfBirth %v", kyc.DateOfBirth.Time)
+	}
+
+	if kyc.IssueDate.Time.Year() != 2019 {
+		t.Errorf("expected RFC 3339 issueDate to parse, got %v", kyc.IssueDate)
+	}
+
+	if !kyc.ExpiryDate.IsZero() || kyc.ExpiryDate.String() != "01/06/2029" {
+		t.Errorf("expected unparsed expiryDate to keep raw text, got %+v", kyc.ExpiryDate)
+	}
+}
+
+func TestClient_GetKyc_DeprecatedAdaptersAgree(t *testing.T) {
+	t.Parallel()
+
+	client := newKycTestClient(t)
+	ctx := context.Background()
+
+	info, err := client.GetKycInformation(ctx, &goaliniex.KycInformationRequest{UserEmail: "jane@example.com"})
+	if err != nil {
+		t.Fatalf("GetKycInformation returned error: %v", err)
+	}
+
+	user, err := client.GetUserKyc(ctx, &goaliniex.GetUserKycRequest{UserEmail: "jane@example.com"})
+	if err != nil {
+		t.Fatalf("GetUserKyc returned error: %v", err)
+	}
+
+	if info.Data.DateOfBirth != "1985-05-15" || user.Data.DateOfBirth != "1985-05-15" {
+		t.Errorf("expected raw dateOfBirth, got %q and %q", info.Data.DateOfBirth, user.Data.DateOfBirth)
+	}
+
+	if info.Data.ExpiryDate != "01/06/2029" || user.Data.ExpiryDate != "01/06/2029" {
+		t.Errorf("expected raw expiryDate, got %q and %q", info.Data.ExpiryDate, user.Data.ExpiryDate)
+	}
+
+	if string(info.Data.KycStatus) != user.Data.KycStatus {
+		t.Errorf("status mismatch: %q vs %q", info.Data.KycStatus, user.Data.KycStatus)
+	}
+
+	if info.Data.Gender != "F" || user.Data.IDType != "id_card" {
+		t.Errorf("expected raw gender and idType, got %q and %q", info.Data.Gender, user.Data.IDType)
+	}
+
+	if !info.Success || !user.Success || info.Message != user.Message {
+		t.Error("expected envelope fields to be carried over")
+	}
+}
+
+// TestClient_GetKyc_DeprecatedAdaptersKeepWireValues checks the deprecated
+// methods return gender and ID type as sent, while GetKyc normalizes them.
+func TestClient_GetKyc_DeprecatedAdaptersKeepWireValues(t *testing.T) {
+	t.Parallel()
+
+	body := strings.NewReplacer(`"gender": "F"`, `"gender": "M"`, `"idType": "id_card"`, `"idType": "ID_CARD"`).
+		Replace(kycResponseBody)
+
+	client, err := newTestClientWithMock(&recordingHTTPClient{
+		response: func() *http.Response {
+			return mockResponse(http.StatusOK, body) //nolint:bodyclose // Response body closed by client
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+
+	info, err := client.GetKycInformation(ctx, &goaliniex.KycInformationRequest{UserEmail: "jane@example.com"})
+	if err != nil {
+		t.Fatalf("GetKycInformation returned error: %v", err)
+	}
+
+	user, err := client.GetUserKyc(ctx, &goaliniex.GetUserKycRequest{UserEmail: "jane@example.com"})
+	if err != nil {
+		t.Fatalf("GetUserKyc returned error: %v", err)
+	}
+
+	for name, data := range map[string][2]string{
+		"GetKycInformation": {info.Data.Gender, info.Data.IDType},
+		"GetUserKyc":        {user.Data.Gender, user.Data.IDType},
+	} {
+		if data != [2]string{"M", "ID_CARD"} {
+			t.Errorf("%s: expected gender M and idType ID_CARD, got %q and %q", name, data[0], data[1])
+		}
+	}
+
+	kyc, err := client.GetKyc(ctx, &goaliniex.KycInformationRequest{UserEmail: "jane@example.com"})
+	if err != nil {
+		t.Fatalf("GetKyc returned error: %v", err)
+	}
+
+	if kyc.Data.Gender != goaliniex.GenderMale || kyc.Data.IDType != goaliniex.IDTypeIDCard {
+		t.Errorf("expected normalized gender and idType, got %q and %q", kyc.Data.Gender, kyc.Data.IDType)
+	}
+}
+
+func TestSubmitKycRequest_DecodeKeepsRawValues(t *testing.T) {
+	t.Parallel()
+
+	var req goaliniex.SubmitKycRequest
+	if err := json.Unmarshal([]byte(`{"gender":"M","type":"id_card"}`), &req); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if req.Gender != "M" || req.DocumentType != "id_card" {
+		t.Errorf("expected raw gender and type, got %q and %q", req.Gender, req.DocumentType)
+	}
+}
+
+func TestClient_GetUserKyc_NilRequest(t *testing.T) {
+	t.Parallel()
+
+	_, err := newKycTestClient(t).GetUserKyc(context.Background(), nil)
+	if err == nil {
+		t.Fatal("expected error for nil request")
+	}
+}
+
+func TestDate_JSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		input    string
+		expected string
+		parsed   bool
+	}{
+		{input: `"2024-02-29"`, expected: `"2024-02-29"`, parsed: true},
+		{input: `"not a date"`, expected: `"not a date"`, parsed: false},
+		{input: `""`, expected: `""`, parsed: false},
+		{input: `null`, expected: `""`, parsed: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			t.Parallel()
+
+			var date goaliniex.Date
+			if err := json.Unmarshal([]byte(tc.input), &date); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+
+			if date.IsZero() == tc.parsed {
+				t.Errorf("expected parsed=%v, got %+v", tc.parsed, date)
+			}
+
+			out, err := json.Marshal(date)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+
+			if string(out) != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, out)
+			}
+		})
+	}
+}
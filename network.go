@@ -0,0 +1,190 @@
+package goaliniex
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrUnknownNetwork       = errors.New("unknown network")
+	ErrUnsupportedNetwork   = errors.New("currency is not available on network")
+	ErrInvalidWalletAddress = errors.New("invalid wallet address")
+)
+
+// Network is the blockchain a token transfer settles on.
+type Network string
+
+const (
+	NetworkTRC20   Network = "TRC20"
+	NetworkERC20   Network = "ERC20"
+	NetworkBEP20   Network = "BEP20"
+	NetworkPolygon Network = "POLYGON"
+	NetworkBitcoin Network = "BTC"
+)
+
+// Networks returns every network the SDK knows.
+func Networks() []Network {
+	return []Network{NetworkTRC20, NetworkERC20, NetworkBEP20, NetworkPolygon, NetworkBitcoin}
+}
+
+// networkAliases maps common alternative names to their network.
+func networkAliases() map[string]Network {
+	return map[string]Network{
+		"TRON":     NetworkTRC20,
+		"TRX":      NetworkTRC20,
+		"ETH":      NetworkERC20,
+		"ETHEREUM": NetworkERC20,
+		"BSC":      NetworkBEP20,
+		"BNB":      NetworkBEP20,
+		"MATIC":    NetworkPolygon,
+		"BITCOIN":  NetworkBitcoin,
+	}
+}
+
+// ParseNetwork returns the network named s, ignoring case and accepting
+// common aliases such as "tron", "ethereum" and "bsc".
+func ParseNetwork(s string) (Network, error) {
+	name := strings.ToUpper(strings.TrimSpace(s))
+
+	if n := Network(name); slices.Contains(Networks(), n) {
+		return n, nil
+	}
+
+	if n, ok := networkAliases()[name]; ok {
+		return n, nil
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrUnknownNetwork, s)
+}
+
+// Currencies returns the currencies that can be transferred on n.
+func (n Network) Currencies() []Currency {
+	switch n {
+	case NetworkTRC20, NetworkBEP20, NetworkPolygon:
+		return []Currency{CurrencyUSDT}
+	case NetworkERC20:
+		return []Currency{CurrencyUSDT, CurrencyETH}
+	case NetworkBitcoin:
+		return []Currency{CurrencyBTC}
+	default:
+		return nil
+	}
+}
+
+// Supports reports whether currency can be transferred on n.
+func (n Network) Supports(currency Currency) bool {
+	return slices.Contains(n.Currencies(), currency)
+}
+
+// ValidateCurrency checks that currency can be transferred on n. Errors wrap
+// ErrUnknownNetwork or ErrUnsupportedNetwork.
+func (n Network) ValidateCurrency(currency Currency) error {
+	if !slices.Contains(Networks(), n) {
+		return fmt.Errorf("%w: %q", ErrUnknownNetwork, n)
+	}
+
+	if !n.Supports(currency) {
+		return fmt.Errorf("%w: %s on %s", ErrUnsupportedNetwork, currency, n)
+	}
+
+	return nil
+}
+
+// ValidateAddress checks that address is valid on n, including its
+// checksum: base58check for Tron and legacy Bitcoin addresses, EIP-55 for
+// mixed-case EVM addresses and bech32/bech32m for segwit addresses. Errors
+// wrap ErrInvalidWalletAddress or ErrUnknownNetwork.
+func (n Network) ValidateAddress(address string) error {
+	var valid bool
+
+	switch n {
+	case NetworkTRC20:
+		version, _, ok := decodeBase58Check(address)
+		valid = ok && version == tronAddressVersion
+	case NetworkERC20, NetworkBEP20, NetworkPolygon:
+		valid = isEVMAddress(address)
+	case NetworkBitcoin:
+		valid = isBitcoinAddress(address)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownNetwork, n)
+	}
+
+	if !valid {
+		return fmt.Errorf("%w: %q is not a %s address", ErrInvalidWalletAddress, address, n)
+	}
+
+	return nil
+}
+
+// explorerURL returns the block explorer base URL of n.
+func (n Network) explorerURL() string {
+	switch n {
+	case NetworkTRC20:
+		return "https://tronscan.org/#"
+	case NetworkERC20:
+		return "https://etherscan.io"
+	case NetworkBEP20:
+		return "https://bscscan.com"
+	case NetworkPolygon:
+		return "https://polygonscan.com"
+	case NetworkBitcoin:
+		return "https://mempool.space"
+	default:
+		return ""
+	}
+}
+
+// ExplorerTxURL returns the block explorer page of the transaction hash on
+// n, or "" for an unknown network or empty hash.
+func (n Network) ExplorerTxURL(hash string) string {
+	base := n.explorerURL()
+	if base == "" || hash == "" {
+		return ""
+	}
+
+	if n == NetworkTRC20 {
+		return base + "/transaction/" + url.PathEscape(hash)
+	}
+
+	return base + "/tx/" + url.PathEscape(hash)
+}
+
+// ExplorerAddressURL returns the block explorer page of address on n, or ""
+// for an unknown network or empty address.
+func (n Network) ExplorerAddressURL(address string) string {
+	base := n.explorerURL()
+	if base == "" || address == "" {
+		return ""
+	}
+
+	return base + "/address/" + url.PathEscape(address)
+}
+
+// ExplorerURL returns the block explorer page of the transfer's TxHash, or ""
+// before the transaction is known.
+func (t TokenTransfer) ExplorerURL() string {
+	return t.Network.ExplorerTxURL(t.TxHash)
+}
+
+// Validate checks that the transfer's currency is available on its network
+// and that WalletAddress is valid there.
+func (t TokenTransfer) Validate() error {
+	if err := t.Network.ValidateCurrency(t.Currency); err != nil {
+		return err
+	}
+
+	return t.Network.ValidateAddress(t.WalletAddress)
+}
+
+func isBitcoinAddress(address string) bool {
+	if strings.HasPrefix(strings.ToLower(address), bitcoinHRP+"1") {
+		return isSegwitAddress(address)
+	}
+
+	version, _, ok := decodeBase58Check(address)
+
+	return ok && (version == btcP2PKHVersion || version == btcP2SHVersion)
+}
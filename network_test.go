@@ -0,0 +1,216 @@
+package goaliniex_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/andyle182810/goaliniex"
+)
+
+const (
+	testTronAddress = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	testEVMAddress  = "0xdAC17F958D2ee523a2206206994597C13D831ec7"
+)
+
+func TestNetwork_ValidateAddress(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		network goaliniex.Network
+		address string
+		valid   bool
+	}{
+		{goaliniex.NetworkTRC20, testTronAddress, true},
+		{goaliniex.NetworkTRC20, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6", false},
+		{goaliniex.NetworkTRC20, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj60", false},
+		{goaliniex.NetworkTRC20, "AR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", false},
+		// Bad base58check checksum.
+		{goaliniex.NetworkTRC20, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6u", false},
+		// A valid Bitcoin address has the wrong version byte for Tron.
+		{goaliniex.NetworkTRC20, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", false},
+		{goaliniex.NetworkERC20, testEVMAddress, true},
+		{goaliniex.NetworkBEP20, "0x0000000000000000000000000000000000000000", true},
+		{goaliniex.NetworkERC20, "dAC17F958D2ee523a2206206994597C13D831ec7", false},
+		{goaliniex.NetworkERC20, "0xdAC17F958D2ee523a2206206994597C13D831ec", false},
+		{goaliniex.NetworkERC20, "0xgAC17F958D2ee523a2206206994597C13D831ec7", false},
+		// EIP-55 test vectors, then single-case forms that carry no checksum.
+		{goaliniex.NetworkERC20, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", true},
+		{goaliniex.NetworkERC20, "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", true},
+		{goaliniex.NetworkBEP20, "0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB", true},
+		{goaliniex.NetworkPolygon, "0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb", true},
+		{goaliniex.NetworkERC20, "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", true},
+		{goaliniex.NetworkERC20, "0x5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED", true},
+		{goaliniex.NetworkERC20, "0x5aaeb6053F3E94C9b9A09f33669435E7Ef1BeAed", false},
+		{goaliniex.NetworkBitcoin, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", true},
+		{goaliniex.NetworkBitcoin, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNb", false},
+		{goaliniex.NetworkBitcoin, "3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", true},
+		{goaliniex.NetworkBitcoin, testTronAddress, false},
+		{goaliniex.NetworkBitcoin, "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq", true},
+		{goaliniex.NetworkBitcoin, "BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", true},
+		{goaliniex.NetworkBitcoin, "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", true},
+		{goaliniex.NetworkBitcoin, "BC1SW50QGDZ25J", true},
+		{goaliniex.NetworkBitcoin, "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mDq", false},
+		{goaliniex.NetworkBitcoin, "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mbo", false},
+		// Bad bech32 checksum.
+		{goaliniex.NetworkBitcoin, "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdp", false},
+		// Witness version 0 with a bech32m checksum.
+		{goaliniex.NetworkBitcoin, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kemeawh", false},
+		// Testnet.
+		{goaliniex.NetworkBitcoin, "tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx", false},
+		{goaliniex.NetworkBitcoin, "0A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", false},
+		{goaliniex.NetworkBitcoin, "", false},
+		// Oversized base58 input is rejected before decoding.
+		{goaliniex.NetworkTRC20, "T" + strings.Repeat("z", 100_000), false},
+		{goaliniex.NetworkBitcoin, "1" + strings.Repeat("z", 100_000), false},
+	}
+
+	for _, tc := range testCases {
+		err := tc.network.ValidateAddress(tc.address)
+
+		switch {
+		case tc.valid && err != nil:
+			t.Errorf("%s %q: expected valid, got %v", tc.network, tc.address, err)
+		case !tc.valid && !errors.Is(err, goaliniex.ErrInvalidWalletAddress):
+			t.Errorf("%s %q: expected ErrInvalidWalletAddress, got %v", tc.network, tc.address, err)
+		}
+	}
+
+	if err := goaliniex.Network("SOLANA").ValidateAddress("x"); !errors.Is(err, goaliniex.ErrUnknownNetwork) {
+		t.Errorf("expected ErrUnknownNetwork, got %v", err)
+	}
+}
+
+func TestToChecksumAddress(t *testing.T) {
+	t.Parallel()
+
+	got, err := goaliniex.ToChecksumAddress("0xfb6916095ca1df60bb79ce92ce3ea74c37c5d359")
+	if err != nil || got != "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359" {
+		t.Errorf("ToChecksumAddress = %q, %v", got, err)
+	}
+
+	if _, err := goaliniex.ToChecksumAddress(testTronAddress); !errors.Is(err, goaliniex.ErrInvalidWalletAddress) {
+		t.Errorf("expected ErrInvalidWalletAddress, got %v", err)
+	}
+}
+
+func TestNetwork_Supports(t *testing.T) {
+	t.Parallel()
+
+	for _, network := range goaliniex.Networks() {
+		if len(network.Currencies()) == 0 {
+			t.Errorf("%s has no currencies", network)
+		}
+	}
+
+	if !goaliniex.NetworkERC20.Supports(goaliniex.CurrencyETH) || goaliniex.NetworkTRC20.Supports(goaliniex.CurrencyETH) {
+		t.Error("unexpected ETH support")
+	}
+}
+
+func TestNetwork_ValidateCurrency(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		network  goaliniex.Network
+		currency goaliniex.Currency
+		want     error
+	}{
+		{goaliniex.NetworkTRC20, goaliniex.CurrencyUSDT, nil},
+		{goaliniex.NetworkERC20, goaliniex.CurrencyETH, nil},
+		{goaliniex.NetworkBitcoin, goaliniex.CurrencyBTC, nil},
+		{goaliniex.NetworkBEP20, goaliniex.CurrencyBTC, goaliniex.ErrUnsupportedNetwork},
+		{goaliniex.Network("SOLANA"), goaliniex.CurrencyUSDT, goaliniex.ErrUnknownNetwork},
+	}
+
+	for _, tc := range testCases {
+		if err := tc.network.ValidateCurrency(tc.currency); !errors.Is(err, tc.want) {
+			t.Errorf("%s on %s: expected %v, got %v", tc.currency, tc.network, tc.want, err)
+		}
+	}
+}
+
+func TestParseNetwork(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]goaliniex.Network{
+		"TRC20":    goaliniex.NetworkTRC20,
+		"tron":     goaliniex.NetworkTRC20,
+		"Ethereum": goaliniex.NetworkERC20,
+		" bsc ":    goaliniex.NetworkBEP20,
+		"polygon":  goaliniex.NetworkPolygon,
+		"bitcoin":  goaliniex.NetworkBitcoin,
+		"BTC":      goaliniex.NetworkBitcoin,
+	}
+
+	for input, want := range testCases {
+		if got, err := goaliniex.ParseNetwork(input); err != nil || got != want {
+			t.Errorf("ParseNetwork(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+
+	if _, err := goaliniex.ParseNetwork("solana"); !errors.Is(err, goaliniex.ErrUnknownNetwork) {
+		t.Errorf("expected ErrUnknownNetwork, got %v", err)
+	}
+}
+
+func TestNetwork_ExplorerURLs(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		network goaliniex.Network
+		tx      string
+	}{
+		{goaliniex.NetworkTRC20, "https://tronscan.org/#/transaction/abc"},
+		{goaliniex.NetworkERC20, "https://etherscan.io/tx/abc"},
+		{goaliniex.NetworkBEP20, "https://bscscan.com/tx/abc"},
+		{goaliniex.NetworkPolygon, "https://polygonscan.com/tx/abc"},
+		{goaliniex.NetworkBitcoin, "https://mempool.space/tx/abc"},
+		{goaliniex.Network("SOLANA"), ""},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.network.ExplorerTxURL("abc"); got != tc.tx {
+			t.Errorf("%s: ExplorerTxURL = %q, want %q", tc.network, got, tc.tx)
+		}
+	}
+
+	if got := goaliniex.NetworkERC20.ExplorerAddressURL(testEVMAddress); got != "https://etherscan.io/address/"+testEVMAddress {
+		t.Errorf("ExplorerAddressURL = %q", got)
+	}
+
+	if got := goaliniex.NetworkERC20.ExplorerTxURL(""); got != "" {
+		t.Errorf("ExplorerTxURL(\"\") = %q, want empty", got)
+	}
+}
+
+func TestTokenTransfer(t *testing.T) {
+	t.Parallel()
+
+	transfer := goaliniex.TokenTransfer{
+		Currency:      goaliniex.CurrencyUSDT,
+		Network:       goaliniex.NetworkTRC20,
+		Price:         26000,
+		Amount:        20,
+		WalletAddress: testTronAddress,
+		TxHash:        "abc",
+	}
+
+	if err := transfer.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+
+	if got := transfer.ExplorerURL(); got != "https://tronscan.org/#/transaction/abc" {
+		t.Errorf("ExplorerURL = %q", got)
+	}
+
+	transfer.Currency = goaliniex.CurrencyETH
+	if err := transfer.Validate(); !errors.Is(err, goaliniex.ErrUnsupportedNetwork) {
+		t.Errorf("expected ErrUnsupportedNetwork, got %v", err)
+	}
+
+	transfer.Currency, transfer.WalletAddress = goaliniex.CurrencyUSDT, testEVMAddress
+	if err := transfer.Validate(); !errors.Is(err, goaliniex.ErrInvalidWalletAddress) {
+		t.Errorf("expected ErrInvalidWalletAddress, got %v", err)
+	}
+}
@@ -0,0 +1,36 @@
+package goaliniex
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidOrderTime = errors.New("invalid order time")
+
+// orderTimeLayouts are the timestamp formats seen in order responses. Times
+// without a zone are UTC.
+func orderTimeLayouts() []string {
+	return []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05"}
+}
+
+// ParseOrderTime parses a CreatedAt or ExpiresAt value.
+func ParseOrderTime(value string) (time.Time, error) {
+	for _, layout := range orderTimeLayouts() {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidOrderTime, value)
+}
+
+// ExpiresAtTime parses ExpiresAt.
+func (o *OrderDetails) ExpiresAtTime() (time.Time, error) {
+	return ParseOrderTime(o.ExpiresAt)
+}
+
+// ExpiresAtTime parses ExpiresAt.
+func (o *CreateOrderResponse) ExpiresAtTime() (time.Time, error) {
+	return ParseOrderTime(o.ExpiresAt)
+}
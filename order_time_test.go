@@ -0,0 +1,33 @@
+package goaliniex_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andyle182810/goaliniex"
+)
+
+func TestParseOrderTime(t *testing.T) {
+	t.Parallel()
+
+	want := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for _, value := range []string{
+		"2025-01-02T03:04:05Z",
+		"2025-01-02T10:04:05+07:00",
+		"2025-01-02T03:04:05",
+		"2025-01-02 03:04:05",
+	} {
+		got, err := goaliniex.ParseOrderTime(value)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("ParseOrderTime(%q) = %v, %v", value, got, err)
+		}
+	}
+
+	for _, value := range []string{"", "02/01/2025"} {
+		if _, err := goaliniex.ParseOrderTime(value); !errors.Is(err, goaliniex.ErrInvalidOrderTime) {
+			t.Errorf("ParseOrderTime(%q): expected ErrInvalidOrderTime, got %v", value, err)
+		}
+	}
+}
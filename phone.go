@@ -0,0 +1,243 @@
+package goaliniex
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	maxE164Digits     = 15
+	maxDialCodeLength = 3
+)
+
+// Phone is a phone number split into its international dialing code and
+// national significant number, the number without any trunk prefix.
+type Phone struct {
+	// Country is the country the number belongs to. It is empty when the
+	// dialing code is shared and nothing points to a specific country.
+	Country  CountryCode
+	DialCode string
+	National string
+}
+
+// E164 formats the number as +<dial code><national number>.
+func (p Phone) E164() string {
+	return "+" + p.DialCode + p.National
+}
+
+func (p Phone) String() string {
+	return p.E164()
+}
+
+// ParsePhone parses an international number (leading "+" or "00") or a
+// national number of country, given as an alpha-2 or alpha-3 code. Spaces,
+// dashes, dots, slashes and parentheses are ignored, trunk prefixes such as
+// the leading 0 in VN, PH and TH are stripped and the length is checked
+// against the country's numbering plan. Errors wrap ErrInvalidPhone.
+func ParsePhone(number, country string) (Phone, error) {
+	var region Country
+
+	if country != "" {
+		found, ok := LookupCountry(country)
+		if !ok {
+			return Phone{}, fmt.Errorf("%w: unknown country %q", ErrInvalidPhone, country)
+		}
+
+		region = found
+	}
+
+	return parsePhone(number, "", region)
+}
+
+// ParsePhoneWithDialCode parses number as ParsePhone does, taking national
+// numbers to use dialCode, with or without "+".
+func ParsePhoneWithDialCode(number, dialCode string) (Phone, error) {
+	return parsePhone(number, strings.TrimPrefix(strings.TrimSpace(dialCode), "+"), Country{}) //nolint:exhaustruct // no region
+}
+
+// parsePhone parses number. dialCode, when set, is the code national numbers
+// use and international ones must match; region breaks ties between
+// countries sharing a code.
+func parsePhone(number, dialCode string, region Country) (Phone, error) {
+	digits, international, err := cleanPhoneNumber(number)
+	if err != nil {
+		return Phone{}, err
+	}
+
+	// Dialing codes such as +1684 carry the start of the national number.
+	var areaPrefix string
+
+	if dialCode != "" {
+		registered := matchDialCode(dialCode)
+		if registered == "" {
+			return Phone{}, fmt.Errorf("%w: unknown dialing code +%s", ErrInvalidPhone, dialCode)
+		}
+
+		dialCode, areaPrefix = registered, dialCode[len(registered):]
+	}
+
+	var national string
+
+	switch {
+	case international:
+		code := matchDialCode(digits)
+		if code == "" {
+			return Phone{}, fmt.Errorf("%w: %q has no known dialing code", ErrInvalidPhone, number)
+		}
+
+		if dialCode != "" && code != dialCode {
+			return Phone{}, fmt.Errorf("%w: %q does not match dialing code +%s",
+				ErrInvalidPhone, number, dialCode)
+		}
+
+		dialCode, national = code, digits[len(code):]
+	case dialCode != "":
+		national = areaPrefix + digits
+	default:
+		dialCode, national = region.DialCode(), digits
+		if dialCode == "" {
+			return Phone{}, fmt.Errorf("%w: national number %q needs a country", ErrInvalidPhone, number)
+		}
+	}
+
+	country := phoneCountry(dialCode, region)
+	national = stripTrunkPrefix(country, dialCode, national)
+
+	minDigits, maxDigits := phoneLengthRange(country, dialCode)
+	if len(national) < minDigits || len(national) > maxDigits || len(dialCode)+len(national) > maxE164Digits {
+		return Phone{}, fmt.Errorf("%w: %q has %d digits, want %d to %d for +%s",
+			ErrInvalidPhone, number, len(national), minDigits, maxDigits, dialCode)
+	}
+
+	return Phone{Country: country, DialCode: dialCode, National: national}, nil
+}
+
+// cleanPhoneNumber drops formatting characters and the international prefix.
+func cleanPhoneNumber(number string) (string, bool, error) {
+	cleaned := strings.NewReplacer(" ", "", "-", "", ".", "", "/", "", "(", "", ")", "", "\u00a0", "").
+		Replace(strings.TrimSpace(number))
+
+	international := false
+
+	if rest, found := strings.CutPrefix(cleaned, "+"); found {
+		cleaned, international = rest, true
+	} else if rest, found := strings.CutPrefix(cleaned, "00"); found {
+		cleaned, international = rest, true
+	}
+
+	if !isDigits(cleaned) {
+		return "", false, fmt.Errorf("%w: %q is not a phone number", ErrInvalidPhone, number)
+	}
+
+	return cleaned, international, nil
+}
+
+// matchDialCode returns the registered dialing code digits start with.
+// Dialing codes form a prefix code, so at most one matches.
+func matchDialCode(digits string) string {
+	registry := countryRegistry()
+
+	for length := min(maxDialCodeLength, len(digits)); length > 0; length-- {
+		if _, ok := registry.byDial[digits[:length]]; ok {
+			return digits[:length]
+		}
+	}
+
+	return ""
+}
+
+// phoneCountry picks the country for dialCode: region if it uses the code,
+// otherwise the sole or principal country using it.
+func phoneCountry(dialCode string, region Country) CountryCode {
+	if region.Alpha2 != "" && slices.Contains(region.DialCodes, dialCode) {
+		return region.Alpha2
+	}
+
+	if country, ok := principalDialCodeCountries()[dialCode]; ok {
+		return country
+	}
+
+	if countries := CountriesByDialCode(dialCode); len(countries) == 1 {
+		return countries[0].Alpha2
+	}
+
+	return ""
+}
+
+func principalDialCodeCountries() map[string]CountryCode {
+	return map[string]CountryCode{
+		"1": "US", "7": "RU", "39": "IT", "44": "GB", "47": "NO", "61": "AU", "64": "NZ",
+		"212": "MA", "262": "RE", "358": "FI", "500": "FK", "590": "GP", "599": "CW", "672": "NF",
+	}
+}
+
+// stripTrunkPrefix removes the national trunk prefix. A leading 0 never
+// starts a national significant number where 0 is the trunk prefix; other
+// prefixes are only stripped from numbers that are too long with them.
+func stripTrunkPrefix(country CountryCode, dialCode, national string) string {
+	var trunk string
+
+	switch {
+	case dialCode == "1":
+		trunk = "1"
+	case dialCode == "7":
+		trunk = "8"
+	case slices.Contains([]CountryCode{"IT", "SM", "VA", "CI"}, country):
+		// The leading 0 is part of the number.
+		return national
+	default:
+		trunk = "0"
+	}
+
+	rest, found := strings.CutPrefix(national, trunk)
+	if !found {
+		return national
+	}
+
+	if _, maxDigits := phoneLengthRange(country, dialCode); trunk == "0" || len(national) > maxDigits {
+		return rest
+	}
+
+	return national
+}
+
+// phoneLengthRange returns the allowed national number lengths. Countries
+// without a specific rule accept 4 to 14 digits.
+//
+//nolint:mnd // numbering plan lengths
+func phoneLengthRange(country CountryCode, dialCode string) (int, int) {
+	if dialCode == "1" || dialCode == "7" {
+		return 10, 10
+	}
+
+	switch country {
+	case "VN":
+		return 9, 10
+	case "PH", "NG", "MY":
+		return 8, 10
+	case "TH", "PE":
+		return 8, 9
+	case "GE", "AU", "FR":
+		return 9, 9
+	case "BR", "AR", "CN":
+		return 10, 11
+	case "GB", "JP":
+		return 9, 10
+	case "IN", "CI":
+		return 10, 10
+	case "SG":
+		return 8, 8
+	case "ID":
+		return 9, 12
+	default:
+		return 4, 14
+	}
+}
+
+// SetPhone fills PhoneNumber with the national number and PhoneCountryCode
+// with the "+"-prefixed dialing code.
+func (r *SubmitKycRequest) SetPhone(phone Phone) {
+	r.PhoneNumber = phone.National
+	r.PhoneCountryCode = "+" + phone.DialCode
+}
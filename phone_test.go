@@ -0,0 +1,121 @@
+package goaliniex_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/andyle182810/goaliniex"
+)
+
+func TestParsePhone(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		number   string
+		country  string
+		expected string
+		region   goaliniex.CountryCode
+	}{
+		{name: "vn national", number: "0987 654 321", country: "VN", expected: "+84987654321", region: "VN"},
+		{name: "vn alpha-3", number: "098-765-4321", country: "vnm", expected: "+84987654321", region: "VN"},
+		{name: "vn international", number: "+84 987 654 321", country: "", expected: "+84987654321", region: "VN"},
+		{name: "vn redundant trunk", number: "+84 (0) 987 654 321", country: "", expected: "+84987654321", region: "VN"},
+		{name: "vn idd prefix", number: "0084987654321", country: "", expected: "+84987654321", region: "VN"},
+		{name: "ph mobile", number: "(0917) 123-4567", country: "PH", expected: "+639171234567", region: "PH"},
+		{name: "th mobile", number: "081 234 5678", country: "TH", expected: "+66812345678", region: "TH"},
+		{name: "th landline", number: "02 123 4567", country: "TH", expected: "+6621234567", region: "TH"},
+		{name: "us national", number: "(212) 555-0123", country: "US", expected: "+12125550123", region: "US"},
+		{name: "us trunk", number: "1 212 555 0123", country: "US", expected: "+12125550123", region: "US"},
+		{name: "canada keeps region", number: "416 555 0123", country: "CA", expected: "+14165550123", region: "CA"},
+		{name: "nanp without region", number: "+1 416 555 0123", country: "", expected: "+14165550123", region: "US"},
+		{name: "italy keeps zero", number: "06 1234 5678", country: "IT", expected: "+390612345678", region: "IT"},
+		{name: "international overrides country", number: "+44 7911 123456", country: "VN", expected: "+447911123456", region: "GB"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			phone, err := goaliniex.ParsePhone(tc.number, tc.country)
+			if err != nil {
+				t.Fatalf("ParsePhone returned error: %v", err)
+			}
+
+			if phone.E164() != tc.expected || phone.String() != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, phone.E164())
+			}
+
+			if phone.Country != tc.region {
+				t.Errorf("expected country %s, got %s", tc.region, phone.Country)
+			}
+		})
+	}
+}
+
+func TestParsePhone_Invalid(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		number  string
+		country string
+	}{
+		{name: "letters", number: "09876ABCDE", country: "VN"},
+		{name: "vn too short", number: "0987 654", country: "VN"},
+		{name: "vn too long", number: "0987 654 321 12", country: "VN"},
+		{name: "us too short", number: "555 0123", country: "US"},
+		{name: "national without country", number: "0987654321", country: ""},
+		{name: "unknown country", number: "0987654321", country: "XYZ"},
+		{name: "unknown dialing code", number: "+999 1234 5678", country: ""},
+		{name: "empty", number: "", country: "VN"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if phone, err := goaliniex.ParsePhone(tc.number, tc.country); !errors.Is(err, goaliniex.ErrInvalidPhone) {
+				t.Errorf("expected ErrInvalidPhone, got %v (%s)", err, phone)
+			}
+		})
+	}
+}
+
+func TestParsePhoneWithDialCode(t *testing.T) {
+	t.Parallel()
+
+	phone, err := goaliniex.ParsePhoneWithDialCode("0917 123 4567", "+63")
+	if err != nil || phone.E164() != "+639171234567" {
+		t.Errorf("unexpected result %s, %v", phone, err)
+	}
+
+	phone, err = goaliniex.ParsePhoneWithDialCode("633 1234", "+1684")
+	if err != nil || phone.E164() != "+16846331234" {
+		t.Errorf("expected area code from dialing code, got %s, %v", phone, err)
+	}
+
+	if _, err := goaliniex.ParsePhoneWithDialCode("+44 7911 123456", "84"); !errors.Is(err, goaliniex.ErrInvalidPhone) {
+		t.Errorf("expected ErrInvalidPhone for mismatched dialing code, got %v", err)
+	}
+}
+
+func TestSubmitKycRequest_SetPhone(t *testing.T) {
+	t.Parallel()
+
+	phone, err := goaliniex.ParsePhone("0987 654 321", "VN")
+	if err != nil {
+		t.Fatalf("ParsePhone returned error: %v", err)
+	}
+
+	req := validKycRequest()
+	req.SetPhone(phone)
+
+	if req.PhoneNumber != "987654321" || req.PhoneCountryCode != "+84" {
+		t.Errorf("unexpected phone fields %q %q", req.PhoneNumber, req.PhoneCountryCode)
+	}
+
+	if err := req.ValidateAt(validationNow); err != nil {
+		t.Errorf("expected request to validate, got %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+package goaliniex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+var ErrQRTypeMismatch = errors.New("qr code type mismatch")
+
+// EMVAdditionalData holds the EMVCo fields shared by every QR scheme. Keys
+// of AdditionalData without a typed field are kept in Extra.
+type EMVAdditionalData struct {
+	PointOfInitiation    string         `json:"pointOfInitiation,omitempty"`
+	Currency             FiatCurrency   `json:"currency,omitempty"`
+	MerchantCategoryCode string         `json:"merchantCategoryCode,omitempty"`
+	MerchantName         string         `json:"merchantName,omitempty"`
+	MerchantCity         string         `json:"merchantCity,omitempty"`
+	PostalCode           string         `json:"postalCode,omitempty"`
+	BillNumber           string         `json:"billNumber,omitempty"`
+	MobileNumber         string         `json:"mobileNumber,omitempty"`
+	StoreLabel           string         `json:"storeLabel,omitempty"`
+	LoyaltyNumber        string         `json:"loyaltyNumber,omitempty"`
+	ReferenceLabel       string         `json:"referenceLabel,omitempty"`
+	CustomerLabel        string         `json:"customerLabel,omitempty"`
+	TerminalLabel        string         `json:"terminalLabel,omitempty"`
+	Purpose              string         `json:"purpose,omitempty"`
+	Extra                map[string]any `json:"-"`
+}
+
+type VietQRAdditionalData struct {
+	EMVAdditionalData
+
+	BankAccountNumber string `json:"bankAccountNumber,omitempty"`
+	BankCode          string `json:"bankCode,omitempty"`
+	BIN               string `json:"bin,omitempty"`
+	ServiceCode       string `json:"serviceCode,omitempty"`
+}
+
+type PIXAdditionalData struct {
+	EMVAdditionalData
+
+	PixKey      string `json:"pixKey,omitempty"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+// QRPhAdditionalData covers both QR Ph schemes, ph.ppmi.p2m and
+// com.p2pqrpay.
+type QRPhAdditionalData struct {
+	EMVAdditionalData
+
+	AcquirerID        string `json:"acquirerId,omitempty"`
+	PaymentType       string `json:"paymentType,omitempty"`
+	BankAccountNumber string `json:"bankAccountNumber,omitempty"`
+}
+
+type PromptPayAdditionalData struct {
+	EMVAdditionalData
+
+	ProxyType  string `json:"proxyType,omitempty"`
+	ProxyID    string `json:"proxyId,omitempty"`
+	Reference1 string `json:"reference1,omitempty"`
+	Reference2 string `json:"reference2,omitempty"`
+}
+
+// QR3AdditionalData guesses the qr3 fields from the other bank transfer
+// schemes; Aliniex has not confirmed them. Anything else lands in Extra.
+type QR3AdditionalData struct {
+	EMVAdditionalData
+
+	BankAccountNumber string `json:"bankAccountNumber,omitempty"`
+	BankCode          string `json:"bankCode,omitempty"`
+}
+
+// PayWithCryptoAdditionalData guesses the paywithcrypto fields from the
+// other bank transfer schemes; Aliniex has not confirmed them. Anything else
+// lands in Extra.
+type PayWithCryptoAdditionalData struct {
+	EMVAdditionalData
+
+	BankAccountNumber string `json:"bankAccountNumber,omitempty"`
+	BankCode          string `json:"bankCode,omitempty"`
+}
+
+func (q *QRCodeInfo) VietQRData() (*VietQRAdditionalData, error) {
+	data := new(VietQRAdditionalData)
+
+	if err := q.decodeAdditionalData(data, &data.Extra, QRTypeVietQR); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (q *QRCodeInfo) PIXData() (*PIXAdditionalData, error) {
+	data := new(PIXAdditionalData)
+
+	if err := q.decodeAdditionalData(data, &data.Extra, QRTypePIX); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (q *QRCodeInfo) QRPhData() (*QRPhAdditionalData, error) {
+	data := new(QRPhAdditionalData)
+
+	if err := q.decodeAdditionalData(data, &data.Extra, QRTypePHPPMIP2M, QRTypeComP2PQRPay); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (q *QRCodeInfo) PromptPayData() (*PromptPayAdditionalData, error) {
+	data := new(PromptPayAdditionalData)
+
+	if err := q.decodeAdditionalData(data, &data.Extra, QRTypePromptPay); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (q *QRCodeInfo) QR3Data() (*QR3AdditionalData, error) {
+	data := new(QR3AdditionalData)
+
+	if err := q.decodeAdditionalData(data, &data.Extra, QRTypeQR3); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (q *QRCodeInfo) PayWithCryptoData() (*PayWithCryptoAdditionalData, error) {
+	data := new(PayWithCryptoAdditionalData)
+
+	if err := q.decodeAdditionalData(data, &data.Extra, QRTypePayWithCrypto); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Map converts the data back into the AdditionalData representation.
+func (d *VietQRAdditionalData) Map() map[string]any { return encodeAdditionalData(d, d.Extra) }
+
+func (d *PIXAdditionalData) Map() map[string]any { return encodeAdditionalData(d, d.Extra) }
+
+func (d *QRPhAdditionalData) Map() map[string]any { return encodeAdditionalData(d, d.Extra) }
+
+func (d *PromptPayAdditionalData) Map() map[string]any { return encodeAdditionalData(d, d.Extra) }
+
+func (d *QR3AdditionalData) Map() map[string]any { return encodeAdditionalData(d, d.Extra) }
+
+func (d *PayWithCryptoAdditionalData) Map() map[string]any { return encodeAdditionalData(d, d.Extra) }
+
+// decodeAdditionalData fills dest from AdditionalData one key at a time.
+// Missing keys leave fields empty; keys dest has no field for, or whose value
+// has the wrong type, are copied into extra.
+func (q *QRCodeInfo) decodeAdditionalData(dest any, extra *map[string]any, types ...QRType) error {
+	if !slices.Contains(types, q.QRType) {
+		return fmt.Errorf("%w: %q is not %v", ErrQRTypeMismatch, q.QRType, types)
+	}
+
+	known := schemaFields(reflect.TypeOf(dest).Elem())
+
+	for key, value := range q.AdditionalData {
+		if _, ok := known[key]; ok && decodeAdditionalField(dest, key, value) {
+			continue
+		}
+
+		if *extra == nil {
+			*extra = map[string]any{}
+		}
+
+		(*extra)[key] = value
+	}
+
+	return nil
+}
+
+// decodeAdditionalField sets the field of dest tagged key, reporting false
+// when value does not fit its type.
+func decodeAdditionalField(dest any, key string, value any) bool {
+	raw, err := json.Marshal(map[string]any{key: value})
+	if err != nil {
+		return false
+	}
+
+	return json.Unmarshal(raw, dest) == nil
+}
+
+func encodeAdditionalData(src any, extra map[string]any) map[string]any {
+	result := maps.Clone(extra)
+	if result == nil {
+		result = map[string]any{}
+	}
+
+	raw, _ := json.Marshal(src) //nolint:errchkjson // string fields only
+	_ = json.Unmarshal(raw, &result)
+
+	return result
+}
@@ -0,0 +1,212 @@
+package goaliniex_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/andyle182810/goaliniex"
+)
+
+// syntheticVietQRInfo is a synthetic get-qr-code-info response body: the
+// baseline VietQR sample plus an invented napasRoute key the typed struct
+// does not know.
+const syntheticVietQRInfo = `{
+	"bankAccountNumber": "888812345678",
+	"bankCode": "Techcombank",
+	"bankName": "Ngân hàng TMCP Kỹ thương Việt Nam",
+	"countryCode": "VN",
+	"qrType": "vietqr",
+	"additionalData": {
+		"bankAccountNumber": "888812345678",
+		"bankCode": "Techcombank",
+		"napasRoute": "QRIBFTTA"
+	}
+}`
+
+func TestQRCodeInfo_TypedAdditionalData_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	synthetic := new(goaliniex.QRCodeInfo)
+	if err := json.Unmarshal([]byte(syntheticVietQRInfo), synthetic); err != nil {
+		t.Fatalf("unmarshal synthetic sample: %v", err)
+	}
+
+	parse := func(content string) *goaliniex.QRCodeInfo {
+		info, err := goaliniex.ParseQRCode(content)
+		if err != nil {
+			t.Fatalf("ParseQRCode returned error: %v", err)
+		}
+
+		return info
+	}
+
+	testCases := []struct {
+		name string
+		info *goaliniex.QRCodeInfo
+		typ  func(info *goaliniex.QRCodeInfo) (map[string]any, error)
+	}{
+		{name: "synthetic vietqr", info: synthetic, typ: vietQRMap},
+		{name: "vietqr", info: parse(testVietQRPayload), typ: vietQRMap},
+		{name: "pix", info: parse(testPIXPayload), typ: func(info *goaliniex.QRCodeInfo) (map[string]any, error) {
+			data, err := info.PIXData()
+			if err != nil {
+				return nil, err
+			}
+
+			return data.Map(), nil
+		}},
+		{name: "qr ph p2p", info: parse(testQRPhP2PPayload), typ: qrPhMap},
+		{name: "qr ph p2m", info: parse(testQRPhP2MPayload), typ: qrPhMap},
+		{name: "promptpay", info: parse(testPromptPayPayload), typ: func(info *goaliniex.QRCodeInfo) (map[string]any, error) {
+			data, err := info.PromptPayData()
+			if err != nil {
+				return nil, err
+			}
+
+			return data.Map(), nil
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.typ(tc.info)
+			if err != nil {
+				t.Fatalf("typed accessor returned error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tc.info.AdditionalData) {
+				t.Errorf("round trip mismatch:\nexpected %v\ngot      %v", tc.info.AdditionalData, got)
+			}
+		})
+	}
+}
+
+func vietQRMap(info *goaliniex.QRCodeInfo) (map[string]any, error) {
+	data, err := info.VietQRData()
+	if err != nil {
+		return nil, err
+	}
+
+	return data.Map(), nil
+}
+
+func qrPhMap(info *goaliniex.QRCodeInfo) (map[string]any, error) {
+	data, err := info.QRPhData()
+	if err != nil {
+		return nil, err
+	}
+
+	return data.Map(), nil
+}
+
+func TestQRCodeInfo_TypedAdditionalData_Fields(t *testing.T) {
+	t.Parallel()
+
+	vietQR, err := goaliniex.ParseQRCode(testVietQRPayload)
+	if err != nil {
+		t.Fatalf("ParseQRCode returned error: %v", err)
+	}
+
+	data, err := vietQR.VietQRData()
+	if err != nil {
+		t.Fatalf("VietQRData returned error: %v", err)
+	}
+
+	if data.BIN != "970407" || data.BankCode != "Techcombank" || data.Purpose != "Thanh toan don hang" {
+		t.Errorf("unexpected vietqr data %+v", data)
+	}
+
+	if data.Currency != goaliniex.FiatCurrencyVND || data.PointOfInitiation != "dynamic" || data.Extra != nil {
+		t.Errorf("unexpected shared fields %+v", data.EMVAdditionalData)
+	}
+
+	pix, err := goaliniex.ParseQRCode(testPIXPayload)
+	if err != nil {
+		t.Fatalf("ParseQRCode returned error: %v", err)
+	}
+
+	pixData, err := pix.PIXData()
+	if err != nil {
+		t.Fatalf("PIXData returned error: %v", err)
+	}
+
+	if pixData.PixKey != "123e4567-e12b-12d1-a456-426655440000" || pixData.MerchantName != "Fulano de Tal" ||
+		pixData.ReferenceLabel != "***" {
+		t.Errorf("unexpected pix data %+v", pixData)
+	}
+}
+
+func TestQRCodeInfo_TypedAdditionalData_MissingKeys(t *testing.T) {
+	t.Parallel()
+
+	for _, additional := range []map[string]any{nil, {}} {
+		info := &goaliniex.QRCodeInfo{
+			BankAccountNumber: "",
+			BankCode:          "",
+			BankName:          "",
+			CountryCode:       goaliniex.CountryCodeGE,
+			QRType:            goaliniex.QRTypeQR3,
+			AdditionalData:    additional,
+			Amount:            0,
+		}
+
+		data, err := info.QR3Data()
+		if err != nil {
+			t.Fatalf("QR3Data returned error: %v", err)
+		}
+
+		if data.BankAccountNumber != "" || data.MerchantName != "" || len(data.Map()) != 0 {
+			t.Errorf("expected empty data, got %+v", data)
+		}
+	}
+}
+
+func TestQRCodeInfo_TypedAdditionalData_Errors(t *testing.T) {
+	t.Parallel()
+
+	info := &goaliniex.QRCodeInfo{
+		BankAccountNumber: "",
+		BankCode:          "",
+		BankName:          "",
+		CountryCode:       goaliniex.CountryCodeAR,
+		QRType:            goaliniex.QRTypePayWithCrypto,
+		AdditionalData:    map[string]any{"bankCode": 123.0},
+		Amount:            0,
+	}
+
+	if _, err := info.VietQRData(); !errors.Is(err, goaliniex.ErrQRTypeMismatch) {
+		t.Errorf("expected ErrQRTypeMismatch, got %v", err)
+	}
+
+}
+
+func TestQRCodeInfo_TypedAdditionalData_MistypedKey(t *testing.T) {
+	t.Parallel()
+
+	info := &goaliniex.QRCodeInfo{
+		BankAccountNumber: "",
+		BankCode:          "",
+		BankName:          "",
+		CountryCode:       goaliniex.CountryCodeVN,
+		QRType:            goaliniex.QRTypeVietQR,
+		AdditionalData:    map[string]any{"billNumber": 42.0, "merchantName": "PHO 24", "bankCode": "970436"},
+		Amount:            0,
+	}
+
+	data, err := info.VietQRData()
+	if err != nil {
+		t.Fatalf("VietQRData returned error: %v", err)
+	}
+
+	if data.MerchantName != "PHO 24" || data.BankCode != "970436" {
+		t.Errorf("expected well-typed keys to decode, got %+v", data)
+	}
+
+	if data.BillNumber != "" || !reflect.DeepEqual(data.Extra, map[string]any{"billNumber": 42.0}) {
+		t.Errorf("expected the mistyped billNumber in Extra, got %q and %v", data.BillNumber, data.Extra)
+	}
+}
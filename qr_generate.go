@@ -0,0 +1,304 @@
+package goaliniex
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	emvMaxValueLength = 99
+
+	vietQRServiceAccount = "QRIBFTTA"
+	pixDefaultCategory   = "0000"
+	pixDefaultReference  = "***"
+)
+
+var ErrInvalidQRPayload = errors.New("invalid qr payload")
+
+// QRPayload describes a merchant-presented QR code to generate. Zero values
+// are omitted or defaulted for the QRType.
+type QRPayload struct {
+	QRType QRType
+	// BankCode is the NAPAS BIN or bank code for VietQR and the BIC for
+	// QR Ph. It is not used for PIX and PromptPay.
+	BankCode string
+	// BankAccountNumber is the account (VietQR, QR Ph), the PIX key or the
+	// PromptPay proxy (mobile number or national ID).
+	BankAccountNumber string
+	// Amount makes the code dynamic when positive.
+	Amount       float64
+	CountryCode  CountryCode
+	Currency     FiatCurrency
+	MerchantName string
+	MerchantCity string
+	// MerchantCategoryCode defaults to 0000 for PIX.
+	MerchantCategoryCode string
+	// Purpose is the transfer content (VietQR) or PIX description.
+	Purpose string
+	// ReferenceLabel is the transaction reference; PIX uses it as the txid.
+	ReferenceLabel string
+	// URL makes a PIX code dynamic, pointing to the PSP payload location.
+	URL string
+}
+
+// QRPayloadFromInfo converts parsed QR information, typically from
+// ParseQRCode or GetQRCodeInfo, back into a payload description.
+func QRPayloadFromInfo(info *QRCodeInfo) *QRPayload {
+	text := func(key string) string {
+		value, _ := info.AdditionalData[key].(string)
+
+		return value
+	}
+
+	bankCode := info.BankCode
+	if bin := text("bin"); bin != "" {
+		bankCode = bin
+	}
+
+	return &QRPayload{
+		QRType:               info.QRType,
+		BankCode:             bankCode,
+		BankAccountNumber:    info.BankAccountNumber,
+		Amount:               info.Amount,
+		CountryCode:          info.CountryCode,
+		Currency:             FiatCurrency(text("currency")),
+		MerchantName:         text("merchantName"),
+		MerchantCity:         text("merchantCity"),
+		MerchantCategoryCode: text("merchantCategoryCode"),
+		Purpose:              firstNonEmpty(text("purpose"), text("description")),
+		ReferenceLabel:       text("referenceLabel"),
+		URL:                  text("url"),
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// fiatCurrencyNumeric is the inverse of fiatCurrencyFromNumeric.
+func fiatCurrencyNumeric(currency FiatCurrency) string {
+	for _, code := range []string{"704", "608", "764", "981", "986", "032", "604", "566"} {
+		if fiatCurrencyFromNumeric(code) == currency {
+			return code
+		}
+	}
+
+	return ""
+}
+
+// vietQRBIN resolves a bank code such as "Techcombank" to its NAPAS BIN. A
+// six-digit code is assumed to be a BIN already.
+func vietQRBIN(bankCode string) string {
+	if len(bankCode) == 6 && isDigits(bankCode) {
+		return bankCode
+	}
+
+	for bin, bank := range vietQRBanks() {
+		if strings.EqualFold(bank.code, bankCode) {
+			return bin
+		}
+	}
+
+	return ""
+}
+
+type emvBuilder struct {
+	buf strings.Builder
+	err error
+}
+
+func (b *emvBuilder) add(id, value string) {
+	if value == "" || b.err != nil {
+		return
+	}
+
+	if len(value) > emvMaxValueLength {
+		b.err = fmt.Errorf("%w: field %s is %d bytes, limit %d", ErrInvalidQRPayload, id, len(value), emvMaxValueLength)
+
+		return
+	}
+
+	fmt.Fprintf(&b.buf, "%s%02d%s", id, len(value), value)
+}
+
+func (b *emvBuilder) String() string {
+	return b.buf.String()
+}
+
+func emvTemplate(fields ...string) (string, error) {
+	var builder emvBuilder
+
+	for i := 0; i+1 < len(fields); i += 2 {
+		builder.add(fields[i], fields[i+1])
+	}
+
+	return builder.String(), builder.err
+}
+
+// GenerateQRCode builds an EMVCo merchant-presented payload, including its
+// CRC, for VietQR, PIX, QR Ph or PromptPay. Field lengths are counted in
+// bytes, so names should be ASCII.
+func GenerateQRCode(payload *QRPayload) (string, error) {
+	if payload == nil {
+		return "", ErrNilRequest
+	}
+
+	if payload.BankAccountNumber == "" && payload.URL == "" {
+		return "", fmt.Errorf("%w: bank account number is required", ErrInvalidQRPayload)
+	}
+
+	tag, account, defaults, err := merchantAccountTemplate(payload)
+	if err != nil {
+		return "", err
+	}
+
+	initiation := emvStaticQR
+	if payload.Amount > 0 || payload.URL != "" {
+		initiation = emvDynamicQR
+	}
+
+	currency := fiatCurrencyNumeric(FiatCurrency(firstNonEmpty(string(payload.Currency), string(defaults.currency))))
+	if currency == "" {
+		return "", fmt.Errorf("%w: unsupported currency %q", ErrInvalidQRPayload, payload.Currency)
+	}
+
+	country := firstNonEmpty(string(payload.CountryCode), string(defaults.country))
+
+	additional, err := emvTemplate("05", payload.ReferenceLabel, "08", payload.Purpose)
+	if err != nil {
+		return "", err
+	}
+
+	if payload.QRType == QRTypePIX {
+		additional, err = emvTemplate("05", firstNonEmpty(payload.ReferenceLabel, pixDefaultReference))
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var builder emvBuilder
+
+	builder.add(emvTagPayloadFormat, emvPayloadFormat)
+	builder.add(emvTagPointOfInitiation, initiation)
+	builder.add(tag, account)
+	builder.add(emvTagMerchantCategory, firstNonEmpty(payload.MerchantCategoryCode, defaults.category))
+	builder.add(emvTagCurrency, currency)
+
+	if payload.Amount > 0 {
+		builder.add(emvTagAmount, strconv.FormatFloat(payload.Amount, 'f', -1, 64))
+	}
+
+	builder.add(emvTagCountryCode, country)
+	builder.add(emvTagMerchantName, payload.MerchantName)
+	builder.add(emvTagMerchantCity, payload.MerchantCity)
+	builder.add(emvTagAdditionalData, additional)
+
+	if builder.err != nil {
+		return "", builder.err
+	}
+
+	content := builder.String() + emvTagCRC + "04"
+
+	return content + emvCRC(content), nil
+}
+
+type qrDefaults struct {
+	currency FiatCurrency
+	country  CountryCode
+	category string
+}
+
+func merchantAccountTemplate(payload *QRPayload) (string, string, qrDefaults, error) {
+	var (
+		tag      string
+		account  string
+		defaults qrDefaults
+		err      error
+	)
+
+	switch payload.QRType {
+	case QRTypeVietQR:
+		bin := vietQRBIN(payload.BankCode)
+		if bin == "" {
+			return "", "", defaults, fmt.Errorf("%w: unknown vietqr bank %q", ErrInvalidQRPayload, payload.BankCode)
+		}
+
+		beneficiary, beneficiaryErr := emvTemplate("00", bin, "01", payload.BankAccountNumber)
+		if beneficiaryErr != nil {
+			return "", "", defaults, beneficiaryErr
+		}
+
+		tag = "38"
+		defaults = qrDefaults{currency: FiatCurrencyVND, country: CountryCodeVN, category: ""}
+		account, err = emvTemplate("00", strings.ToUpper(qrGUIDVietQR), "01", beneficiary, "02", vietQRServiceAccount)
+	case QRTypePIX:
+		if payload.MerchantName == "" || payload.MerchantCity == "" {
+			return "", "", defaults, fmt.Errorf("%w: pix requires merchant name and city", ErrInvalidQRPayload)
+		}
+
+		key := payload.BankAccountNumber
+		if payload.URL != "" {
+			key = ""
+		}
+
+		tag = "26"
+		defaults = qrDefaults{currency: FiatCurrencyBRL, country: CountryCodeBR, category: pixDefaultCategory}
+		account, err = emvTemplate("00", qrGUIDPIX, "01", key, "02", payload.Purpose, "25", payload.URL)
+	case QRTypePHPPMIP2M, QRTypeComP2PQRPay:
+		if payload.BankCode == "" {
+			return "", "", defaults, fmt.Errorf("%w: qr ph requires the bank BIC", ErrInvalidQRPayload)
+		}
+
+		tag = "27"
+		defaults = qrDefaults{currency: FiatCurrencyPHP, country: CountryCodePH, category: ""}
+		account, err = emvTemplate("00", string(payload.QRType), "01", payload.BankCode, "03", payload.BankAccountNumber)
+	case QRTypePromptPay:
+		tag = "29"
+		defaults = qrDefaults{currency: FiatCurrencyTHB, country: CountryCodeTH, category: ""}
+		account, err = emvTemplate("00", strings.ToUpper(qrGUIDPromptPay), promptPayProxyTag(payload.BankAccountNumber),
+			promptPayProxy(payload.BankAccountNumber))
+	case QRTypeQR3, QRTypePayWithCrypto:
+		return "", "", defaults, fmt.Errorf("%w: %s", ErrUnsupportedQRCode, payload.QRType)
+	default:
+		return "", "", defaults, fmt.Errorf("%w: %q", ErrUnsupportedQRCode, payload.QRType)
+	}
+
+	return tag, account, defaults, err
+}
+
+// promptPayProxyTag picks the credit transfer sub-tag: 01 for a mobile number
+// (10 digits starting with 0), 02 for a 13-digit national or tax ID and 03 for
+// a 15-digit e-wallet ID.
+func promptPayProxyTag(proxy string) string {
+	const (
+		nationalIDLength = 13
+		eWalletIDLength  = 15
+	)
+
+	switch len(proxy) {
+	case nationalIDLength:
+		if !strings.HasPrefix(proxy, thaiMobilePrefix) {
+			return "02"
+		}
+	case eWalletIDLength:
+		return "03"
+	}
+
+	return "01"
+}
+
+func promptPayProxy(proxy string) string {
+	if promptPayProxyTag(proxy) == "01" && !strings.HasPrefix(proxy, thaiMobilePrefix) {
+		return thaiMobilePrefix + strings.TrimPrefix(proxy, "0")
+	}
+
+	return proxy
+}
@@ -0,0 +1,237 @@
+package goaliniex_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/andyle182810/goaliniex"
+)
+
+func TestGenerateQRCode_KnownPayloads(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		payload  *goaliniex.QRPayload
+		expected string
+	}{
+		{
+			name: "vietqr",
+			payload: &goaliniex.QRPayload{
+				QRType:               goaliniex.QRTypeVietQR,
+				BankCode:             "Techcombank",
+				BankAccountNumber:    "888812345678",
+				Amount:               50000,
+				CountryCode:          "",
+				Currency:             "",
+				MerchantName:         "",
+				MerchantCity:         "",
+				MerchantCategoryCode: "",
+				Purpose:              "Thanh toan don hang",
+				ReferenceLabel:       "",
+				URL:                  "",
+			},
+			expected: testVietQRPayload,
+		},
+		{
+			name: "promptpay",
+			payload: &goaliniex.QRPayload{
+				QRType:               goaliniex.QRTypePromptPay,
+				BankCode:             "",
+				BankAccountNumber:    "0812345678",
+				Amount:               0,
+				CountryCode:          "",
+				Currency:             "",
+				MerchantName:         "",
+				MerchantCity:         "",
+				MerchantCategoryCode: "",
+				Purpose:              "",
+				ReferenceLabel:       "",
+				URL:                  "",
+			},
+			expected: testPromptPayPayload,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			content, err := goaliniex.GenerateQRCode(tc.payload)
+			if err != nil {
+				t.Fatalf("GenerateQRCode returned error: %v", err)
+			}
+
+			if content != tc.expected {
+				t.Errorf("expected\n%s\ngot\n%s", tc.expected, content)
+			}
+		})
+	}
+}
+
+func TestGenerateQRCode_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		content string
+	}{
+		{name: "vietqr", content: testVietQRPayload},
+		{name: "pix", content: testPIXPayload},
+		{name: "qr ph p2m", content: testQRPhP2MPayload},
+		{name: "promptpay", content: testPromptPayPayload},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			original, err := goaliniex.ParseQRCode(tc.content)
+			if err != nil {
+				t.Fatalf("ParseQRCode returned error: %v", err)
+			}
+
+			content, err := goaliniex.GenerateQRCode(goaliniex.QRPayloadFromInfo(original))
+			if err != nil {
+				t.Fatalf("GenerateQRCode returned error: %v", err)
+			}
+
+			parsed, err := goaliniex.ParseQRCode(content)
+			if err != nil {
+				t.Fatalf("generated payload does not parse: %v\n%s", err, content)
+			}
+
+			if parsed.QRType != original.QRType || parsed.BankCode != original.BankCode ||
+				parsed.BankAccountNumber != original.BankAccountNumber || parsed.Amount != original.Amount ||
+				parsed.CountryCode != original.CountryCode {
+				t.Errorf("round trip mismatch:\noriginal %+v\nparsed   %+v", original, parsed)
+			}
+
+			for key, value := range original.AdditionalData {
+				if key == "pointOfInitiation" {
+					continue
+				}
+
+				if parsed.AdditionalData[key] != value {
+					t.Errorf("AdditionalData[%q]: expected %v, got %v", key, value, parsed.AdditionalData[key])
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateQRCode_PIXDynamic(t *testing.T) {
+	t.Parallel()
+
+	content, err := goaliniex.GenerateQRCode(&goaliniex.QRPayload{
+		QRType:               goaliniex.QRTypePIX,
+		BankCode:             "",
+		BankAccountNumber:    "",
+		Amount:               12.34,
+		CountryCode:          "",
+		Currency:             "",
+		MerchantName:         "Loja Exemplo",
+		MerchantCity:         "SAO PAULO",
+		MerchantCategoryCode: "",
+		Purpose:              "",
+		ReferenceLabel:       "TX123",
+		URL:                  "pix.example.com/qr/v2/9d36b84f",
+	})
+	if err != nil {
+		t.Fatalf("GenerateQRCode returned error: %v", err)
+	}
+
+	info, err := goaliniex.ParseQRCode(content)
+	if err != nil {
+		t.Fatalf("ParseQRCode returned error: %v", err)
+	}
+
+	if info.AdditionalData["url"] != "pix.example.com/qr/v2/9d36b84f" || info.AdditionalData["pointOfInitiation"] != "dynamic" {
+		t.Errorf("unexpected dynamic pix data %v", info.AdditionalData)
+	}
+
+	if info.Amount != 12.34 || info.AdditionalData["referenceLabel"] != "TX123" {
+		t.Errorf("unexpected amount or txid in %+v", info)
+	}
+}
+
+func TestGenerateQRCode_Errors(t *testing.T) {
+	t.Parallel()
+
+	valid := func() *goaliniex.QRPayload {
+		return &goaliniex.QRPayload{
+			QRType:               goaliniex.QRTypeVietQR,
+			BankCode:             "970407",
+			BankAccountNumber:    "888812345678",
+			Amount:               0,
+			CountryCode:          "",
+			Currency:             "",
+			MerchantName:         "",
+			MerchantCity:         "",
+			MerchantCategoryCode: "",
+			Purpose:              "",
+			ReferenceLabel:       "",
+			URL:                  "",
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		modify   func(p *goaliniex.QRPayload)
+		expected error
+	}{
+		{
+			name:     "unknown bank",
+			modify:   func(p *goaliniex.QRPayload) { p.BankCode = "NoSuchBank" },
+			expected: goaliniex.ErrInvalidQRPayload,
+		},
+		{
+			name:     "missing account",
+			modify:   func(p *goaliniex.QRPayload) { p.BankAccountNumber = "" },
+			expected: goaliniex.ErrInvalidQRPayload,
+		},
+		{
+			name:     "unknown currency",
+			modify:   func(p *goaliniex.QRPayload) { p.Currency = "USD" },
+			expected: goaliniex.ErrInvalidQRPayload,
+		},
+		{
+			name:     "field too long",
+			modify:   func(p *goaliniex.QRPayload) { p.Purpose = strings.Repeat("x", 100) },
+			expected: goaliniex.ErrInvalidQRPayload,
+		},
+		{
+			name:     "pix without merchant",
+			modify:   func(p *goaliniex.QRPayload) { p.QRType = goaliniex.QRTypePIX },
+			expected: goaliniex.ErrInvalidQRPayload,
+		},
+		{
+			name:     "qr ph without bic",
+			modify:   func(p *goaliniex.QRPayload) { p.QRType, p.BankCode = goaliniex.QRTypeComP2PQRPay, "" },
+			expected: goaliniex.ErrInvalidQRPayload,
+		},
+		{
+			name:     "unsupported type",
+			modify:   func(p *goaliniex.QRPayload) { p.QRType = goaliniex.QRTypePayWithCrypto },
+			expected: goaliniex.ErrUnsupportedQRCode,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			payload := valid()
+			tc.modify(payload)
+
+			if _, err := goaliniex.GenerateQRCode(payload); !errors.Is(err, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+
+	if _, err := goaliniex.GenerateQRCode(nil); !errors.Is(err, goaliniex.ErrNilRequest) {
+		t.Errorf("expected ErrNilRequest, got %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+package goaliniex
+
+import (
+	"context"
+	"image"
+
+	"github.com/andyle182810/goaliniex/qrcode"
+)
+
+// ParseQRCodeImage reads the QR code in img, such as a decoded PNG or JPEG
+// screenshot, and parses it offline with ParseQRCode.
+func ParseQRCodeImage(img image.Image) (*QRCodeInfo, error) {
+	content, err := qrcode.Decode(img)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseQRCode(content)
+}
+
+// DecodeQRCodeImage reads the QR code in img and resolves it with
+// DecodeQRCode, falling back to GetQRCodeInfo for unknown formats.
+func (c *Client) DecodeQRCodeImage(ctx context.Context, img image.Image) (*QRCodeInfo, error) {
+	content, err := qrcode.Decode(img)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.DecodeQRCode(ctx, content)
+}
@@ -0,0 +1,71 @@
+package goaliniex_test
+
+import (
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/andyle182810/goaliniex"
+	"github.com/andyle182810/goaliniex/qrcode"
+)
+
+func loadTestQRImage(t *testing.T) image.Image {
+	t.Helper()
+
+	file, err := os.Open("testdata/vietqr.png")
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer file.Close()
+
+	img, err := png.Decode(file)
+	if err != nil {
+		t.Fatalf("decode fixture: %v", err)
+	}
+
+	return img
+}
+
+func TestParseQRCodeImage(t *testing.T) {
+	t.Parallel()
+
+	info, err := goaliniex.ParseQRCodeImage(loadTestQRImage(t))
+	if err != nil {
+		t.Fatalf("ParseQRCodeImage returned error: %v", err)
+	}
+
+	if info.QRType != goaliniex.QRTypeVietQR || info.BankAccountNumber != "888812345678" || info.Amount != 50000 {
+		t.Errorf("unexpected QR info %+v", info)
+	}
+
+	blank := image.NewGray(image.Rect(0, 0, 50, 50))
+	if _, err := goaliniex.ParseQRCodeImage(blank); !errors.Is(err, qrcode.ErrNotFound) {
+		t.Errorf("expected qrcode.ErrNotFound, got %v", err)
+	}
+}
+
+func TestClient_DecodeQRCodeImage(t *testing.T) {
+	t.Parallel()
+
+	recorder := &recordingHTTPClient{
+		response: func() *http.Response { return mockResponse(http.StatusOK, "{}") }, //nolint:bodyclose // closed by client
+	}
+
+	client, err := newTestClientWithMock(recorder)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	info, err := client.DecodeQRCodeImage(context.Background(), loadTestQRImage(t))
+	if err != nil {
+		t.Fatalf("DecodeQRCodeImage returned error: %v", err)
+	}
+
+	if info.BankCode != "Techcombank" || len(recorder.requests) != 0 {
+		t.Errorf("expected offline VietQR parse, got %+v after %d requests", info, len(recorder.requests))
+	}
+}
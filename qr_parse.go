@@ -0,0 +1,350 @@
+package goaliniex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Merchant account information identifiers (tag 00 of templates 26-51).
+const (
+	qrGUIDVietQR          = "a000000727"
+	qrGUIDPIX             = "br.gov.bcb.pix"
+	qrGUIDPromptPay       = "a000000677010111"
+	qrGUIDPromptPayBiller = "a000000677010112"
+)
+
+const thaiMobilePrefix = "0066"
+
+type vietQRBank struct {
+	code string
+	name string
+}
+
+// vietQRBanks maps NAPAS acquirer BINs to the bank codes and names returned
+// by get-qr-code-info.
+func vietQRBanks() map[string]vietQRBank {
+	return map[string]vietQRBank{
+		"970400": {"SaigonBank", "Ngân hàng TMCP Sài Gòn Công Thương"},
+		"970403": {"Sacombank", "Ngân hàng TMCP Sài Gòn Thương Tín"},
+		"970405": {"Agribank", "Ngân hàng Nông nghiệp và Phát triển Nông thôn Việt Nam"},
+		"970406": {"DongABank", "Ngân hàng TMCP Đông Á"},
+		"970407": {"Techcombank", "Ngân hàng TMCP Kỹ thương Việt Nam"},
+		"970408": {"GPBank", "Ngân hàng Thương mại TNHH MTV Dầu Khí Toàn Cầu"},
+		"970409": {"BacABank", "Ngân hàng TMCP Bắc Á"},
+		"970412": {"PVcomBank", "Ngân hàng TMCP Đại Chúng Việt Nam"},
+		"970414": {"Oceanbank", "Ngân hàng Thương mại TNHH MTV Đại Dương"},
+		"970415": {"VietinBank", "Ngân hàng TMCP Công thương Việt Nam"},
+		"970416": {"ACB", "Ngân hàng TMCP Á Châu"},
+		"970418": {"BIDV", "Ngân hàng TMCP Đầu tư và Phát triển Việt Nam"},
+		"970419": {"NCB", "Ngân hàng TMCP Quốc Dân"},
+		"970422": {"MBBank", "Ngân hàng TMCP Quân đội"},
+		"970423": {"TPBank", "Ngân hàng TMCP Tiên Phong"},
+		"970425": {"ABBANK", "Ngân hàng TMCP An Bình"},
+		"970426": {"MSB", "Ngân hàng TMCP Hàng Hải"},
+		"970427": {"VietABank", "Ngân hàng TMCP Việt Á"},
+		"970428": {"NamABank", "Ngân hàng TMCP Nam Á"},
+		"970429": {"SCB", "Ngân hàng TMCP Sài Gòn"},
+		"970430": {"PGBank", "Ngân hàng TMCP Thịnh vượng và Phát triển"},
+		"970431": {"Eximbank", "Ngân hàng TMCP Xuất Nhập khẩu Việt Nam"},
+		"970432": {"VPBank", "Ngân hàng TMCP Việt Nam Thịnh Vượng"},
+		"970433": {"VietBank", "Ngân hàng TMCP Việt Nam Thương Tín"},
+		"970436": {"Vietcombank", "Ngân hàng TMCP Ngoại Thương Việt Nam"},
+		"970437": {"HDBank", "Ngân hàng TMCP Phát triển Thành phố Hồ Chí Minh"},
+		"970438": {"BaoVietBank", "Ngân hàng TMCP Bảo Việt"},
+		"970440": {"SeABank", "Ngân hàng TMCP Đông Nam Á"},
+		"970441": {"VIB", "Ngân hàng TMCP Quốc tế Việt Nam"},
+		"970443": {"SHB", "Ngân hàng TMCP Sài Gòn - Hà Nội"},
+		"970448": {"OCB", "Ngân hàng TMCP Phương Đông"},
+		"970449": {"LPBank", "Ngân hàng TMCP Lộc Phát Việt Nam"},
+		"970452": {"KienLongBank", "Ngân hàng TMCP Kiên Long"},
+		"970454": {"VietCapitalBank", "Ngân hàng TMCP Bản Việt"},
+	}
+}
+
+// fiatCurrencyFromNumeric converts an ISO 4217 numeric code (EMVCo tag 53).
+func fiatCurrencyFromNumeric(code string) FiatCurrency {
+	switch code {
+	case "704":
+		return FiatCurrencyVND
+	case "608":
+		return FiatCurrencyPHP
+	case "764":
+		return FiatCurrencyTHB
+	case "981":
+		return FiatCurrencyGEL
+	case "986":
+		return FiatCurrencyBRL
+	case "032":
+		return FiatCurrencyARS
+	case "604":
+		return FiatCurrencyPEN
+	case "566":
+		return FiatCurrencyNGN
+	default:
+		return ""
+	}
+}
+
+func setAdditional(data map[string]any, key, value string) {
+	if value != "" {
+		data[key] = value
+	}
+}
+
+// ParseQRCode decodes an EMVCo merchant-presented QR payload locally, without
+// calling get-qr-code-info. VietQR, PIX, QR Ph (P2M and P2P) and PromptPay
+// are recognised. Other payloads return ErrUnsupportedQRCode; corrupted ones
+// return ErrInvalidQRCode or ErrQRCodeChecksum.
+func ParseQRCode(content string) (*QRCodeInfo, error) {
+	fields, err := decodeEMVPayload(content)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &QRCodeInfo{
+		BankAccountNumber: "",
+		BankCode:          "",
+		BankName:          "",
+		CountryCode:       "",
+		QRType:            "",
+		AdditionalData:    map[string]any{},
+		Amount:            0,
+	}
+
+	if err := applyMerchantAccount(fields, info); err != nil {
+		return nil, err
+	}
+
+	if err := applyTransactionFields(fields, info); err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+func applyMerchantAccount(fields emvFields, info *QRCodeInfo) error {
+	for _, field := range fields {
+		tag, err := strconv.Atoi(field.id)
+		if err != nil || tag < emvTagMerchantAccountLo || tag > emvTagMerchantAccountHi {
+			continue
+		}
+
+		template, err := parseEMVFields(field.value)
+		if err != nil {
+			return err
+		}
+
+		var applied bool
+
+		switch guid := strings.ToLower(template.get("00")); guid {
+		case qrGUIDVietQR:
+			applied, err = applyVietQR(template, info)
+		case qrGUIDPIX:
+			applied = applyPIX(template, info)
+		case string(QRTypePHPPMIP2M), string(QRTypeComP2PQRPay):
+			applied = applyQRPh(QRType(guid), template, info)
+		case qrGUIDPromptPay, qrGUIDPromptPayBiller:
+			applied = applyPromptPay(guid, template, info)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if applied {
+			return nil
+		}
+	}
+
+	return ErrUnsupportedQRCode
+}
+
+func applyVietQR(template emvFields, info *QRCodeInfo) (bool, error) {
+	beneficiary, err := parseEMVFields(template.get("01"))
+	if err != nil {
+		return false, err
+	}
+
+	bin := beneficiary.get("00")
+	account := beneficiary.get("01")
+
+	if bin == "" || account == "" {
+		return false, fmt.Errorf("%w: vietqr template without bin or account", ErrInvalidQRCode)
+	}
+
+	info.QRType = QRTypeVietQR
+	info.CountryCode = CountryCodeVN
+	info.BankAccountNumber = account
+	info.BankCode = bin
+
+	if bank, ok := vietQRBanks()[bin]; ok {
+		info.BankCode = bank.code
+		info.BankName = bank.name
+	}
+
+	info.AdditionalData["bankAccountNumber"] = account
+	info.AdditionalData["bankCode"] = info.BankCode
+	info.AdditionalData["bin"] = bin
+	setAdditional(info.AdditionalData, "serviceCode", template.get("02"))
+
+	return true, nil
+}
+
+func applyPIX(template emvFields, info *QRCodeInfo) bool {
+	key := template.get("01")
+	url := template.get("25")
+
+	if key == "" && url == "" {
+		return false
+	}
+
+	info.QRType = QRTypePIX
+	info.CountryCode = CountryCodeBR
+	info.BankAccountNumber = key
+
+	setAdditional(info.AdditionalData, "pixKey", key)
+	setAdditional(info.AdditionalData, "description", template.get("02"))
+	setAdditional(info.AdditionalData, "url", url)
+
+	return true
+}
+
+func applyQRPh(qrType QRType, template emvFields, info *QRCodeInfo) bool {
+	bic := template.get("01")
+
+	account := template.get("03")
+	if account == "" {
+		account = template.get("04")
+	}
+
+	if bic == "" {
+		return false
+	}
+
+	info.QRType = qrType
+	info.CountryCode = CountryCodePH
+	info.BankCode = bic
+	info.BankAccountNumber = account
+
+	setAdditional(info.AdditionalData, "acquirerId", bic)
+	setAdditional(info.AdditionalData, "paymentType", template.get("02"))
+	setAdditional(info.AdditionalData, "bankAccountNumber", account)
+	setAdditional(info.AdditionalData, "mobileNumber", template.get("04"))
+
+	return true
+}
+
+func applyPromptPay(guid string, template emvFields, info *QRCodeInfo) bool {
+	var proxyType, proxy string
+
+	if guid == qrGUIDPromptPayBiller {
+		proxyType, proxy = "billerId", template.get("01")
+		setAdditional(info.AdditionalData, "reference1", template.get("02"))
+		setAdditional(info.AdditionalData, "reference2", template.get("03"))
+	} else {
+		for _, candidate := range []struct{ tag, kind string }{
+			{"01", "mobileNumber"},
+			{"02", "nationalId"},
+			{"03", "eWalletId"},
+			{"04", "bankAccount"},
+		} {
+			if value := template.get(candidate.tag); value != "" {
+				proxyType, proxy = candidate.kind, value
+
+				break
+			}
+		}
+	}
+
+	if proxy == "" {
+		return false
+	}
+
+	if local, found := strings.CutPrefix(proxy, thaiMobilePrefix); found && proxyType == "mobileNumber" {
+		proxy = "0" + local
+	}
+
+	info.QRType = QRTypePromptPay
+	info.CountryCode = CountryCodeTH
+	info.BankAccountNumber = proxy
+
+	info.AdditionalData["proxyType"] = proxyType
+	info.AdditionalData["proxyId"] = proxy
+
+	return true
+}
+
+func applyTransactionFields(fields emvFields, info *QRCodeInfo) error {
+	if amount := fields.get(emvTagAmount); amount != "" {
+		value, err := strconv.ParseFloat(amount, 64)
+		if err != nil || value < 0 {
+			return fmt.Errorf("%w: invalid amount %q", ErrInvalidQRCode, amount)
+		}
+
+		info.Amount = value
+	}
+
+	if country := fields.get(emvTagCountryCode); country != "" {
+		info.CountryCode = CountryCode(strings.ToUpper(country))
+	}
+
+	switch fields.get(emvTagPointOfInitiation) {
+	case emvStaticQR:
+		info.AdditionalData["pointOfInitiation"] = "static"
+	case emvDynamicQR:
+		info.AdditionalData["pointOfInitiation"] = "dynamic"
+	}
+
+	setAdditional(info.AdditionalData, "currency", string(fiatCurrencyFromNumeric(fields.get(emvTagCurrency))))
+	setAdditional(info.AdditionalData, "merchantCategoryCode", fields.get(emvTagMerchantCategory))
+	setAdditional(info.AdditionalData, "merchantName", fields.get(emvTagMerchantName))
+	setAdditional(info.AdditionalData, "merchantCity", fields.get(emvTagMerchantCity))
+	setAdditional(info.AdditionalData, "postalCode", fields.get(emvTagPostalCode))
+
+	additional, err := parseEMVFields(fields.get(emvTagAdditionalData))
+	if err != nil {
+		return err
+	}
+
+	for _, item := range []struct{ tag, key string }{
+		{"01", "billNumber"},
+		{"02", "mobileNumber"},
+		{"03", "storeLabel"},
+		{"04", "loyaltyNumber"},
+		{"05", "referenceLabel"},
+		{"06", "customerLabel"},
+		{"07", "terminalLabel"},
+		{"08", "purpose"},
+	} {
+		if _, exists := info.AdditionalData[item.key]; !exists {
+			setAdditional(info.AdditionalData, item.key, additional.get(item.tag))
+		}
+	}
+
+	return nil
+}
+
+// DecodeQRCode parses content with ParseQRCode and, for formats the local
+// parser does not recognise, falls back to GetQRCodeInfo.
+func (c *Client) DecodeQRCode(ctx context.Context, content string) (*QRCodeInfo, error) {
+	info, err := ParseQRCode(content)
+	if !errors.Is(err, ErrUnsupportedQRCode) {
+		return info, err
+	}
+
+	c.logDebug("qr code not recognised locally, using api", "error", err)
+
+	response, err := c.GetQRCodeInfo(ctx, &GetQRCodeInfoRequest{QRContent: content})
+	if err != nil {
+		return nil, err
+	}
+
+	if !response.Success || response.Data == nil {
+		return nil, fmt.Errorf("%w: %s (errorCode=%d)", ErrUnsupportedQRCode, response.Message, response.ErrorCode)
+	}
+
+	return response.Data, nil
+}
@@ -0,0 +1,242 @@
+package goaliniex_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/andyle182810/goaliniex"
+)
+
+const (
+	testVietQRPayload = "00020101021238560010A0000007270126000697040701128888123456780208QRIBFTTA" +
+		"53037045405500005802VN62230819Thanh toan don hang6304DF40"
+	testPIXPayload = "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-426655440000" +
+		"5204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D"
+	testQRPhP2PPayload = "00020101021127580012com.p2pqrpay0111GXCHPHM2XXX020899964403041109171234567" +
+		"5204601653036085802PH5914JUAN DELA CRUZ6006Manila630475ED"
+	testQRPhP2MPayload = "00020101021228460011ph.ppmi.p2m0111BNORPHMMXXX0312123456789012" +
+		"5204541153036085406250.505802PH5915SARI SARI STORE6011Quezon City6304BB0E"
+	testPromptPayPayload  = "00020101021129370016A0000006770101110113006681234567853037645802TH6304823E"
+	testUnknownEMVPayload = "00020101021126240015com.example.pay0101X5802SG6304975F"
+)
+
+func TestParseQRCode(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		content    string
+		qrType     goaliniex.QRType
+		country    goaliniex.CountryCode
+		bankCode   string
+		bankName   string
+		account    string
+		amount     float64
+		additional map[string]any
+	}{
+		{
+			name:     "vietqr",
+			content:  testVietQRPayload,
+			qrType:   goaliniex.QRTypeVietQR,
+			country:  goaliniex.CountryCodeVN,
+			bankCode: "Techcombank",
+			bankName: "Ngân hàng TMCP Kỹ thương Việt Nam",
+			account:  "888812345678",
+			amount:   50000,
+			additional: map[string]any{
+				"bankAccountNumber": "888812345678",
+				"bankCode":          "Techcombank",
+				"bin":               "970407",
+				"purpose":           "Thanh toan don hang",
+				"currency":          "VND",
+				"pointOfInitiation": "dynamic",
+			},
+		},
+		{
+			name:     "pix",
+			content:  testPIXPayload,
+			qrType:   goaliniex.QRTypePIX,
+			country:  goaliniex.CountryCodeBR,
+			bankCode: "",
+			bankName: "",
+			account:  "123e4567-e12b-12d1-a456-426655440000",
+			amount:   0,
+			additional: map[string]any{
+				"pixKey":         "123e4567-e12b-12d1-a456-426655440000",
+				"merchantName":   "Fulano de Tal",
+				"merchantCity":   "BRASILIA",
+				"referenceLabel": "***",
+				"currency":       "BRL",
+			},
+		},
+		{
+			name:     "qr ph p2p",
+			content:  testQRPhP2PPayload,
+			qrType:   goaliniex.QRTypeComP2PQRPay,
+			country:  goaliniex.CountryCodePH,
+			bankCode: "GXCHPHM2XXX",
+			bankName: "",
+			account:  "09171234567",
+			amount:   0,
+			additional: map[string]any{
+				"mobileNumber":      "09171234567",
+				"merchantName":      "JUAN DELA CRUZ",
+				"pointOfInitiation": "static",
+			},
+		},
+		{
+			name:     "qr ph p2m",
+			content:  testQRPhP2MPayload,
+			qrType:   goaliniex.QRTypePHPPMIP2M,
+			country:  goaliniex.CountryCodePH,
+			bankCode: "BNORPHMMXXX",
+			bankName: "",
+			account:  "123456789012",
+			amount:   250.5,
+			additional: map[string]any{
+				"merchantCategoryCode": "5411",
+				"merchantCity":         "Quezon City",
+				"currency":             "PHP",
+			},
+		},
+		{
+			name:     "promptpay",
+			content:  testPromptPayPayload,
+			qrType:   goaliniex.QRTypePromptPay,
+			country:  goaliniex.CountryCodeTH,
+			bankCode: "",
+			bankName: "",
+			account:  "0812345678",
+			amount:   0,
+			additional: map[string]any{
+				"proxyType": "mobileNumber",
+				"currency":  "THB",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			info, err := goaliniex.ParseQRCode(tc.content)
+			if err != nil {
+				t.Fatalf("ParseQRCode returned error: %v", err)
+			}
+
+			if info.QRType != tc.qrType || info.CountryCode != tc.country {
+				t.Errorf("expected %s/%s, got %s/%s", tc.qrType, tc.country, info.QRType, info.CountryCode)
+			}
+
+			if info.BankCode != tc.bankCode || info.BankName != tc.bankName {
+				t.Errorf("expected bank %q (%q), got %q (%q)", tc.bankCode, tc.bankName, info.BankCode, info.BankName)
+			}
+
+			if info.BankAccountNumber != tc.account {
+				t.Errorf("expected account %q, got %q", tc.account, info.BankAccountNumber)
+			}
+
+			if info.Amount != tc.amount {
+				t.Errorf("expected amount %v, got %v", tc.amount, info.Amount)
+			}
+
+			for key, expected := range tc.additional {
+				if got := info.AdditionalData[key]; got != expected {
+					t.Errorf("expected AdditionalData[%q]=%v, got %v", key, expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestParseQRCode_Errors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		content  string
+		expected error
+	}{
+		{name: "not emvco", content: "bitcoin:bc1qxy2kgdygjrsqtzq2n0yrf2493p83kkfjhx0wlh", expected: goaliniex.ErrUnsupportedQRCode},
+		{name: "unknown template", content: testUnknownEMVPayload, expected: goaliniex.ErrUnsupportedQRCode},
+		{name: "bad checksum", content: testVietQRPayload[:len(testVietQRPayload)-4] + "0000", expected: goaliniex.ErrQRCodeChecksum},
+		{name: "truncated", content: testVietQRPayload[:40], expected: goaliniex.ErrInvalidQRCode},
+		{name: "missing crc", content: "000201010211", expected: goaliniex.ErrInvalidQRCode},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := goaliniex.ParseQRCode(tc.content); !errors.Is(err, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestClient_DecodeQRCode(t *testing.T) {
+	t.Parallel()
+
+	apiResponse := `{"success":true,"message":"Success","errorCode":0,` +
+		`"data":{"bankAccountNumber":"0xabc","countryCode":"VN","qrType":"paywithcrypto"}}`
+
+	testCases := []struct {
+		name     string
+		content  string
+		requests int
+		qrType   goaliniex.QRType
+	}{
+		{name: "parsed locally", content: testVietQRPayload, requests: 0, qrType: goaliniex.QRTypeVietQR},
+		{name: "falls back to api", content: "0xabc", requests: 1, qrType: goaliniex.QRTypePayWithCrypto},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := &recordingHTTPClient{
+				response: func() *http.Response { return mockResponse(http.StatusOK, apiResponse) }, //nolint:bodyclose // closed by client
+			}
+
+			client, err := newTestClientWithMock(recorder)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			info, err := client.DecodeQRCode(context.Background(), tc.content)
+			if err != nil {
+				t.Fatalf("DecodeQRCode returned error: %v", err)
+			}
+
+			if info.QRType != tc.qrType || len(recorder.requests) != tc.requests {
+				t.Errorf("expected %s with %d api calls, got %s with %d", tc.qrType, tc.requests, info.QRType, len(recorder.requests))
+			}
+		})
+	}
+}
+
+//nolint:bodyclose // Response bodies closed by client
+func TestClient_DecodeQRCode_DoesNotFallBackOnCorruptPayload(t *testing.T) {
+	t.Parallel()
+
+	client, err := newTestClientWithMock(&mockHTTPClient{
+		response: mockResponse(http.StatusOK, `{"success":false,"message":"unused","data":null,"errorCode":1}`),
+		err:      nil,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = client.DecodeQRCode(context.Background(), testPIXPayload[:len(testPIXPayload)-1]+"E")
+	if !errors.Is(err, goaliniex.ErrQRCodeChecksum) {
+		t.Errorf("expected ErrQRCodeChecksum, got %v", err)
+	}
+
+	_, err = client.DecodeQRCode(context.Background(), "unknown")
+	if !errors.Is(err, goaliniex.ErrUnsupportedQRCode) {
+		t.Errorf("expected ErrUnsupportedQRCode from failed api lookup, got %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package qrcode
+
+import "image"
+
+// bitmap is a binarized image; true is dark.
+type bitmap struct {
+	width  int
+	height int
+	dark   []bool
+}
+
+func (b *bitmap) at(x, y int) bool {
+	if x < 0 || y < 0 || x >= b.width || y >= b.height {
+		return false
+	}
+
+	return b.dark[y*b.width+x]
+}
+
+// binarize converts img to black and white with Otsu's threshold.
+// Transparent pixels are composited over white.
+func binarize(img image.Image) *bitmap {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	luminance := make([]uint8, width*height)
+
+	var histogram [256]int
+
+	for y := range height {
+		for x := range width {
+			r, g, b, a := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			background := 0xFFFF - a
+			r, g, b = r+background, g+background, b+background
+
+			// ITU-R BT.601 luma on 16-bit channels, scaled to 8 bits.
+			lum := uint8((19595*r + 38470*g + 7471*b + 1<<15) >> 24) //nolint:gosec // at most 255
+			luminance[y*width+x] = lum
+			histogram[lum]++
+		}
+	}
+
+	threshold := otsuThreshold(&histogram, width*height)
+
+	dark := make([]bool, len(luminance))
+	for i, lum := range luminance {
+		dark[i] = int(lum) <= threshold
+	}
+
+	return &bitmap{width: width, height: height, dark: dark}
+}
+
+func otsuThreshold(histogram *[256]int, total int) int {
+	var sum float64
+	for level, count := range histogram {
+		sum += float64(level * count)
+	}
+
+	var (
+		sumBackground float64
+		weight        int
+		best          float64
+		threshold     int
+	)
+
+	for level, count := range histogram {
+		weight += count
+		if weight == 0 {
+			continue
+		}
+
+		foreground := total - weight
+		if foreground == 0 {
+			break
+		}
+
+		sumBackground += float64(level * count)
+
+		meanBackground := sumBackground / float64(weight)
+		meanForeground := (sum - sumBackground) / float64(foreground)
+		between := float64(weight) * float64(foreground) * (meanBackground - meanForeground) * (meanBackground - meanForeground)
+
+		if between > best {
+			best, threshold = between, level
+		}
+	}
+
+	return threshold
+}
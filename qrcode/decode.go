@@ -0,0 +1,237 @@
+// Package qrcode decodes QR codes from images without external dependencies.
+package qrcode
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg" // Register JPEG for DecodeReader.
+	_ "image/png"  // Register PNG for DecodeReader.
+	"io"
+	"math"
+)
+
+var (
+	ErrNotFound = errors.New("no qr code found")
+	ErrDecode   = errors.New("failed to decode qr code")
+	ErrImage    = errors.New("failed to read image")
+
+	errFormatInfo  = errors.New("unreadable format information")
+	errVersionInfo = errors.New("version information does not match symbol size")
+)
+
+// Decode locates a QR code in img and returns its text content.
+func Decode(img image.Image) (string, error) {
+	bitmap := binarize(img)
+
+	triples := finderTriples(bitmap.findFinderPatterns())
+	if len(triples) == 0 {
+		return "", ErrNotFound
+	}
+
+	var lastErr error
+
+	for _, triple := range triples {
+		for _, size := range candidateSizes(triple) {
+			for _, transform := range bitmap.transforms(triple, size) {
+				content, err := decodeGrid(bitmap.sample(transform, size))
+				if err == nil {
+					return content, nil
+				}
+
+				lastErr = err
+			}
+		}
+	}
+
+	return "", fmt.Errorf("%w: %w", ErrDecode, lastErr)
+}
+
+// DecodeReader decodes a PNG or JPEG image and returns the QR code content.
+func DecodeReader(r io.Reader) (string, error) {
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrImage, err)
+	}
+
+	return Decode(img)
+}
+
+// candidateSizes estimates the symbol size from the finder spacing, nearest
+// valid sizes first.
+func candidateSizes(triple finderTriple) []int {
+	module := (triple.topLeft.moduleSize + triple.topRight.moduleSize + triple.bottomLeft.moduleSize) / 3
+	span := (distance(triple.topLeft.center, triple.topRight.center) +
+		distance(triple.topLeft.center, triple.bottomLeft.center)) / 2
+	estimate := span/module + finderModules
+
+	version := int(math.Round((estimate - float64(dimensionForVersion(0))) / 4))
+
+	var sizes []int
+
+	for _, delta := range []int{0, -1, 1} {
+		if v := version + delta; v >= minVersion && v <= maxVersion {
+			sizes = append(sizes, dimensionForVersion(v))
+		}
+	}
+
+	return sizes
+}
+
+// decodeGrid decodes a sampled module grid.
+func decodeGrid(grid [][]bool) (string, error) {
+	size := len(grid)
+	version := (size - dimensionForVersion(0)) / 4
+
+	level, mask, err := readFormat(grid)
+	if err != nil {
+		return "", err
+	}
+
+	if version >= versionInfoMinVersion {
+		if err := checkVersion(grid, version); err != nil {
+			return "", err
+		}
+	}
+
+	codewords := readCodewords(grid, version, mask)
+
+	data, err := correctBlocks(codewords, version, level)
+	if err != nil {
+		return "", err
+	}
+
+	return decodeSegments(data, version)
+}
+
+func readBits(grid [][]bool, positions [][2]int) int {
+	value := 0
+
+	for _, pos := range positions {
+		value <<= 1
+		if grid[pos[1]][pos[0]] {
+			value |= 1
+		}
+	}
+
+	return value
+}
+
+func readFormat(grid [][]bool) (Level, int, error) {
+	first, second := formatInfoPositions(len(grid))
+
+	for _, positions := range [][15][2]int{first, second} {
+		if level, mask, ok := decodeFormatInfo(readBits(grid, positions[:])); ok {
+			return level, mask, nil
+		}
+	}
+
+	return 0, 0, errFormatInfo
+}
+
+func checkVersion(grid [][]bool, version int) error {
+	topRight, bottomLeft := versionInfoPositions(len(grid))
+
+	for _, positions := range [][18][2]int{topRight, bottomLeft} {
+		if decoded, ok := decodeVersionInfo(readBits(grid, positions[:])); ok {
+			if decoded != version {
+				return fmt.Errorf("%w: version %d, size %d", errVersionInfo, decoded, len(grid))
+			}
+
+			return nil
+		}
+	}
+
+	return errVersionInfo
+}
+
+// readCodewords reads the unmasked data area in zigzag order.
+func readCodewords(grid [][]bool, version, mask int) []byte {
+	size := len(grid)
+	function := functionModules(version)
+	codewords := make([]byte, rawCodewords(version))
+	bitIndex := 0
+
+	for right := size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+
+		for vert := range size {
+			for j := range 2 {
+				x := right - j
+
+				y := vert
+				if (right+1)&2 == 0 {
+					y = size - 1 - vert
+				}
+
+				if function[y][x] || bitIndex >= len(codewords)*8 {
+					continue
+				}
+
+				if grid[y][x] != masked(mask, x, y) {
+					codewords[bitIndex>>3] |= 1 << (7 - bitIndex&7)
+				}
+
+				bitIndex++
+			}
+		}
+	}
+
+	return codewords
+}
+
+// correctBlocks de-interleaves the codewords into error correction blocks,
+// corrects each and returns the concatenated data codewords.
+func correctBlocks(codewords []byte, version int, level Level) ([]byte, error) {
+	numBlocks := numECBlocks(level, version)
+	ecLen := eccCodewordsPerBlock(level, version)
+	numShort := numBlocks - len(codewords)%numBlocks
+	shortLen := len(codewords) / numBlocks
+	shortData := shortLen - ecLen
+
+	blocks := make([][]byte, numBlocks)
+	for i := range blocks {
+		length := shortLen
+		if i >= numShort {
+			length++
+		}
+
+		blocks[i] = make([]byte, length)
+	}
+
+	index := 0
+
+	// Data codewords, where long blocks have one extra at the end.
+	for i := range shortData + 1 {
+		for j := range blocks {
+			if i == shortData && j < numShort {
+				continue
+			}
+
+			blocks[j][i] = codewords[index]
+			index++
+		}
+	}
+
+	for i := range ecLen {
+		for j := range blocks {
+			blocks[j][len(blocks[j])-ecLen+i] = codewords[index]
+			index++
+		}
+	}
+
+	field := newGF256()
+	data := make([]byte, 0, dataCodewords(version, level))
+
+	for _, block := range blocks {
+		if _, err := field.correct(block, ecLen); err != nil {
+			return nil, err
+		}
+
+		data = append(data, block[:len(block)-ecLen]...)
+	}
+
+	return data, nil
+}
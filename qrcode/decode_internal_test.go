@@ -0,0 +1,347 @@
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReedSolomonEncode_KnownVector(t *testing.T) {
+	t.Parallel()
+
+	// "HELLO WORLD" at 1-M.
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	expected := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	if got := reedSolomonEncode(newGF256(), data, 10); !bytes.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestReedSolomonCorrect(t *testing.T) {
+	t.Parallel()
+
+	field := newGF256()
+	rng := rand.New(rand.NewPCG(7, 11)) //nolint:gosec // deterministic test data
+
+	for _, numEC := range []int{7, 10, 22, 30} {
+		for errorCount := 0; errorCount <= numEC/2; errorCount++ {
+			data := make([]byte, 40)
+			for i := range data {
+				data[i] = byte(rng.IntN(256))
+			}
+
+			block := append(slices.Clone(data), reedSolomonEncode(field, data, numEC)...)
+			original := slices.Clone(block)
+
+			for _, pos := range rng.Perm(len(block))[:errorCount] {
+				block[pos] ^= byte(1 + rng.IntN(255))
+			}
+
+			corrected, err := field.correct(block, numEC)
+			if err != nil {
+				t.Fatalf("ec=%d errors=%d: %v", numEC, errorCount, err)
+			}
+
+			if corrected != errorCount || !bytes.Equal(block, original) {
+				t.Fatalf("ec=%d errors=%d: corrected %d, block mismatch", numEC, errorCount, corrected)
+			}
+		}
+	}
+}
+
+func TestFormatAndVersionInfo_KnownValues(t *testing.T) {
+	t.Parallel()
+
+	formats := []struct {
+		level    Level
+		mask     int
+		expected int
+	}{
+		{LevelL, 0, 0b111011111000100},
+		{LevelL, 4, 0b110011000101111},
+		{LevelM, 0, 0b101010000010010},
+		{LevelQ, 0, 0b011010101011111},
+		{LevelH, 0, 0b001011010001001},
+	}
+
+	for _, tc := range formats {
+		if got := formatInfo(tc.level, tc.mask); got != tc.expected {
+			t.Errorf("format %d/%d: expected %015b, got %015b", tc.level, tc.mask, tc.expected, got)
+		}
+
+		level, mask, ok := decodeFormatInfo(tc.expected ^ 0b101) // two bit errors
+		if !ok || level != tc.level || mask != tc.mask {
+			t.Errorf("decodeFormatInfo(%015b) = %d, %d, %v", tc.expected, level, mask, ok)
+		}
+	}
+
+	if got := versionInfo(7); got != 0b000111110010010100 {
+		t.Errorf("version 7: got %018b", got)
+	}
+
+	if version, ok := decodeVersionInfo(versionInfo(23) ^ 0b1000000001); !ok || version != 23 {
+		t.Errorf("decodeVersionInfo = %d, %v", version, ok)
+	}
+}
+
+func TestCapacityTables(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		version  int
+		level    Level
+		expected int
+	}{
+		{1, LevelL, 19}, {1, LevelM, 16}, {1, LevelQ, 13}, {1, LevelH, 9},
+		{5, LevelQ, 62}, {10, LevelM, 216}, {15, LevelL, 523}, {20, LevelQ, 485},
+		{27, LevelM, 1128}, {27, LevelH, 628}, {40, LevelL, 2956}, {40, LevelH, 1276},
+	}
+
+	for _, tc := range testCases {
+		if got := dataCodewords(tc.version, tc.level); got != tc.expected {
+			t.Errorf("version %d level %d: expected %d data codewords, got %d", tc.version, tc.level, tc.expected, got)
+		}
+	}
+
+	if got := alignmentPositions(32); !slices.Equal(got, []int{6, 34, 60, 86, 112, 138}) {
+		t.Errorf("unexpected version 32 alignment positions %v", got)
+	}
+
+	if got := alignmentPositions(40); !slices.Equal(got, []int{6, 30, 58, 86, 114, 142, 170}) {
+		t.Errorf("unexpected version 40 alignment positions %v", got)
+	}
+}
+
+const testVietQR = "00020101021238560010A0000007270126000697040701128888123456780208QRIBFTTA" +
+	"53037045405500005802VN62230819Thanh toan don hang6304DF40"
+
+func TestDecode_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		segments []testSegment
+		version  int
+		level    Level
+		expected string
+	}{
+		{
+			name:     "alphanumeric version 1",
+			segments: []testSegment{{modeAlphanumeric, "HELLO WORLD"}},
+			version:  1,
+			level:    LevelQ,
+			expected: "HELLO WORLD",
+		},
+		{
+			name:     "numeric",
+			segments: []testSegment{{modeNumeric, "0123456789012"}},
+			version:  1,
+			level:    LevelH,
+			expected: "0123456789012",
+		},
+		{
+			name:     "vietqr byte mode",
+			segments: []testSegment{{modeByte, testVietQR}},
+			version:  8,
+			level:    LevelM,
+			expected: testVietQR,
+		},
+		{
+			name:     "utf-8",
+			segments: []testSegment{{modeByte, "Thanh toán đơn hàng"}},
+			version:  3,
+			level:    LevelL,
+			expected: "Thanh toán đơn hàng",
+		},
+		{
+			name:     "mixed segments",
+			segments: []testSegment{{modeAlphanumeric, "ORDER-"}, {modeNumeric, "20261019"}, {modeByte, "/ok"}},
+			version:  2,
+			level:    LevelM,
+			expected: "ORDER-20261019/ok",
+		},
+		{
+			name:     "large version",
+			segments: []testSegment{{modeByte, strings.Repeat("aliniex ", 60)}},
+			version:  25,
+			level:    LevelQ,
+			expected: strings.Repeat("aliniex ", 60),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			for mask := range 8 {
+				img := renderSymbol(encodeSymbol(t, tc.segments, tc.version, tc.level, mask), 3)
+
+				got, err := Decode(img)
+				if err != nil {
+					t.Fatalf("mask %d: Decode returned error: %v", mask, err)
+				}
+
+				if got != tc.expected {
+					t.Fatalf("mask %d: expected %q, got %q", mask, tc.expected, got)
+				}
+			}
+		})
+	}
+}
+
+func rotate90(src image.Image) *image.Gray {
+	bounds := src.Bounds()
+	dst := image.NewGray(image.Rect(0, 0, bounds.Dy(), bounds.Dx()))
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			dst.Set(bounds.Max.Y-1-y, x, src.At(x, y))
+		}
+	}
+
+	return dst
+}
+
+// warp renders src through a perspective transform that moves its corners
+// to the given positions on a white canvas.
+func warp(t *testing.T, src *image.Gray, corners [4]point, size int) *image.Gray {
+	t.Helper()
+
+	width, height := float64(src.Bounds().Dx()), float64(src.Bounds().Dy())
+
+	// Map destination pixels back to source pixels.
+	inverse, ok := newHomography(corners, [4]point{{0, 0}, {width, 0}, {0, height}, {width, height}})
+	if !ok {
+		t.Fatal("degenerate warp")
+	}
+
+	dst := image.NewGray(image.Rect(0, 0, size, size))
+
+	for y := range size {
+		for x := range size {
+			p := inverse.apply(float64(x)+0.5, float64(y)+0.5)
+			dst.Pix[y*dst.Stride+x] = 255
+
+			if p.x >= 0 && p.y >= 0 && p.x < width && p.y < height {
+				dst.Pix[y*dst.Stride+x] = src.GrayAt(int(p.x), int(p.y)).Y
+			}
+		}
+	}
+
+	return dst
+}
+
+func TestDecode_Transformations(t *testing.T) {
+	t.Parallel()
+
+	grid := encodeSymbol(t, []testSegment{{modeByte, testVietQR}}, 8, LevelM, 2)
+
+	rotated := image.Image(renderSymbol(grid, 4))
+	for range 3 {
+		rotated = rotate90(rotated)
+	}
+
+	// Embed the symbol off-centre in a larger, coloured canvas.
+	canvas := image.NewRGBA(image.Rect(0, 0, 600, 500))
+	draw.Draw(canvas, canvas.Bounds(), image.NewUniform(image.White), image.Point{}, draw.Src)
+	draw.Draw(canvas, image.Rect(120, 60, 600, 500), renderSymbol(grid, 3), image.Point{}, draw.Src)
+
+	damaged := slices.Clone(grid)
+	for y := range damaged {
+		damaged[y] = slices.Clone(grid[y])
+	}
+
+	for _, pos := range [][2]int{{20, 20}, {21, 20}, {30, 12}, {12, 33}, {25, 40}} {
+		damaged[pos[1]][pos[0]] = !damaged[pos[1]][pos[0]]
+	}
+
+	testCases := []struct {
+		name string
+		img  image.Image
+	}{
+		{name: "rotated", img: rotated},
+		{name: "fractional scale", img: renderSymbol(grid, 2.6)},
+		{name: "embedded", img: canvas},
+		{name: "damaged modules", img: renderSymbol(damaged, 3)},
+		{
+			name: "perspective",
+			img:  warp(t, renderSymbol(grid, 5), [4]point{{30, 20}, {520, 60}, {10, 540}, {560, 500}}, 600),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Decode(tc.img)
+			if err != nil {
+				t.Fatalf("Decode returned error: %v", err)
+			}
+
+			if got != testVietQR {
+				t.Errorf("unexpected content %q", got)
+			}
+		})
+	}
+}
+
+func TestDecodeReader(t *testing.T) {
+	t.Parallel()
+
+	img := renderSymbol(encodeSymbol(t, []testSegment{{modeByte, testVietQR}}, 8, LevelM, 5), 4)
+
+	var pngData, jpegData bytes.Buffer
+
+	if err := png.Encode(&pngData, img); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+
+	if err := jpeg.Encode(&jpegData, img, &jpeg.Options{Quality: 75}); err != nil {
+		t.Fatalf("encode jpeg: %v", err)
+	}
+
+	for name, data := range map[string][]byte{"png": pngData.Bytes(), "jpeg": jpegData.Bytes()} {
+		got, err := DecodeReader(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: DecodeReader returned error: %v", name, err)
+		}
+
+		if got != testVietQR {
+			t.Errorf("%s: unexpected content %q", name, got)
+		}
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	t.Parallel()
+
+	blank := image.NewGray(image.Rect(0, 0, 100, 100))
+	draw.Draw(blank, blank.Bounds(), image.NewUniform(image.White), image.Point{}, draw.Src)
+
+	if _, err := Decode(blank); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	if _, err := DecodeReader(strings.NewReader("not an image")); !errors.Is(err, ErrImage) {
+		t.Errorf("expected ErrImage, got %v", err)
+	}
+
+	grid := encodeSymbol(t, []testSegment{{modeByte, "hello"}}, 2, LevelL, 0)
+	for y := 9; y < 17; y++ {
+		for x := 9; x < 25; x++ {
+			grid[y][x] = !grid[y][x]
+		}
+	}
+
+	if _, err := Decode(renderSymbol(grid, 3)); !errors.Is(err, ErrDecode) {
+		t.Errorf("expected ErrDecode for a damaged symbol, got %v", err)
+	}
+}
@@ -0,0 +1,310 @@
+package qrcode
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// This file holds a minimal QR encoder used to build test fixtures.
+
+type testSegment struct {
+	mode int
+	text string
+}
+
+type bitWriter struct {
+	bits []bool
+}
+
+func (w *bitWriter) write(value, n int) {
+	for i := n - 1; i >= 0; i-- {
+		w.bits = append(w.bits, value>>i&1 == 1)
+	}
+}
+
+func (w *bitWriter) bytes() []byte {
+	out := make([]byte, (len(w.bits)+7)/8)
+
+	for i, bit := range w.bits {
+		if bit {
+			out[i/8] |= 1 << (7 - i%8)
+		}
+	}
+
+	return out
+}
+
+func encodeData(t *testing.T, segments []testSegment, version int, level Level) []byte {
+	t.Helper()
+
+	capacity := dataCodewords(version, level) * 8
+	writer := &bitWriter{bits: nil}
+
+	for _, segment := range segments {
+		writer.write(segment.mode, 4)
+
+		switch segment.mode {
+		case modeNumeric:
+			writer.write(len(segment.text), characterCountBits(modeNumeric, version))
+
+			for i := 0; i < len(segment.text); i += 3 {
+				group := segment.text[i:min(i+3, len(segment.text))]
+				value := 0
+
+				for _, digit := range group {
+					value = value*10 + int(digit-'0')
+				}
+
+				writer.write(value, [...]int{0, 4, 7, 10}[len(group)])
+			}
+		case modeAlphanumeric:
+			writer.write(len(segment.text), characterCountBits(modeAlphanumeric, version))
+
+			index := func(c byte) int {
+				for i := range len(alphanumericCharset) {
+					if alphanumericCharset[i] == c {
+						return i
+					}
+				}
+
+				t.Fatalf("%q is not alphanumeric", c)
+
+				return 0
+			}
+
+			for i := 0; i+1 < len(segment.text); i += 2 {
+				writer.write(index(segment.text[i])*45+index(segment.text[i+1]), 11)
+			}
+
+			if len(segment.text)%2 == 1 {
+				writer.write(index(segment.text[len(segment.text)-1]), 6)
+			}
+		default:
+			writer.write(len(segment.text), characterCountBits(modeByte, version))
+
+			for i := range len(segment.text) {
+				writer.write(int(segment.text[i]), 8)
+			}
+		}
+	}
+
+	if len(writer.bits) > capacity {
+		t.Fatalf("data does not fit version %d level %d", version, level)
+	}
+
+	writer.write(0, min(4, capacity-len(writer.bits)))
+	writer.write(0, (8-len(writer.bits)%8)%8)
+
+	data := writer.bytes()
+	for pad := 0; len(data) < capacity/8; pad++ {
+		data = append(data, [...]byte{0xEC, 0x11}[pad%2])
+	}
+
+	return data
+}
+
+// reedSolomonEncode returns the error correction codewords for data.
+func reedSolomonEncode(field *gf256, data []byte, numEC int) []byte {
+	generator := []byte{1}
+
+	for i := range numEC {
+		next := make([]byte, len(generator)+1)
+
+		for j, coefficient := range generator {
+			next[j] ^= coefficient
+			next[j+1] ^= field.mul(coefficient, field.pow(i))
+		}
+
+		generator = next
+	}
+
+	remainder := make([]byte, numEC)
+
+	for _, b := range data {
+		factor := b ^ remainder[0]
+		copy(remainder, remainder[1:])
+		remainder[numEC-1] = 0
+
+		for i := range numEC {
+			remainder[i] ^= field.mul(generator[i+1], factor)
+		}
+	}
+
+	return remainder
+}
+
+func interleave(data []byte, version int, level Level) []byte {
+	field := newGF256()
+	numBlocks := numECBlocks(level, version)
+	ecLen := eccCodewordsPerBlock(level, version)
+	raw := rawCodewords(version)
+	numShort := numBlocks - raw%numBlocks
+	shortData := raw/numBlocks - ecLen
+
+	var (
+		dataBlocks [][]byte
+		ecBlocks   [][]byte
+	)
+
+	for i, offset := 0, 0; i < numBlocks; i++ {
+		length := shortData
+		if i >= numShort {
+			length++
+		}
+
+		block := data[offset : offset+length]
+		offset += length
+
+		dataBlocks = append(dataBlocks, block)
+		ecBlocks = append(ecBlocks, reedSolomonEncode(field, block, ecLen))
+	}
+
+	out := make([]byte, 0, raw)
+
+	for i := range shortData + 1 {
+		for _, block := range dataBlocks {
+			if i < len(block) {
+				out = append(out, block[i])
+			}
+		}
+	}
+
+	for i := range ecLen {
+		for _, block := range ecBlocks {
+			out = append(out, block[i])
+		}
+	}
+
+	return out
+}
+
+// encodeSymbol builds the module grid for segments.
+func encodeSymbol(t *testing.T, segments []testSegment, version int, level Level, mask int) [][]bool {
+	t.Helper()
+
+	size := dimensionForVersion(version)
+	grid := make([][]bool, size)
+
+	for y := range grid {
+		grid[y] = make([]bool, size)
+	}
+
+	drawFinder := func(cx, cy int) {
+		for dy := -4; dy <= 4; dy++ {
+			for dx := -4; dx <= 4; dx++ {
+				x, y := cx+dx, cy+dy
+				if x < 0 || y < 0 || x >= size || y >= size {
+					continue
+				}
+
+				ring := max(abs(dx), abs(dy))
+				grid[y][x] = ring != 2 && ring != 4
+			}
+		}
+	}
+
+	drawFinder(3, 3)
+	drawFinder(size-4, 3)
+	drawFinder(3, size-4)
+
+	for i := 8; i < size-8; i++ {
+		grid[6][i] = i%2 == 0
+		grid[i][6] = i%2 == 0
+	}
+
+	positions := alignmentPositions(version)
+	last := len(positions) - 1
+
+	for i, cy := range positions {
+		for j, cx := range positions {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					grid[cy+dy][cx+dx] = max(abs(dx), abs(dy)) != 1
+				}
+			}
+		}
+	}
+
+	format := formatInfo(level, mask)
+	first, second := formatInfoPositions(size)
+
+	for i := range 15 {
+		bit := format>>(14-i)&1 == 1
+		grid[first[i][1]][first[i][0]] = bit
+		grid[second[i][1]][second[i][0]] = bit
+	}
+
+	grid[size-8][8] = true
+
+	if version >= versionInfoMinVersion {
+		info := versionInfo(version)
+		topRight, bottomLeft := versionInfoPositions(size)
+
+		for i := range 18 {
+			bit := info>>(17-i)&1 == 1
+			grid[topRight[i][1]][topRight[i][0]] = bit
+			grid[bottomLeft[i][1]][bottomLeft[i][0]] = bit
+		}
+	}
+
+	codewords := interleave(encodeData(t, segments, version, level), version, level)
+	function := functionModules(version)
+	bitIndex := 0
+
+	for right := size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+
+		for vert := range size {
+			for j := range 2 {
+				x := right - j
+
+				y := vert
+				if (right+1)&2 == 0 {
+					y = size - 1 - vert
+				}
+
+				if function[y][x] {
+					continue
+				}
+
+				bit := false
+				if bitIndex < len(codewords)*8 {
+					bit = codewords[bitIndex>>3]>>(7-bitIndex&7)&1 == 1
+					bitIndex++
+				}
+
+				grid[y][x] = bit != masked(mask, x, y)
+			}
+		}
+	}
+
+	return grid
+}
+
+// renderSymbol draws grid with a four-module quiet zone at scale pixels
+// per module.
+func renderSymbol(grid [][]bool, scale float64) *image.Gray {
+	const quiet = 4
+
+	size := len(grid)
+	pixels := int(float64(size+2*quiet) * scale)
+	img := image.NewGray(image.Rect(0, 0, pixels, pixels))
+
+	for y := range pixels {
+		for x := range pixels {
+			mx, my := int(float64(x)/scale)-quiet, int(float64(y)/scale)-quiet
+			dark := mx >= 0 && my >= 0 && mx < size && my < size && grid[my][mx]
+
+			img.SetGray(x, y, color.Gray{Y: map[bool]uint8{true: 0, false: 255}[dark]})
+		}
+	}
+
+	return img
+}
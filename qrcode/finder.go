@@ -0,0 +1,267 @@
+package qrcode
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	// maxFinderCandidates bounds the combinations tried when more than three
+	// finder-like patterns are found.
+	maxFinderCandidates = 8
+
+	finderModules = 7
+)
+
+type point struct {
+	x, y float64
+}
+
+func (p point) sub(q point) point { return point{p.x - q.x, p.y - q.y} }
+
+func (p point) add(q point) point { return point{p.x + q.x, p.y + q.y} }
+
+func (p point) scale(f float64) point { return point{p.x * f, p.y * f} }
+
+func distance(p, q point) float64 {
+	return math.Hypot(p.x-q.x, p.y-q.y)
+}
+
+type finderPattern struct {
+	center     point
+	moduleSize float64
+	count      int
+}
+
+// finderRatioOK checks five run lengths against the 1:1:3:1:1 finder ratio.
+func finderRatioOK(runs [5]int) bool {
+	total := 0
+
+	for _, run := range runs {
+		if run == 0 {
+			return false
+		}
+
+		total += run
+	}
+
+	if total < finderModules {
+		return false
+	}
+
+	module := float64(total) / finderModules
+	tolerance := module / 2
+
+	return math.Abs(module-float64(runs[0])) < tolerance &&
+		math.Abs(module-float64(runs[1])) < tolerance &&
+		math.Abs(3*module-float64(runs[2])) < 3*tolerance &&
+		math.Abs(module-float64(runs[3])) < tolerance &&
+		math.Abs(module-float64(runs[4])) < tolerance
+}
+
+// crossCheck measures the finder pattern along a line through pixel (x, y)
+// in direction (dx, dy). It returns the continuous coordinate of the core
+// centre along that axis and the total pattern length in pixels.
+func (b *bitmap) crossCheck(x, y, dx, dy int) (float64, int, bool) {
+	var (
+		runs     [5]int
+		coreBack int
+	)
+
+	// Walk backwards from the centre: dark core, light ring, dark ring.
+	cx, cy := x, y
+	for state := 2; state >= 0; state-- {
+		wantDark := state != 1
+
+		for inBounds(b, cx, cy) && b.at(cx, cy) == wantDark {
+			runs[state]++
+			cx, cy = cx-dx, cy-dy
+		}
+	}
+
+	coreBack = runs[2]
+
+	cx, cy = x+dx, y+dy
+	for state := 2; state < 5; state++ {
+		wantDark := state != 3
+
+		for inBounds(b, cx, cy) && b.at(cx, cy) == wantDark {
+			runs[state]++
+			cx, cy = cx+dx, cy+dy
+		}
+	}
+
+	if !finderRatioOK(runs) {
+		return 0, 0, false
+	}
+
+	origin := x*dx + y*dy
+	coreForward := runs[2] - coreBack
+	center := float64(origin) + float64(coreForward-coreBack+2)/2
+
+	return center, runs[0] + runs[1] + runs[2] + runs[3] + runs[4], true
+}
+
+func inBounds(b *bitmap, x, y int) bool {
+	return x >= 0 && y >= 0 && x < b.width && y < b.height
+}
+
+// findFinderPatterns scans rows for 1:1:3:1:1 runs and confirms each hit
+// vertically and horizontally.
+func (b *bitmap) findFinderPatterns() []finderPattern {
+	var patterns []finderPattern
+
+	for y := range b.height {
+		runs := b.rowRuns(y)
+
+		for i := 0; i+5 <= len(runs); i++ {
+			if !runs[i].dark {
+				continue
+			}
+
+			var lengths [5]int
+			for j := range lengths {
+				lengths[j] = runs[i+j].length
+			}
+
+			if !finderRatioOK(lengths) {
+				continue
+			}
+
+			core := runs[i+2]
+			cx := core.start + core.length/2
+
+			patterns = b.confirmFinder(patterns, cx, y)
+		}
+	}
+
+	return patterns
+}
+
+func (b *bitmap) confirmFinder(patterns []finderPattern, cx, y int) []finderPattern {
+	centerY, vertical, ok := b.crossCheck(cx, y, 0, 1)
+	if !ok {
+		return patterns
+	}
+
+	centerX, horizontal, ok := b.crossCheck(cx, int(centerY), 1, 0)
+	if !ok {
+		return patterns
+	}
+
+	center := point{centerX, centerY}
+	moduleSize := float64(vertical+horizontal) / (2 * finderModules)
+
+	for i := range patterns {
+		existing := &patterns[i]
+		if distance(existing.center, center) <= 2*moduleSize &&
+			math.Abs(existing.moduleSize-moduleSize) <= existing.moduleSize {
+			weight := float64(existing.count)
+			existing.center = existing.center.scale(weight).add(center).scale(1 / (weight + 1))
+			existing.moduleSize = (existing.moduleSize*weight + moduleSize) / (weight + 1)
+			existing.count++
+
+			return patterns
+		}
+	}
+
+	return append(patterns, finderPattern{center: center, moduleSize: moduleSize, count: 1})
+}
+
+type run struct {
+	start  int
+	length int
+	dark   bool
+}
+
+func (b *bitmap) rowRuns(y int) []run {
+	var runs []run
+
+	for x := range b.width {
+		dark := b.at(x, y)
+		if len(runs) > 0 && runs[len(runs)-1].dark == dark {
+			runs[len(runs)-1].length++
+
+			continue
+		}
+
+		runs = append(runs, run{start: x, length: 1, dark: dark})
+	}
+
+	return runs
+}
+
+// finderTriple is an ordered set of finder patterns.
+type finderTriple struct {
+	topLeft, topRight, bottomLeft finderPattern
+	score                         float64
+}
+
+// finderTriples returns plausible top-left/top-right/bottom-left
+// arrangements, best first.
+func finderTriples(patterns []finderPattern) []finderTriple {
+	sort.SliceStable(patterns, func(i, j int) bool { return patterns[i].count > patterns[j].count })
+
+	if len(patterns) > maxFinderCandidates {
+		patterns = patterns[:maxFinderCandidates]
+	}
+
+	var triples []finderTriple
+
+	for i := range patterns {
+		for j := i + 1; j < len(patterns); j++ {
+			for k := j + 1; k < len(patterns); k++ {
+				if triple, ok := orderFinders(patterns[i], patterns[j], patterns[k]); ok {
+					triples = append(triples, triple)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(triples, func(i, j int) bool { return triples[i].score < triples[j].score })
+
+	return triples
+}
+
+// orderFinders identifies the corner finder as the one opposite the longest
+// side and orients the other two clockwise.
+func orderFinders(a, b, c finderPattern) (finderTriple, bool) {
+	sizes := []float64{a.moduleSize, b.moduleSize, c.moduleSize}
+	sort.Float64s(sizes)
+
+	if sizes[2] > 2*sizes[0] {
+		return finderTriple{}, false //nolint:exhaustruct // rejected
+	}
+
+	ab, bc, ca := distance(a.center, b.center), distance(b.center, c.center), distance(c.center, a.center)
+
+	var corner, p, q finderPattern
+
+	switch {
+	case bc >= ab && bc >= ca:
+		corner, p, q = a, b, c
+	case ca >= ab && ca >= bc:
+		corner, p, q = b, c, a
+	default:
+		corner, p, q = c, a, b
+	}
+
+	u, v := p.center.sub(corner.center), q.center.sub(corner.center)
+	if u.x*v.y-u.y*v.x < 0 {
+		p, q = q, p
+	}
+
+	sideA, sideB := distance(corner.center, p.center), distance(corner.center, q.center)
+	hypotenuse := distance(p.center, q.center)
+	module := (a.moduleSize + b.moduleSize + c.moduleSize) / 3
+
+	if sideA < 8*module || sideB < 8*module {
+		return finderTriple{}, false //nolint:exhaustruct // rejected
+	}
+
+	// Penalise unequal sides and a corner angle far from 90 degrees.
+	score := math.Abs(sideA-sideB)/math.Max(sideA, sideB) +
+		math.Abs(hypotenuse-math.Hypot(sideA, sideB))/hypotenuse
+
+	return finderTriple{topLeft: corner, topRight: p, bottomLeft: q, score: score}, true
+}
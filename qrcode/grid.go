@@ -0,0 +1,173 @@
+package qrcode
+
+import "math"
+
+// alignmentSearchModules is the search radius, in modules, around the
+// predicted bottom-right alignment pattern.
+const alignmentSearchModules = 4
+
+// homography maps module coordinates to image coordinates.
+type homography [8]float64
+
+func (h homography) apply(x, y float64) point {
+	w := h[6]*x + h[7]*y + 1
+
+	return point{(h[0]*x + h[1]*y + h[2]) / w, (h[3]*x + h[4]*y + h[5]) / w}
+}
+
+// newHomography solves for the projective transform mapping each src point
+// to the matching dst point.
+func newHomography(src, dst [4]point) (homography, bool) {
+	var matrix [8][9]float64
+
+	for i := range 4 {
+		x, y, u, v := src[i].x, src[i].y, dst[i].x, dst[i].y
+		matrix[2*i] = [9]float64{x, y, 1, 0, 0, 0, -x * u, -y * u, u}
+		matrix[2*i+1] = [9]float64{0, 0, 0, x, y, 1, -x * v, -y * v, v}
+	}
+
+	for col := range 8 {
+		pivot := col
+		for row := col + 1; row < 8; row++ {
+			if math.Abs(matrix[row][col]) > math.Abs(matrix[pivot][col]) {
+				pivot = row
+			}
+		}
+
+		if math.Abs(matrix[pivot][col]) < 1e-12 {
+			return homography{}, false
+		}
+
+		matrix[col], matrix[pivot] = matrix[pivot], matrix[col]
+
+		for row := range 8 {
+			if row == col {
+				continue
+			}
+
+			factor := matrix[row][col] / matrix[col][col]
+			for k := col; k < 9; k++ {
+				matrix[row][k] -= factor * matrix[col][k]
+			}
+		}
+	}
+
+	var h homography
+	for i := range h {
+		h[i] = matrix[i][8] / matrix[i][i]
+	}
+
+	return h, true
+}
+
+// findAlignment searches near the predicted position for the bottom-right
+// alignment pattern: a dark module inside a light ring inside a dark ring.
+// stepX and stepY are the image offsets of one module along each axis.
+func (b *bitmap) findAlignment(predicted, stepX, stepY point, moduleSize float64) (point, bool) {
+	radius := int(math.Ceil(alignmentSearchModules * moduleSize))
+	best := 0
+
+	var sum point
+
+	count := 0
+
+	for dy := -radius; dy <= radius; dy++ {
+		for dx := -radius; dx <= radius; dx++ {
+			center := point{predicted.x + float64(dx), predicted.y + float64(dy)}
+
+			score := b.alignmentScore(center, stepX, stepY)
+			if score < best {
+				continue
+			}
+
+			if score > best {
+				best, sum, count = score, point{0, 0}, 0
+			}
+
+			sum = sum.add(center)
+			count++
+		}
+	}
+
+	// Allow a couple of misread modules out of 25.
+	const minScore = 23
+	if best < minScore {
+		return point{}, false
+	}
+
+	return sum.scale(1 / float64(count)), true
+}
+
+func (b *bitmap) alignmentScore(center, stepX, stepY point) int {
+	score := 0
+
+	for j := -2; j <= 2; j++ {
+		for i := -2; i <= 2; i++ {
+			p := center.add(stepX.scale(float64(i))).add(stepY.scale(float64(j)))
+			ring := max(abs(i), abs(j))
+
+			if b.at(int(math.Floor(p.x)), int(math.Floor(p.y))) == (ring != 1) {
+				score++
+			}
+		}
+	}
+
+	return score
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
+
+// transforms returns candidate module-to-image transforms for a symbol of
+// the given size, the alignment-refined one first.
+func (b *bitmap) transforms(triple finderTriple, size int) []homography {
+	tl, tr, bl := triple.topLeft.center, triple.topRight.center, triple.bottomLeft.center
+	far := float64(size) - 3.5
+
+	src := [4]point{{3.5, 3.5}, {far, 3.5}, {3.5, far}, {far, far}}
+	dst := [4]point{tl, tr, bl, tr.add(bl).sub(tl)}
+
+	affine, ok := newHomography(src, dst)
+	if !ok {
+		return nil
+	}
+
+	var result []homography
+
+	if size > dimensionForVersion(1) {
+		span := float64(size) - 7
+		stepX, stepY := tr.sub(tl).scale(1/span), bl.sub(tl).scale(1/span)
+		moduleSize := (triple.topLeft.moduleSize + triple.topRight.moduleSize + triple.bottomLeft.moduleSize) / 3
+		near := float64(size) - 6.5
+
+		if alignment, found := b.findAlignment(affine.apply(near, near), stepX, stepY, moduleSize); found {
+			src[3], dst[3] = point{near, near}, alignment
+			if refined, ok := newHomography(src, dst); ok {
+				result = append(result, refined)
+			}
+		}
+	}
+
+	return append(result, affine)
+}
+
+// sample reads the module grid through transform h.
+func (b *bitmap) sample(h homography, size int) [][]bool {
+	grid := make([][]bool, size)
+
+	for y := range size {
+		grid[y] = make([]bool, size)
+
+		for x := range size {
+			p := h.apply(float64(x)+0.5, float64(y)+0.5)
+			grid[y][x] = b.at(int(math.Floor(p.x)), int(math.Floor(p.y)))
+		}
+	}
+
+	return grid
+}
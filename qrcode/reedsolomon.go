@@ -0,0 +1,202 @@
+package qrcode
+
+import "errors"
+
+var errTooManyErrors = errors.New("too many errors to correct")
+
+// gf256 holds exp/log tables for GF(2^8) with the QR primitive polynomial
+// x^8 + x^4 + x^3 + x^2 + 1.
+type gf256 struct {
+	exp [512]byte
+	log [256]byte
+}
+
+func newGF256() *gf256 {
+	const primitive = 0x11D
+
+	field := new(gf256)
+	value := 1
+
+	for i := range 255 {
+		field.exp[i] = byte(value)
+		field.log[value] = byte(i)
+
+		value <<= 1
+		if value >= 256 {
+			value ^= primitive
+		}
+	}
+
+	for i := 255; i < len(field.exp); i++ {
+		field.exp[i] = field.exp[i-255]
+	}
+
+	return field
+}
+
+func (f *gf256) mul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+
+	return f.exp[int(f.log[a])+int(f.log[b])]
+}
+
+func (f *gf256) div(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+
+	return f.exp[int(f.log[a])+255-int(f.log[b])]
+}
+
+func (f *gf256) inv(a byte) byte {
+	return f.exp[255-int(f.log[a])]
+}
+
+// pow returns alpha^n.
+func (f *gf256) pow(n int) byte {
+	return f.exp[n%255]
+}
+
+// evalPoly evaluates a polynomial stored highest degree first, as codewords
+// are, at x.
+func (f *gf256) evalPoly(poly []byte, x byte) byte {
+	var result byte
+
+	for _, coefficient := range poly {
+		result = f.mul(result, x) ^ coefficient
+	}
+
+	return result
+}
+
+// correct fixes errors in place in a block of data followed by numEC error
+// correction codewords, returning the number of corrected codewords.
+func (f *gf256) correct(block []byte, numEC int) (int, error) {
+	syndromes := make([]byte, numEC)
+	clean := true
+
+	for i := range syndromes {
+		syndromes[i] = f.evalPoly(block, f.pow(i))
+		if syndromes[i] != 0 {
+			clean = false
+		}
+	}
+
+	if clean {
+		return 0, nil
+	}
+
+	locator := f.berlekampMassey(syndromes)
+	errorCount := len(locator) - 1
+
+	if errorCount*2 > numEC {
+		return 0, errTooManyErrors
+	}
+
+	// Chien search: locator coefficients are stored lowest degree first.
+	var positions []int
+
+	for degree := range len(block) {
+		xInv := f.inv(f.pow(degree))
+
+		var sum byte
+		for i := len(locator) - 1; i >= 0; i-- {
+			sum = f.mul(sum, xInv) ^ locator[i]
+		}
+
+		if sum == 0 {
+			positions = append(positions, degree)
+		}
+	}
+
+	if len(positions) != errorCount {
+		return 0, errTooManyErrors
+	}
+
+	// Forney: omega = (S * locator) mod x^numEC, lowest degree first.
+	omega := make([]byte, numEC)
+
+	for i := range numEC {
+		for j := 0; j <= i && j < len(locator); j++ {
+			omega[i] ^= f.mul(syndromes[i-j], locator[j])
+		}
+	}
+
+	for _, degree := range positions {
+		x := f.pow(degree)
+		xInv := f.inv(x)
+
+		var numerator, denominator byte
+		for i := len(omega) - 1; i >= 0; i-- {
+			numerator = f.mul(numerator, xInv) ^ omega[i]
+		}
+
+		// The formal derivative keeps only odd-degree terms.
+		for i := 1; i < len(locator); i += 2 {
+			denominator ^= f.mul(locator[i], f.pow((i-1)*int(f.log[xInv])))
+		}
+
+		if denominator == 0 {
+			return 0, errTooManyErrors
+		}
+
+		block[len(block)-1-degree] ^= f.mul(x, f.div(numerator, denominator))
+	}
+
+	for i := range numEC {
+		if f.evalPoly(block, f.pow(i)) != 0 {
+			return 0, errTooManyErrors
+		}
+	}
+
+	return errorCount, nil
+}
+
+// berlekampMassey returns the error locator polynomial, lowest degree first.
+func (f *gf256) berlekampMassey(syndromes []byte) []byte {
+	locator := []byte{1}
+	previous := []byte{1}
+	length := 0
+	shift := 1
+	lastDiscrepancy := byte(1)
+
+	for n := range syndromes {
+		discrepancy := syndromes[n]
+		for i := 1; i <= length && i < len(locator); i++ {
+			discrepancy ^= f.mul(locator[i], syndromes[n-i])
+		}
+
+		if discrepancy == 0 {
+			shift++
+
+			continue
+		}
+
+		scale := f.div(discrepancy, lastDiscrepancy)
+		updated := make([]byte, max(len(locator), len(previous)+shift))
+		copy(updated, locator)
+
+		for i, coefficient := range previous {
+			updated[i+shift] ^= f.mul(scale, coefficient)
+		}
+
+		if 2*length <= n {
+			previous = locator
+			length = n + 1 - length
+			lastDiscrepancy = discrepancy
+			shift = 1
+		} else {
+			shift++
+		}
+
+		locator = updated
+	}
+
+	for len(locator) > 1 && locator[len(locator)-1] == 0 {
+		locator = locator[:len(locator)-1]
+	}
+
+	return locator
+}
@@ -0,0 +1,236 @@
+package qrcode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Segment mode indicators.
+const (
+	modeTerminator       = 0x0
+	modeNumeric          = 0x1
+	modeAlphanumeric     = 0x2
+	modeStructuredAppend = 0x3
+	modeByte             = 0x4
+	modeFNC1First        = 0x5
+	modeECI              = 0x7
+	modeKanji            = 0x8
+	modeFNC1Second       = 0x9
+)
+
+const alphanumericCharset = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+var (
+	errTruncated       = errors.New("truncated data segment")
+	errUnsupportedMode = errors.New("unsupported segment mode")
+	errInvalidSegment  = errors.New("invalid segment data")
+)
+
+type bitReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *bitReader) remaining() int {
+	return len(r.data)*8 - r.pos
+}
+
+func (r *bitReader) read(n int) (int, error) {
+	if n > r.remaining() {
+		return 0, errTruncated
+	}
+
+	value := 0
+
+	for range n {
+		value = value<<1 | int(r.data[r.pos>>3]>>(7-r.pos&7)&1)
+		r.pos++
+	}
+
+	return value, nil
+}
+
+// characterCountBits returns the width of the count field for a mode.
+func characterCountBits(mode, version int) int {
+	group := 0
+
+	switch {
+	case version >= 27:
+		group = 2
+	case version >= 10:
+		group = 1
+	}
+
+	switch mode {
+	case modeNumeric:
+		return [...]int{10, 12, 14}[group]
+	case modeAlphanumeric:
+		return [...]int{9, 11, 13}[group]
+	case modeByte:
+		return [...]int{8, 16, 16}[group]
+	default:
+		return [...]int{8, 10, 12}[group]
+	}
+}
+
+// decodeSegments decodes the data codewords into text. Byte segments are
+// taken as UTF-8 when valid and ISO 8859-1 otherwise.
+func decodeSegments(data []byte, version int) (string, error) {
+	reader := &bitReader{data: data, pos: 0}
+
+	var text strings.Builder
+
+	for reader.remaining() >= 4 {
+		mode, _ := reader.read(4)
+
+		var err error
+
+		switch mode {
+		case modeTerminator:
+			return text.String(), nil
+		case modeNumeric:
+			err = readNumeric(reader, version, &text)
+		case modeAlphanumeric:
+			err = readAlphanumeric(reader, version, &text)
+		case modeByte:
+			err = readByteSegment(reader, version, &text)
+		case modeECI:
+			err = skipECI(reader)
+		case modeStructuredAppend:
+			_, err = reader.read(16)
+		case modeFNC1First:
+		case modeFNC1Second:
+			_, err = reader.read(8)
+		case modeKanji:
+			err = fmt.Errorf("%w: kanji", errUnsupportedMode)
+		default:
+			err = fmt.Errorf("%w: %#x", errUnsupportedMode, mode)
+		}
+
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return text.String(), nil
+}
+
+func readNumeric(reader *bitReader, version int, text *strings.Builder) error {
+	count, err := reader.read(characterCountBits(modeNumeric, version))
+	if err != nil {
+		return err
+	}
+
+	for count > 0 {
+		digits := min(count, 3)
+		width := [...]int{0, 4, 7, 10}[digits]
+
+		value, err := reader.read(width)
+		if err != nil {
+			return err
+		}
+
+		formatted := fmt.Sprintf("%0*d", digits, value)
+		if len(formatted) != digits {
+			return fmt.Errorf("%w: numeric group %d", errInvalidSegment, value)
+		}
+
+		text.WriteString(formatted)
+
+		count -= digits
+	}
+
+	return nil
+}
+
+func readAlphanumeric(reader *bitReader, version int, text *strings.Builder) error {
+	count, err := reader.read(characterCountBits(modeAlphanumeric, version))
+	if err != nil {
+		return err
+	}
+
+	charset := len(alphanumericCharset)
+
+	for ; count >= 2; count -= 2 {
+		value, err := reader.read(11)
+		if err != nil {
+			return err
+		}
+
+		if value >= charset*charset {
+			return fmt.Errorf("%w: alphanumeric pair %d", errInvalidSegment, value)
+		}
+
+		text.WriteByte(alphanumericCharset[value/charset])
+		text.WriteByte(alphanumericCharset[value%charset])
+	}
+
+	if count == 1 {
+		value, err := reader.read(6)
+		if err != nil {
+			return err
+		}
+
+		if value >= charset {
+			return fmt.Errorf("%w: alphanumeric character %d", errInvalidSegment, value)
+		}
+
+		text.WriteByte(alphanumericCharset[value])
+	}
+
+	return nil
+}
+
+func readByteSegment(reader *bitReader, version int, text *strings.Builder) error {
+	count, err := reader.read(characterCountBits(modeByte, version))
+	if err != nil {
+		return err
+	}
+
+	raw := make([]byte, count)
+
+	for i := range raw {
+		value, err := reader.read(8)
+		if err != nil {
+			return err
+		}
+
+		raw[i] = byte(value)
+	}
+
+	if utf8.Valid(raw) {
+		text.Write(raw)
+
+		return nil
+	}
+
+	for _, b := range raw {
+		text.WriteRune(rune(b))
+	}
+
+	return nil
+}
+
+// skipECI consumes an ECI designator. Content is interpreted by
+// readByteSegment regardless of the declared character set.
+func skipECI(reader *bitReader) error {
+	first, err := reader.read(8)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case first&0x80 == 0:
+		return nil
+	case first&0xC0 == 0x80:
+		_, err = reader.read(8)
+	case first&0xE0 == 0xC0:
+		_, err = reader.read(16)
+	default:
+		err = fmt.Errorf("%w: eci designator %#x", errInvalidSegment, first)
+	}
+
+	return err
+}
@@ -0,0 +1,313 @@
+package qrcode
+
+import "math/bits"
+
+const (
+	minVersion = 1
+	maxVersion = 40
+
+	// versionInfoMinVersion is the first version carrying version info.
+	versionInfoMinVersion = 7
+
+	formatInfoMask = 0x5412
+	formatInfoPoly = 0x537
+	versionPoly    = 0x1F25
+
+	// maxInfoDistance is the largest Hamming distance accepted when matching
+	// format or version information against valid codes.
+	maxInfoDistance = 3
+)
+
+// Level is the error correction level.
+type Level int
+
+const (
+	LevelL Level = iota
+	LevelM
+	LevelQ
+	LevelH
+)
+
+// formatBits maps a level to its two-bit format information value.
+func (l Level) formatBits() int {
+	return [...]int{1, 0, 3, 2}[l]
+}
+
+func levelFromFormatBits(value int) Level {
+	return [...]Level{LevelM, LevelL, LevelH, LevelQ}[value]
+}
+
+// eccCodewordsPerBlock and numECBlocks are indexed by level, then version.
+func eccCodewordsPerBlock(level Level, version int) int {
+	table := [4][41]int{
+		{
+			-1, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28,
+			28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30,
+		},
+		{
+			-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26,
+			26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28,
+		},
+		{
+			-1, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30,
+			28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30,
+		},
+		{
+			-1, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28,
+			30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30,
+		},
+	}
+
+	return table[level][version]
+}
+
+func numECBlocks(level Level, version int) int {
+	table := [4][41]int{
+		{
+			-1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8,
+			8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25,
+		},
+		{
+			-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16,
+			17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49,
+		},
+		{
+			-1, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20,
+			23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68,
+		},
+		{
+			-1, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25,
+			25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81,
+		},
+	}
+
+	return table[level][version]
+}
+
+func dimensionForVersion(version int) int {
+	return 17 + 4*version
+}
+
+// rawCodewords is the number of codewords that fit in the data area.
+func rawCodewords(version int) int {
+	modules := (16*version+128)*version + 64
+
+	if version >= 2 {
+		numAlign := version/7 + 2
+		modules -= (25*numAlign-10)*numAlign - 55
+
+		if version >= versionInfoMinVersion {
+			modules -= 36
+		}
+	}
+
+	return modules / 8
+}
+
+// dataCodewords is the number of data codewords for a version and level.
+func dataCodewords(version int, level Level) int {
+	return rawCodewords(version) - eccCodewordsPerBlock(level, version)*numECBlocks(level, version)
+}
+
+// alignmentPositions returns the row/column centres of alignment patterns.
+func alignmentPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+
+	numAlign := version/7 + 2
+
+	step := (version*4 + numAlign*2 + 1) / (numAlign*2 - 2) * 2
+	if version == 32 {
+		step = 26
+	}
+
+	positions := make([]int, numAlign)
+	positions[0] = 6
+
+	for i, pos := numAlign-1, dimensionForVersion(version)-7; i >= 1; i, pos = i-1, pos-step {
+		positions[i] = pos
+	}
+
+	return positions
+}
+
+// bchRemainder returns value<<degree(poly) mod poly over GF(2).
+func bchRemainder(value, poly int) int {
+	degree := bits.Len(uint(poly)) - 1
+	value <<= degree
+
+	for bits.Len(uint(value)) > degree {
+		value ^= poly << (bits.Len(uint(value)) - 1 - degree)
+	}
+
+	return value
+}
+
+// formatInfo returns the masked 15-bit format information.
+func formatInfo(level Level, mask int) int {
+	data := level.formatBits()<<3 | mask
+
+	return (data<<10 | bchRemainder(data, formatInfoPoly)) ^ formatInfoMask
+}
+
+// versionInfo returns the 18-bit version information.
+func versionInfo(version int) int {
+	return version<<12 | bchRemainder(version, versionPoly)
+}
+
+// decodeFormatInfo returns the level and mask closest to raw.
+func decodeFormatInfo(raw int) (Level, int, bool) {
+	best, bestDistance := 0, maxInfoDistance+1
+
+	for data := range 32 {
+		code := (data<<10 | bchRemainder(data, formatInfoPoly)) ^ formatInfoMask
+		if distance := bits.OnesCount(uint(code ^ raw)); distance < bestDistance {
+			best, bestDistance = data, distance
+		}
+	}
+
+	if bestDistance > maxInfoDistance {
+		return 0, 0, false
+	}
+
+	return levelFromFormatBits(best >> 3), best & 7, true
+}
+
+// decodeVersionInfo returns the version closest to raw.
+func decodeVersionInfo(raw int) (int, bool) {
+	best, bestDistance := 0, maxInfoDistance+1
+
+	for version := versionInfoMinVersion; version <= maxVersion; version++ {
+		if distance := bits.OnesCount(uint(versionInfo(version) ^ raw)); distance < bestDistance {
+			best, bestDistance = version, distance
+		}
+	}
+
+	return best, bestDistance <= maxInfoDistance
+}
+
+// masked reports whether mask pattern inverts the module at column x, row y.
+func masked(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+// functionModules marks finder, timing, alignment, format and version
+// modules, which carry no data.
+func functionModules(version int) [][]bool {
+	size := dimensionForVersion(version)
+
+	grid := make([][]bool, size)
+	for y := range grid {
+		grid[y] = make([]bool, size)
+	}
+
+	fill := func(x0, y0, width, height int) {
+		for y := y0; y < y0+height; y++ {
+			for x := x0; x < x0+width; x++ {
+				grid[y][x] = true
+			}
+		}
+	}
+
+	// Finder patterns with separators and format information.
+	fill(0, 0, 9, 9)
+	fill(size-8, 0, 8, 9)
+	fill(0, size-8, 9, 8)
+
+	// Timing patterns.
+	fill(6, 0, 1, size)
+	fill(0, 6, size, 1)
+
+	positions := alignmentPositions(version)
+	last := len(positions) - 1
+
+	for i, cy := range positions {
+		for j, cx := range positions {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+
+			fill(cx-2, cy-2, 5, 5)
+		}
+	}
+
+	if version >= versionInfoMinVersion {
+		fill(size-11, 0, 3, 6)
+		fill(0, size-11, 6, 3)
+	}
+
+	return grid
+}
+
+// formatInfoPositions lists the two copies of the format information, most
+// significant bit first, as (x, y) module coordinates.
+func formatInfoPositions(size int) ([15][2]int, [15][2]int) {
+	var first, second [15][2]int
+
+	index := 0
+
+	for x := range 6 {
+		first[index] = [2]int{x, 8}
+		index++
+	}
+
+	first[6] = [2]int{7, 8}
+	first[7] = [2]int{8, 8}
+	first[8] = [2]int{8, 7}
+	index = 9
+
+	for y := 5; y >= 0; y-- {
+		first[index] = [2]int{8, y}
+		index++
+	}
+
+	index = 0
+
+	for y := size - 1; y >= size-7; y-- {
+		second[index] = [2]int{8, y}
+		index++
+	}
+
+	for x := size - 8; x < size; x++ {
+		second[index] = [2]int{x, 8}
+		index++
+	}
+
+	return first, second
+}
+
+// versionInfoPositions lists the two copies of the version information,
+// most significant bit first, as (x, y) module coordinates.
+func versionInfoPositions(size int) ([18][2]int, [18][2]int) {
+	var topRight, bottomLeft [18][2]int
+
+	index := 0
+
+	for j := 5; j >= 0; j-- {
+		for i := size - 9; i >= size-11; i-- {
+			topRight[index] = [2]int{i, j}
+			bottomLeft[index] = [2]int{j, i}
+			index++
+		}
+	}
+
+	return topRight, bottomLeft
+}
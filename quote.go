@@ -0,0 +1,229 @@
+package goaliniex
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	defaultQuoteTTL         = time.Minute
+	defaultQuoteMaxPriceAge = 15 * time.Minute
+
+	tokenAmountPrecision = 1e6
+)
+
+var (
+	ErrNoPrice      = errors.New("no recent price")
+	ErrInvalidQuote = errors.New("invalid quote request")
+)
+
+// FeeSchedule describes how Aliniex charges an order in one fiat currency.
+// Rates are fractions of the fiat amount; fixed fees are in fiat.
+type FeeSchedule struct {
+	SystemRate      float64
+	SystemFixed     float64
+	ProcessingRate  float64
+	ProcessingFixed float64
+}
+
+// Fees returns the fees charged on fiatAmount.
+func (s FeeSchedule) Fees(fiatAmount float64) Fees {
+	return Fees{
+		SystemFee:     fiatAmount*s.SystemRate + s.SystemFixed,
+		ProcessingFee: fiatAmount*s.ProcessingRate + s.ProcessingFixed,
+	}
+}
+
+// OrderType is the direction of an order.
+type OrderType string
+
+const (
+	// OrderTypeSell pays out fiat to a bank account for crypto.
+	OrderTypeSell OrderType = "SELL"
+	// OrderTypeBuy delivers crypto to a wallet for fiat.
+	OrderTypeBuy OrderType = "BUY"
+)
+
+type QuoteRequest struct {
+	// Type defaults to OrderTypeSell.
+	Type         OrderType
+	Currency     Currency
+	FiatCurrency FiatCurrency
+	FiatAmount   float64
+}
+
+// Quote is an estimate of an order. For a sell order TokenAmount is what the
+// user sends to receive FiatAmount, with fees added; for a buy order it is
+// what the user receives for paying FiatAmount, with fees deducted.
+type Quote struct {
+	Type         OrderType
+	Currency     Currency
+	FiatCurrency FiatCurrency
+	FiatAmount   float64
+	// Price is the fiat price of one token.
+	Price       float64
+	TokenAmount float64
+	Fees        Fees
+	// PriceObservedAt is when Price was seen.
+	PriceObservedAt time.Time
+	// ExpiresAt is the end of the quote's TTL, or when its price goes
+	// stale if that is sooner.
+	ExpiresAt time.Time
+}
+
+type QuoteEstimatorOptions struct {
+	// TTL is how long a quote is valid. Defaults to 1m.
+	TTL time.Duration
+	// MaxPriceAge is the oldest price observation used. Defaults to 15m.
+	MaxPriceAge time.Duration
+	// Fees are the schedules per fiat currency; others are quoted without
+	// fees.
+	Fees map[FiatCurrency]FeeSchedule
+	// Now overrides the clock.
+	Now func() time.Time
+}
+
+func (o *QuoteEstimatorOptions) withDefaults() QuoteEstimatorOptions {
+	opts := QuoteEstimatorOptions{
+		TTL:         defaultQuoteTTL,
+		MaxPriceAge: defaultQuoteMaxPriceAge,
+		Fees:        map[FiatCurrency]FeeSchedule{},
+		Now:         time.Now,
+	}
+
+	if o == nil {
+		return opts
+	}
+
+	if o.TTL > 0 {
+		opts.TTL = o.TTL
+	}
+
+	if o.MaxPriceAge > 0 {
+		opts.MaxPriceAge = o.MaxPriceAge
+	}
+
+	for fiat, schedule := range o.Fees {
+		opts.Fees[fiat] = schedule
+	}
+
+	if o.Now != nil {
+		opts.Now = o.Now
+	}
+
+	return opts
+}
+
+type pricePair struct {
+	currency Currency
+	fiat     FiatCurrency
+}
+
+type priceObservation struct {
+	price float64
+	at    time.Time
+}
+
+// QuoteEstimator estimates orders locally from recently observed prices,
+// such as TokenTransfer.Price of the orders you create, and the configured
+// fee schedules. It is safe for concurrent use.
+type QuoteEstimator struct {
+	opts QuoteEstimatorOptions
+
+	mu     sync.Mutex
+	prices map[pricePair]priceObservation
+}
+
+func NewQuoteEstimator(opts *QuoteEstimatorOptions) *QuoteEstimator {
+	return &QuoteEstimator{
+		opts:   opts.withDefaults(),
+		mu:     sync.Mutex{},
+		prices: map[pricePair]priceObservation{},
+	}
+}
+
+// ObservePrice records the fiat price of one token seen at at. Older
+// observations than the latest for the pair are ignored.
+func (e *QuoteEstimator) ObservePrice(currency Currency, fiat FiatCurrency, price float64, at time.Time) {
+	if price <= 0 {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	pair := pricePair{currency: currency, fiat: fiat}
+	if latest, ok := e.prices[pair]; ok && at.Before(latest.at) {
+		return
+	}
+
+	e.prices[pair] = priceObservation{price: price, at: at}
+}
+
+// ObserveTransfer records the price of an order's token transfer, dated by
+// the order's CreatedAt.
+func (e *QuoteEstimator) ObserveTransfer(fiat FiatCurrency, transfer TokenTransfer, createdAt string) error {
+	at, err := ParseOrderTime(createdAt)
+	if err != nil {
+		return err
+	}
+
+	e.ObservePrice(transfer.Currency, fiat, transfer.Price, at)
+
+	return nil
+}
+
+// Quote estimates req from the latest price for its pair. It fails with
+// ErrNoPrice when that price is missing or older than MaxPriceAge.
+func (e *QuoteEstimator) Quote(req QuoteRequest) (*Quote, error) {
+	if req.Type == "" {
+		req.Type = OrderTypeSell
+	}
+
+	if req.FiatAmount <= 0 || (req.Type != OrderTypeSell && req.Type != OrderTypeBuy) {
+		return nil, fmt.Errorf("%w: %s of %v %s", ErrInvalidQuote, req.Type, req.FiatAmount, req.FiatCurrency)
+	}
+
+	now := e.opts.Now()
+
+	e.mu.Lock()
+	observation, ok := e.prices[pricePair{currency: req.Currency, fiat: req.FiatCurrency}]
+	e.mu.Unlock()
+
+	staleAt := observation.at.Add(e.opts.MaxPriceAge)
+	if !ok || !now.Before(staleAt) {
+		return nil, fmt.Errorf("%w: %s/%s", ErrNoPrice, req.Currency, req.FiatCurrency)
+	}
+
+	fees := e.opts.Fees[req.FiatCurrency].Fees(req.FiatAmount)
+	totalFees := fees.SystemFee + fees.ProcessingFee
+
+	fiatForTokens := req.FiatAmount + totalFees
+	if req.Type == OrderTypeBuy {
+		fiatForTokens = req.FiatAmount - totalFees
+	}
+
+	if fiatForTokens <= 0 {
+		return nil, fmt.Errorf("%w: fees of %v exceed %v %s", ErrInvalidQuote, totalFees, req.FiatAmount, req.FiatCurrency)
+	}
+
+	expiresAt := now.Add(e.opts.TTL)
+	if staleAt.Before(expiresAt) {
+		expiresAt = staleAt
+	}
+
+	return &Quote{
+		Type:            req.Type,
+		Currency:        req.Currency,
+		FiatCurrency:    req.FiatCurrency,
+		FiatAmount:      req.FiatAmount,
+		Price:           observation.price,
+		TokenAmount:     math.Round(fiatForTokens/observation.price*tokenAmountPrecision) / tokenAmountPrecision,
+		Fees:            fees,
+		PriceObservedAt: observation.at,
+		ExpiresAt:       expiresAt,
+	}, nil
+}
@@ -0,0 +1,165 @@
+package goaliniex_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andyle182810/goaliniex"
+)
+
+func newEstimator(now *time.Time) *goaliniex.QuoteEstimator {
+	return goaliniex.NewQuoteEstimator(&goaliniex.QuoteEstimatorOptions{
+		TTL:         time.Minute,
+		MaxPriceAge: 10 * time.Minute,
+		Fees: map[goaliniex.FiatCurrency]goaliniex.FeeSchedule{
+			goaliniex.FiatCurrencyVND: {SystemRate: 0.01, SystemFixed: 0, ProcessingRate: 0, ProcessingFixed: 10000},
+		},
+		Now: func() time.Time { return *now },
+	})
+}
+
+func quoteRequest(
+	orderType goaliniex.OrderType, currency goaliniex.Currency, fiat goaliniex.FiatCurrency, amount float64,
+) goaliniex.QuoteRequest {
+	return goaliniex.QuoteRequest{Type: orderType, Currency: currency, FiatCurrency: fiat, FiatAmount: amount}
+}
+
+func TestQuoteEstimator_Quote(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	estimator := newEstimator(&now)
+	estimator.ObservePrice(goaliniex.CurrencyUSDT, goaliniex.FiatCurrencyVND, 26000, now.Add(-time.Minute))
+	estimator.ObservePrice(goaliniex.CurrencyUSDT, goaliniex.FiatCurrencyVND, 99999, now.Add(-time.Hour))
+
+	testCases := []struct {
+		orderType goaliniex.OrderType
+		tokens    float64
+	}{
+		// 1,000,000 VND plus 10,000 system and 10,000 processing fees.
+		{orderType: "", tokens: 39.230769},
+		{orderType: goaliniex.OrderTypeBuy, tokens: 37.692308},
+	}
+
+	for _, tc := range testCases {
+		quote, err := estimator.Quote(goaliniex.QuoteRequest{
+			Type:         tc.orderType,
+			Currency:     goaliniex.CurrencyUSDT,
+			FiatCurrency: goaliniex.FiatCurrencyVND,
+			FiatAmount:   1000000,
+		})
+		if err != nil {
+			t.Fatalf("Quote(%q): %v", tc.orderType, err)
+		}
+
+		if quote.Price != 26000 || quote.TokenAmount != tc.tokens {
+			t.Errorf("Quote(%q): price %v, tokens %v; want 26000, %v", tc.orderType, quote.Price, quote.TokenAmount, tc.tokens)
+		}
+
+		if quote.Fees.SystemFee != 10000 || quote.Fees.ProcessingFee != 10000 {
+			t.Errorf("Quote(%q): fees %+v", tc.orderType, quote.Fees)
+		}
+
+		if !quote.ExpiresAt.Equal(now.Add(time.Minute)) {
+			t.Errorf("Quote(%q): ExpiresAt %v", tc.orderType, quote.ExpiresAt)
+		}
+	}
+
+	// The price goes stale before the TTL ends.
+	now = now.Add(8*time.Minute + 30*time.Second)
+
+	//nolint:exhaustruct // sell by default
+	quote, err := estimator.Quote(goaliniex.QuoteRequest{
+		Currency: goaliniex.CurrencyUSDT, FiatCurrency: goaliniex.FiatCurrencyVND, FiatAmount: 1000000,
+	})
+	if err != nil || !quote.ExpiresAt.Equal(quote.PriceObservedAt.Add(10*time.Minute)) {
+		t.Errorf("quote near staleness = %+v, %v", quote, err)
+	}
+}
+
+func TestQuoteEstimator_Errors(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	estimator := newEstimator(&now)
+	estimator.ObservePrice(goaliniex.CurrencyUSDT, goaliniex.FiatCurrencyVND, 26000, now.Add(-11*time.Minute))
+	estimator.ObservePrice(goaliniex.CurrencyUSDT, goaliniex.FiatCurrencyPHP, 58, now)
+
+	vnd, php := goaliniex.FiatCurrencyVND, goaliniex.FiatCurrencyPHP
+	usdt, btc := goaliniex.CurrencyUSDT, goaliniex.CurrencyBTC
+
+	testCases := []struct {
+		name string
+		req  goaliniex.QuoteRequest
+		want error
+	}{
+		{"stale price", quoteRequest("", usdt, vnd, 1), goaliniex.ErrNoPrice},
+		{"unobserved pair", quoteRequest("", btc, php, 1), goaliniex.ErrNoPrice},
+		{"zero amount", quoteRequest("", usdt, php, 0), goaliniex.ErrInvalidQuote},
+		{"unknown type", quoteRequest("SWAP", usdt, php, 1), goaliniex.ErrInvalidQuote},
+	}
+
+	for _, tc := range testCases {
+		if _, err := estimator.Quote(tc.req); !errors.Is(err, tc.want) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, err)
+		}
+	}
+
+	now = now.Add(9 * time.Minute)
+	estimator.ObservePrice(goaliniex.CurrencyUSDT, goaliniex.FiatCurrencyVND, 26000, now)
+
+	_, err := estimator.Quote(goaliniex.QuoteRequest{
+		Type: goaliniex.OrderTypeBuy, Currency: goaliniex.CurrencyUSDT, FiatCurrency: goaliniex.FiatCurrencyVND, FiatAmount: 10000,
+	})
+	if !errors.Is(err, goaliniex.ErrInvalidQuote) {
+		t.Errorf("buy below fees: expected ErrInvalidQuote, got %v", err)
+	}
+}
+
+// TestQuoteEstimator_MatchesServer feeds the estimator from a created order
+// and checks the next quote matches the server's pricing.
+func TestQuoteEstimator_MatchesServer(t *testing.T) {
+	t.Parallel()
+
+	_, client := newFakeServer(t)
+	estimator := goaliniex.NewQuoteEstimator(nil)
+
+	created, err := client.CreateOrder(context.Background(), &goaliniex.CreateOrderRequest{
+		Currency:          goaliniex.CurrencyUSDT,
+		FiatAmount:        260000,
+		FiatCurrency:      goaliniex.FiatCurrencyVND,
+		BankCode:          "970436",
+		BankAccountNumber: "0123456789",
+		ExternalOrderID:   "ORDER-1",
+		WebhookSecretKey:  "",
+		UserEmail:         "user@example.com",
+		UserKYCVerified:   true,
+		Content:           "",
+		ExtendInfo:        nil,
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	err = estimator.ObserveTransfer(goaliniex.FiatCurrencyVND, created.Data.TokenTransfer, created.Data.CreatedAt)
+	if err != nil {
+		t.Fatalf("ObserveTransfer: %v", err)
+	}
+
+	quote, err := estimator.Quote(goaliniex.QuoteRequest{
+		Type: goaliniex.OrderTypeSell, Currency: goaliniex.CurrencyUSDT, FiatCurrency: goaliniex.FiatCurrencyVND, FiatAmount: 260000,
+	})
+	if err != nil {
+		t.Fatalf("Quote: %v", err)
+	}
+
+	if quote.TokenAmount != created.Data.TokenTransfer.Amount {
+		t.Errorf("quoted %v tokens, server charged %v", quote.TokenAmount, created.Data.TokenTransfer.Amount)
+	}
+
+	if err := estimator.ObserveTransfer(goaliniex.FiatCurrencyVND, created.Data.TokenTransfer, "later"); err == nil {
+		t.Error("expected an error for an unparseable CreatedAt")
+	}
+}
@@ -9,6 +9,7 @@ type request struct {
 	Method      string
 	Endpoint    string
 	Params      any
+	Operation   Operation
 	SigningData []byte
 	Header      http.Header
 	Body        io.Reader
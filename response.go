@@ -5,4 +5,7 @@ type Response[T any] struct {
 	Message   string `json:"message"`
 	Data      *T     `json:"data"`
 	ErrorCode int    `json:"errorCode"`
+	// Unknown reports fields that differ from the SDK structs. It is set
+	// only with WithStrictDecoding.
+	Unknown *SchemaDrift `json:"-"`
 }
@@ -0,0 +1,75 @@
+package goaliniex
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// ResponseMeta holds HTTP details of a call. Pass one in with
+// WithResponseMeta; calls that make several requests, such as
+// CreateOrderFromQR, leave the details of the last one.
+type ResponseMeta struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Header     http.Header
+	// RequestID is the server's request ID header, empty when not sent.
+	RequestID string
+	Latency   time.Duration
+	// Attempts is the number of times the request was sent.
+	Attempts int
+	// RawBody is the undecoded response body.
+	RawBody []byte
+}
+
+// requestIDHeaders are checked in order for the server's request ID.
+func requestIDHeaders() []string {
+	return []string{"X-Request-Id", "X-Correlation-Id", "X-Trace-Id", "Request-Id"}
+}
+
+type responseMetaKey struct{}
+
+// WithResponseMeta returns a context that makes calls record their HTTP
+// details in meta. The meta is filled even when the call fails, as long as
+// a request was sent.
+func WithResponseMeta(ctx context.Context, meta *ResponseMeta) context.Context {
+	return context.WithValue(ctx, responseMetaKey{}, meta)
+}
+
+// ResponseMetaFrom returns the meta passed to WithResponseMeta, or nil.
+func ResponseMetaFrom(ctx context.Context) *ResponseMeta {
+	meta, _ := ctx.Value(responseMetaKey{}).(*ResponseMeta)
+
+	return meta
+}
+
+func (m *ResponseMeta) record(req *http.Request, resp *http.Response, body []byte, started time.Time, attempts int) {
+	if m == nil {
+		return
+	}
+
+	m.Method = req.Method
+	m.URL = req.URL.String()
+	m.Latency = time.Since(started)
+	m.Attempts = attempts
+	m.StatusCode = 0
+	m.Header = nil
+	m.RequestID = ""
+	m.RawBody = body
+
+	if resp == nil {
+		return
+	}
+
+	m.StatusCode = resp.StatusCode
+	m.Header = resp.Header.Clone()
+
+	for _, name := range requestIDHeaders() {
+		if id := resp.Header.Get(name); id != "" {
+			m.RequestID = id
+
+			break
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package goaliniex_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/andyle182810/goaliniex"
+)
+
+const metaBalanceBody = `{"success":true,"message":"Success","errorCode":0,` +
+	`"data":{"balance":3,"currency":"USDT","signature":""}}`
+
+func TestWithResponseMeta_Success(t *testing.T) {
+	t.Parallel()
+
+	client, err := newTestClientWithMock(&recordingHTTPClient{
+		response: func() *http.Response {
+			resp := mockResponse(http.StatusOK, metaBalanceBody)
+			resp.Header.Set("X-Request-Id", "req-123")
+			resp.Header.Set("Content-Type", "application/json")
+
+			return resp
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	var meta goaliniex.ResponseMeta
+
+	ctx := goaliniex.WithResponseMeta(context.Background(), &meta)
+
+	resp, err := client.GetWalletBalance(ctx, &goaliniex.GetWalletBalanceRequest{Currency: goaliniex.CurrencyUSDT})
+	if err != nil {
+		t.Fatalf("GetWalletBalance: %v", err)
+	}
+
+	if resp.Data.Balance != 3 {
+		t.Errorf("balance = %v, want 3", resp.Data.Balance)
+	}
+
+	if meta.StatusCode != http.StatusOK || meta.RequestID != "req-123" || meta.Attempts != 1 {
+		t.Errorf("unexpected meta: %+v", meta)
+	}
+
+	if meta.Method != http.MethodPost || meta.URL != "https://sandbox.alixpay.com/api/v2/wallet/balance" {
+		t.Errorf("request = %s %s", meta.Method, meta.URL)
+	}
+
+	if meta.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("header = %v", meta.Header)
+	}
+
+	if string(meta.RawBody) != metaBalanceBody {
+		t.Errorf("raw body = %s", meta.RawBody)
+	}
+
+	if meta.Latency < 0 {
+		t.Errorf("latency = %v", meta.Latency)
+	}
+}
+
+func TestWithResponseMeta_HTTPError(t *testing.T) {
+	t.Parallel()
+
+	client, err := newTestClientWithMock(&recordingHTTPClient{
+		response: func() *http.Response {
+			resp := mockResponse(http.StatusBadGateway, "upstream down")
+			resp.Header.Set("X-Correlation-Id", "corr-9")
+
+			return resp
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	var meta goaliniex.ResponseMeta
+
+	ctx := goaliniex.WithResponseMeta(context.Background(), &meta)
+
+	_, err = client.GetOrderDetails(ctx, &goaliniex.GetOrderDetailsRequest{ExternalOrderID: "ORDER-1"})
+	if !errors.Is(err, goaliniex.ErrUnexpectedStatus) {
+		t.Fatalf("expected ErrUnexpectedStatus, got %v", err)
+	}
+
+	if meta.StatusCode != http.StatusBadGateway || meta.RequestID != "corr-9" || string(meta.RawBody) != "upstream down" {
+		t.Errorf("unexpected meta: %+v", meta)
+	}
+}
+
+func TestWithResponseMeta_TransportError(t *testing.T) {
+	t.Parallel()
+
+	client, err := newTestClientWithMock(&mockHTTPClient{response: nil, err: errConnectionRefused})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	var meta goaliniex.ResponseMeta
+
+	ctx := goaliniex.WithResponseMeta(context.Background(), &meta)
+
+	_, err = client.GetQRCodeInfo(ctx, &goaliniex.GetQRCodeInfoRequest{QRContent: "000201"})
+	if !errors.Is(err, goaliniex.ErrHTTPFailure) {
+		t.Fatalf("expected ErrHTTPFailure, got %v", err)
+	}
+
+	if meta.StatusCode != 0 || meta.Attempts != 1 || meta.Method != http.MethodGet {
+		t.Errorf("unexpected meta: %+v", meta)
+	}
+
+	if !strings.Contains(meta.URL, "qrContent=000201") {
+		t.Errorf("url = %s", meta.URL)
+	}
+}
@@ -0,0 +1,154 @@
+package goaliniex
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// SchemaDrift records where a response body differs from the SDK struct it
+// decodes into. Paths are dotted JSON keys from the envelope root, with []
+// marking array elements, e.g. "data.tokenTransfer.network".
+type SchemaDrift struct {
+	Endpoint string
+	// Unknown maps each field the SDK does not model to its raw JSON.
+	Unknown map[string]json.RawMessage
+	// Missing lists modelled fields absent from the body. Fields tagged
+	// omitempty are optional and never reported.
+	Missing []string
+}
+
+// Empty reports whether the body matched the struct exactly.
+func (d *SchemaDrift) Empty() bool {
+	return d == nil || (len(d.Unknown) == 0 && len(d.Missing) == 0)
+}
+
+// UnknownPaths returns the paths of Unknown, sorted.
+func (d *SchemaDrift) UnknownPaths() []string {
+	if d == nil {
+		return nil
+	}
+
+	paths := make([]string, 0, len(d.Unknown))
+	for path := range d.Unknown {
+		paths = append(paths, path)
+	}
+
+	slices.Sort(paths)
+
+	return paths
+}
+
+// DetectSchemaDrift compares a JSON body from endpoint against T, usually a
+// Response[...], without failing on differences. Only malformed JSON is an
+// error. Types with their own UnmarshalJSON and map fields are not
+// descended into.
+func DetectSchemaDrift[T any](endpoint string, body []byte) (*SchemaDrift, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	drift := &SchemaDrift{Endpoint: endpoint, Unknown: map[string]json.RawMessage{}, Missing: nil}
+	compareSchema(value, reflect.TypeFor[T](), "", drift)
+	slices.Sort(drift.Missing)
+
+	return drift, nil
+}
+
+func compareSchema(value any, typ reflect.Type, path string, drift *SchemaDrift) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	if value == nil || reflect.PointerTo(typ).Implements(reflect.TypeFor[json.Unmarshaler]()) {
+		return
+	}
+
+	switch typ.Kind() { //nolint:exhaustive // only composite kinds have fields
+	case reflect.Struct:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return
+		}
+
+		fields := schemaFields(typ)
+
+		for key, fieldValue := range object {
+			field, known := fields[key]
+			if !known {
+				raw, _ := json.Marshal(fieldValue) //nolint:errchkjson // decoded JSON
+				drift.Unknown[joinSchemaPath(path, key)] = raw
+
+				continue
+			}
+
+			compareSchema(fieldValue, field.typ, joinSchemaPath(path, key), drift)
+		}
+
+		for name, field := range fields {
+			if _, present := object[name]; !present && !field.optional {
+				drift.Missing = append(drift.Missing, joinSchemaPath(path, name))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := value.([]any)
+		if !ok {
+			return
+		}
+
+		for _, item := range items {
+			compareSchema(item, typ.Elem(), path+"[]", drift)
+		}
+	}
+}
+
+func joinSchemaPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+type schemaField struct {
+	typ      reflect.Type
+	optional bool
+}
+
+// schemaFields returns the JSON fields of a struct by key, flattening
+// embedded structs as encoding/json does.
+func schemaFields(typ reflect.Type) map[string]schemaField {
+	fields := map[string]schemaField{}
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		tag := field.Tag.Get("json")
+
+		if tag == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			maps.Copy(fields, schemaFields(field.Type))
+
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		fields[name] = schemaField{typ: field.Type, optional: slices.Contains(strings.Split(options, ","), "omitempty")}
+	}
+
+	return fields
+}
@@ -0,0 +1,49 @@
+package goaliniex
+
+import "fmt"
+
+// SecretMask replaces the secret key in payloads returned by ExplainSignature.
+const SecretMask = "********"
+
+// Operation identifies a signed API call.
+type Operation string
+
+const (
+	OperationCreateOrder       Operation = "create-order"
+	OperationGetOrderDetails   Operation = "get-order-details"
+	OperationSubmitKyc         Operation = "submit-kyc"
+	OperationGetKycInformation Operation = "get-kyc-information"
+	OperationGetUserKyc        Operation = "get-user-kyc"
+	OperationGetWalletBalance  Operation = "get-wallet-balance"
+)
+
+// Operations returns every operation that carries a request signature.
+func Operations() []Operation {
+	return []Operation{
+		OperationCreateOrder,
+		OperationGetOrderDetails,
+		OperationSubmitKyc,
+		OperationGetKycInformation,
+		OperationGetUserKyc,
+		OperationGetWalletBalance,
+	}
+}
+
+// SignaturePayload builds the canonical pipe-delimited string that is signed
+// for op, following RequestSignatureSpec(op). The request is normally the
+// pointer type accepted by the matching Client method, e.g.
+// *CreateOrderRequest for OperationCreateOrder.
+func SignaturePayload(op Operation, partnerCode, secretKey string, req any) (string, error) {
+	spec, ok := RequestSignatureSpec(op)
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownOperation, op)
+	}
+
+	return spec.Payload(partnerCode, secretKey, req)
+}
+
+// ExplainSignature returns the payload the client would sign for op, with the
+// secret key replaced by SecretMask so it can be shared with support.
+func (c *Client) ExplainSignature(op Operation, req any) (string, error) {
+	return SignaturePayload(op, c.partnerCode, SecretMask, req)
+}
@@ -0,0 +1,226 @@
+package goaliniex
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	// FieldPartnerCode resolves to the client's partner code rather than a
+	// request or response field.
+	FieldPartnerCode = "partnerCode"
+
+	signatureSeparator = "|"
+	signatureJSONField = "signature"
+)
+
+// SecretPlacement controls where the secret key is placed in a payload.
+type SecretPlacement int
+
+const (
+	SecretLast SecretPlacement = iota
+	SecretFirst
+	SecretNone
+)
+
+// SignatureSpec declares how a signing payload is assembled: the JSON field
+// names in order, joined by "|", with the secret key added per Secret.
+//
+// Values are formatted by FormatSignatureValue: floats use the shortest
+// decimal form without exponent, bools are "true"/"false", string enums use
+// their underlying value and missing or nil values are empty.
+type SignatureSpec struct {
+	Fields []string
+	Secret SecretPlacement
+}
+
+// RequestSignatureSpec returns the payload spec used to sign requests for op.
+func RequestSignatureSpec(op Operation) (SignatureSpec, bool) {
+	switch op {
+	case OperationCreateOrder:
+		return SignatureSpec{
+			Fields: []string{
+				FieldPartnerCode,
+				"externalOrderId",
+				"currency",
+				"fiatAmount",
+				"bankCode",
+				"bankAccountNumber",
+				"content",
+				"userEmail",
+			},
+			Secret: SecretLast,
+		}, true
+	case OperationGetOrderDetails:
+		return SignatureSpec{Fields: []string{FieldPartnerCode, "externalOrderId"}, Secret: SecretLast}, true
+	case OperationSubmitKyc:
+		return SignatureSpec{Fields: []string{FieldPartnerCode, "userEmail", "nationality"}, Secret: SecretLast}, true
+	case OperationGetKycInformation, OperationGetUserKyc:
+		return SignatureSpec{Fields: []string{FieldPartnerCode, "userEmail"}, Secret: SecretLast}, true
+	case OperationGetWalletBalance:
+		return SignatureSpec{Fields: []string{FieldPartnerCode, "currency"}, Secret: SecretLast}, true
+	default:
+		return SignatureSpec{Fields: nil, Secret: SecretLast}, false
+	}
+}
+
+// ResponseSignatureSpec returns the payload spec assumed for the signature
+// in the data of op's response. Aliniex does not document response
+// signatures, so these field orders are unconfirmed and only used when
+// WithResponsePublicKey opts in to verification. Operations whose responses
+// are unsigned report false.
+func ResponseSignatureSpec(op Operation) (SignatureSpec, bool) {
+	switch op {
+	case OperationCreateOrder, OperationGetOrderDetails:
+		return SignatureSpec{
+			Fields: []string{FieldPartnerCode, "externalOrderId", "fiatAmount", "status"},
+			Secret: SecretLast,
+		}, true
+	case OperationSubmitKyc:
+		return SignatureSpec{Fields: []string{FieldPartnerCode, "id", "kycStatus"}, Secret: SecretLast}, true
+	case OperationGetWalletBalance:
+		return SignatureSpec{Fields: []string{FieldPartnerCode, "currency", "balance"}, Secret: SecretLast}, true
+	case OperationGetKycInformation, OperationGetUserKyc:
+		return SignatureSpec{Fields: nil, Secret: SecretLast}, false
+	default:
+		return SignatureSpec{Fields: nil, Secret: SecretLast}, false
+	}
+}
+
+// Payload assembles the signing payload from v, which may be a struct (or
+// pointer to one) with json tags, or a map[string]any as decoded from JSON.
+// A struct that lacks one of the spec's fields is reported as
+// ErrOperationRequestType; a map simply yields an empty value.
+func (s SignatureSpec) Payload(partnerCode, secretKey string, v any) (string, error) {
+	values, err := signingValues(v)
+	if err != nil {
+		return "", err
+	}
+
+	parts := make([]string, 0, len(s.Fields)+1)
+
+	if s.Secret == SecretFirst {
+		parts = append(parts, secretKey)
+	}
+
+	for _, field := range s.Fields {
+		if field == FieldPartnerCode {
+			parts = append(parts, partnerCode)
+
+			continue
+		}
+
+		value, ok := values.lookup(field)
+		if !ok && values.strict {
+			return "", fmt.Errorf("%w: %T has no field %q", ErrOperationRequestType, v, field)
+		}
+
+		parts = append(parts, FormatSignatureValue(value))
+	}
+
+	if s.Secret == SecretLast {
+		parts = append(parts, secretKey)
+	}
+
+	return strings.Join(parts, signatureSeparator), nil
+}
+
+// FormatSignatureValue renders a single payload value.
+func FormatSignatureValue(value any) string {
+	if value == nil {
+		return ""
+	}
+
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return ""
+		}
+
+		rv = rv.Elem()
+	}
+
+	//nolint:exhaustive // everything else falls back to fmt
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String()
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool())
+	case reflect.Float32:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 64)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+	default:
+		return fmt.Sprint(rv.Interface())
+	}
+}
+
+type fieldValues struct {
+	fields map[string]any
+	strict bool
+}
+
+func (f fieldValues) lookup(name string) (any, bool) {
+	value, ok := f.fields[name]
+
+	return value, ok
+}
+
+func signingValues(v any) (fieldValues, error) {
+	if v == nil {
+		return fieldValues{fields: nil, strict: false}, ErrNilRequest
+	}
+
+	if m, ok := v.(map[string]any); ok {
+		return fieldValues{fields: m, strict: false}, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return fieldValues{fields: nil, strict: false}, ErrNilRequest
+		}
+
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return fieldValues{fields: nil, strict: false}, fmt.Errorf("%w: unsupported type %T", ErrOperationRequestType, v)
+	}
+
+	fields := make(map[string]any, rv.NumField())
+	rt := rv.Type()
+
+	for i := range rt.NumField() {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		fields[name] = rv.Field(i).Interface()
+	}
+
+	return fieldValues{fields: fields, strict: true}, nil
+}
+
+func responseSignature(data any) string {
+	values, err := signingValues(data)
+	if err != nil {
+		return ""
+	}
+
+	signature, _ := values.lookup(signatureJSONField)
+
+	return FormatSignatureValue(signature)
+}
@@ -0,0 +1,313 @@
+package goaliniex_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/andyle182810/goaliniex"
+	"github.com/andyle182810/goaliniex/signer"
+)
+
+func TestRequestSignatureSpec_Golden(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		op       goaliniex.Operation
+		req      any
+		expected string
+	}{
+		{
+			op: goaliniex.OperationCreateOrder,
+			req: &goaliniex.CreateOrderRequest{
+				Currency:          goaliniex.CurrencyUSDT,
+				FiatAmount:        1000000,
+				FiatCurrency:      goaliniex.FiatCurrencyVND,
+				BankCode:          "TCB",
+				BankAccountNumber: "888812345678",
+				ExternalOrderID:   "ext-001",
+				WebhookSecretKey:  "whsec",
+				UserEmail:         "a@b.c",
+				UserKYCVerified:   false,
+				Content:           "",
+				ExtendInfo:        map[string]any{"ignored": true},
+			},
+			expected: "P|ext-001|USDT|1000000|TCB|888812345678||a@b.c|S",
+		},
+		{
+			op:       goaliniex.OperationGetOrderDetails,
+			req:      &goaliniex.GetOrderDetailsRequest{ExternalOrderID: "ext-001"},
+			expected: "P|ext-001|S",
+		},
+		{
+			op: goaliniex.OperationSubmitKyc,
+			req: &goaliniex.SubmitKycRequest{ //nolint:exhaustruct // only signed fields matter
+				UserEmail:   "a@b.c",
+				Nationality: "PH",
+			},
+			expected: "P|a@b.c|PH|S",
+		},
+		{
+			op:       goaliniex.OperationGetKycInformation,
+			req:      &goaliniex.KycInformationRequest{UserEmail: "a@b.c"},
+			expected: "P|a@b.c|S",
+		},
+		{
+			op:       goaliniex.OperationGetUserKyc,
+			req:      &goaliniex.GetUserKycRequest{UserEmail: "a@b.c"},
+			expected: "P|a@b.c|S",
+		},
+		{
+			op:       goaliniex.OperationGetWalletBalance,
+			req:      &goaliniex.GetWalletBalanceRequest{Currency: goaliniex.CurrencyBTC},
+			expected: "P|BTC|S",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.op), func(t *testing.T) {
+			t.Parallel()
+
+			spec, ok := goaliniex.RequestSignatureSpec(tc.op)
+			if !ok {
+				t.Fatalf("no request spec for %s", tc.op)
+			}
+
+			payload, err := spec.Payload("P", "S", tc.req)
+			if err != nil {
+				t.Fatalf("Payload returned error: %v", err)
+			}
+
+			if payload != tc.expected {
+				t.Errorf("expected payload=%q, got %q", tc.expected, payload)
+			}
+		})
+	}
+}
+
+func TestResponseSignatureSpec_Golden(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		op       goaliniex.Operation
+		data     any
+		expected string
+	}{
+		{
+			name: "create-order",
+			op:   goaliniex.OperationCreateOrder,
+			data: &goaliniex.CreateOrderResponse{ //nolint:exhaustruct // only signed fields matter
+				ExternalOrderID: "ext-001",
+				FiatAmount:      250000.75,
+				Status:          goaliniex.OrderStatusAwaitingPayment,
+			},
+			expected: "P|ext-001|250000.75|AWAITING_PAYMENT|S",
+		},
+		{
+			name: "get-order-details",
+			op:   goaliniex.OperationGetOrderDetails,
+			data: &goaliniex.OrderDetails{ //nolint:exhaustruct // only signed fields matter
+				ExternalOrderID: "ext-001",
+				FiatAmount:      1e21,
+				Status:          goaliniex.OrderStatusSuccess,
+			},
+			expected: "P|ext-001|1000000000000000000000|SUCCESS|S",
+		},
+		{
+			name:     "submit-kyc",
+			op:       goaliniex.OperationSubmitKyc,
+			data:     &goaliniex.SubmitKycResponse{ID: 42, KycStatus: "PROCESSING", Signature: ""},
+			expected: "P|42|PROCESSING|S",
+		},
+		{
+			name:     "get-wallet-balance",
+			op:       goaliniex.OperationGetWalletBalance,
+			data:     &goaliniex.WalletBalance{Balance: 12.5, Currency: goaliniex.CurrencyUSDT, Signature: ""},
+			expected: "P|USDT|12.5|S",
+		},
+		{
+			name:     "decoded map",
+			op:       goaliniex.OperationGetWalletBalance,
+			data:     map[string]any{"balance": 3.0, "currency": "ETH"},
+			expected: "P|ETH|3|S",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec, ok := goaliniex.ResponseSignatureSpec(tc.op)
+			if !ok {
+				t.Fatalf("no response spec for %s", tc.op)
+			}
+
+			payload, err := spec.Payload("P", "S", tc.data)
+			if err != nil {
+				t.Fatalf("Payload returned error: %v", err)
+			}
+
+			if payload != tc.expected {
+				t.Errorf("expected payload=%q, got %q", tc.expected, payload)
+			}
+		})
+	}
+}
+
+func TestSignatureSpec_SecretPlacement(t *testing.T) {
+	t.Parallel()
+
+	req := &goaliniex.GetOrderDetailsRequest{ExternalOrderID: "ext"}
+
+	testCases := []struct {
+		placement goaliniex.SecretPlacement
+		expected  string
+	}{
+		{placement: goaliniex.SecretLast, expected: "P|ext|S"},
+		{placement: goaliniex.SecretFirst, expected: "S|P|ext"},
+		{placement: goaliniex.SecretNone, expected: "P|ext"},
+	}
+
+	for _, tc := range testCases {
+		spec := goaliniex.SignatureSpec{
+			Fields: []string{goaliniex.FieldPartnerCode, "externalOrderId"},
+			Secret: tc.placement,
+		}
+
+		payload, err := spec.Payload("P", "S", req)
+		if err != nil {
+			t.Fatalf("Payload returned error: %v", err)
+		}
+
+		if payload != tc.expected {
+			t.Errorf("expected payload=%q, got %q", tc.expected, payload)
+		}
+	}
+}
+
+func TestFormatSignatureValue(t *testing.T) {
+	t.Parallel()
+
+	var nilString *string
+
+	testCases := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{name: "nil", value: nil, expected: ""},
+		{name: "nil pointer", value: nilString, expected: ""},
+		{name: "float", value: 0.1, expected: "0.1"},
+		{name: "whole float", value: 100.0, expected: "100"},
+		{name: "large float", value: 123456789012.0, expected: "123456789012"},
+		{name: "bool", value: true, expected: "true"},
+		{name: "int", value: -7, expected: "-7"},
+		{name: "enum", value: goaliniex.FiatCurrencyPHP, expected: "PHP"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := goaliniex.FormatSignatureValue(tc.value); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestClient_ResponseSignatureVerification(t *testing.T) {
+	t.Parallel()
+
+	spec, _ := goaliniex.ResponseSignatureSpec(goaliniex.OperationGetWalletBalance)
+
+	payload, err := spec.Payload("TEST_PARTNER", "TEST_SECRET", map[string]any{"balance": 100.5, "currency": "USDT"})
+	if err != nil {
+		t.Fatalf("Payload returned error: %v", err)
+	}
+
+	signature, err := signer.Sign(testPrivateKey(), []byte(payload))
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+
+	testCases := []struct {
+		name    string
+		balance string
+		opts    []goaliniex.Option
+		wantErr bool
+	}{
+		{name: "valid", balance: "100.5", opts: nil, wantErr: false},
+		{name: "tampered", balance: "999", opts: nil, wantErr: true},
+		{
+			name:    "request algorithm override",
+			balance: "100.5",
+			opts: []goaliniex.Option{
+				goaliniex.WithOperationSigningAlgorithm(goaliniex.OperationGetWalletBalance, signer.AlgorithmPSSSHA256),
+			},
+			wantErr: false,
+		},
+		{
+			name:    "response algorithm mismatch",
+			balance: "100.5",
+			opts:    []goaliniex.Option{goaliniex.WithResponseSigningAlgorithm(signer.AlgorithmPSSSHA256)},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			body := `{"success":true,"message":"ok","errorCode":0,"data":{"balance":` + tc.balance +
+				`,"currency":"USDT","signature":"` + signature + `"}}`
+
+			opts := append([]goaliniex.Option{
+				goaliniex.WithHTTPClient(&mockHTTPClient{
+					response: mockResponse(http.StatusOK, body), //nolint:bodyclose // Response body closed by client
+					err:      nil,
+				}),
+				goaliniex.WithResponsePublicKey(testPublicKey(t)),
+			}, tc.opts...)
+
+			client, err := goaliniex.NewClient(
+				"https://sandbox.alixpay.com",
+				"TEST_PARTNER",
+				"TEST_SECRET",
+				testPrivateKey(),
+				opts...,
+			)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			_, err = client.GetWalletBalance(context.Background(), &goaliniex.GetWalletBalanceRequest{
+				Currency: goaliniex.CurrencyUSDT,
+			})
+
+			if tc.wantErr && !errors.Is(err, goaliniex.ErrResponseSignature) {
+				t.Errorf("expected ErrResponseSignature, got %v", err)
+			}
+
+			if !tc.wantErr && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestClient_NilRequestFailsSigning(t *testing.T) {
+	t.Parallel()
+
+	client, err := newTestClientWithMock(&mockHTTPClient{response: nil, err: nil})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = client.GetOrderDetails(context.Background(), nil)
+	if !errors.Is(err, goaliniex.ErrRequestSign) {
+		t.Errorf("expected ErrRequestSign, got %v", err)
+	}
+}
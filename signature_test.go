@@ -0,0 +1,136 @@
+package goaliniex_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/andyle182810/goaliniex"
+)
+
+func TestSignaturePayload_AllOperations(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		op       goaliniex.Operation
+		req      any
+		expected string
+	}{
+		{
+			op: goaliniex.OperationCreateOrder,
+			req: &goaliniex.CreateOrderRequest{
+				Currency:          goaliniex.CurrencyUSDT,
+				FiatAmount:        150000.5,
+				FiatCurrency:      goaliniex.FiatCurrencyVND,
+				BankCode:          "VCB",
+				BankAccountNumber: "0123456789",
+				ExternalOrderID:   "order-1",
+				WebhookSecretKey:  "hook",
+				UserEmail:         "user@example.com",
+				UserKYCVerified:   true,
+				Content:           "payment",
+				ExtendInfo:        nil,
+			},
+			expected: "P|order-1|USDT|150000.5|VCB|0123456789|payment|user@example.com|S",
+		},
+		{
+			op:       goaliniex.OperationGetOrderDetails,
+			req:      &goaliniex.GetOrderDetailsRequest{ExternalOrderID: "order-1"},
+			expected: "P|order-1|S",
+		},
+		{
+			op:       goaliniex.OperationGetKycInformation,
+			req:      &goaliniex.KycInformationRequest{UserEmail: "user@example.com"},
+			expected: "P|user@example.com|S",
+		},
+		{
+			op:       goaliniex.OperationGetUserKyc,
+			req:      &goaliniex.GetUserKycRequest{UserEmail: "user@example.com"},
+			expected: "P|user@example.com|S",
+		},
+		{
+			op:       goaliniex.OperationGetWalletBalance,
+			req:      &goaliniex.GetWalletBalanceRequest{Currency: goaliniex.CurrencyUSDT},
+			expected: "P|USDT|S",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.op), func(t *testing.T) {
+			t.Parallel()
+
+			payload, err := goaliniex.SignaturePayload(tc.op, "P", "S", tc.req)
+			if err != nil {
+				t.Fatalf("SignaturePayload returned error: %v", err)
+			}
+
+			if payload != tc.expected {
+				t.Errorf("expected payload=%q, got %q", tc.expected, payload)
+			}
+		})
+	}
+}
+
+func TestSignaturePayload_SubmitKyc(t *testing.T) {
+	t.Parallel()
+
+	req := &goaliniex.SubmitKycRequest{ //nolint:exhaustruct // only signed fields matter
+		UserEmail:   "user@example.com",
+		Nationality: "VN",
+	}
+
+	payload, err := goaliniex.SignaturePayload(goaliniex.OperationSubmitKyc, "P", "S", req)
+	if err != nil {
+		t.Fatalf("SignaturePayload returned error: %v", err)
+	}
+
+	if payload != "P|user@example.com|VN|S" {
+		t.Errorf("unexpected payload %q", payload)
+	}
+}
+
+func TestSignaturePayload_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := goaliniex.SignaturePayload("unknown", "P", "S", &goaliniex.GetOrderDetailsRequest{ExternalOrderID: "x"})
+	if !errors.Is(err, goaliniex.ErrUnknownOperation) {
+		t.Errorf("expected ErrUnknownOperation, got %v", err)
+	}
+
+	_, err = goaliniex.SignaturePayload(
+		goaliniex.OperationCreateOrder, "P", "S", &goaliniex.GetOrderDetailsRequest{ExternalOrderID: "x"},
+	)
+	if !errors.Is(err, goaliniex.ErrOperationRequestType) {
+		t.Errorf("expected ErrOperationRequestType, got %v", err)
+	}
+
+	_, err = goaliniex.SignaturePayload(goaliniex.OperationCreateOrder, "P", "S", nil)
+	if !errors.Is(err, goaliniex.ErrNilRequest) {
+		t.Errorf("expected ErrNilRequest, got %v", err)
+	}
+}
+
+func TestClient_ExplainSignature_MasksSecret(t *testing.T) {
+	t.Parallel()
+
+	client, err := newTestClientWithMock(&mockHTTPClient{response: nil, err: nil})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	payload, err := client.ExplainSignature(
+		goaliniex.OperationGetOrderDetails,
+		&goaliniex.GetOrderDetailsRequest{ExternalOrderID: "order-1"},
+	)
+	if err != nil {
+		t.Fatalf("ExplainSignature returned error: %v", err)
+	}
+
+	if strings.Contains(payload, "TEST_SECRET") {
+		t.Errorf("payload leaks secret key: %q", payload)
+	}
+
+	if payload != "TEST_PARTNER|order-1|"+goaliniex.SecretMask {
+		t.Errorf("unexpected payload %q", payload)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
+	"crypto/sha512"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/pem"
@@ -17,22 +18,62 @@ var (
 	ErrInvalidPublicPEM  = errors.New("invalid public key PEM")
 	ErrUnsupportedKey    = errors.New("unsupported private key type")
 	ErrUnsupportedPubKey = errors.New("unsupported public key type")
+	ErrUnsupportedAlgo   = errors.New("unsupported signing algorithm")
 )
 
+// Algorithm selects the RSA signature scheme and digest.
+type Algorithm string
+
+const (
+	AlgorithmPKCS1v15SHA256 Algorithm = "PKCS1v15-SHA256"
+	AlgorithmPSSSHA256      Algorithm = "PSS-SHA256"
+	AlgorithmPKCS1v15SHA512 Algorithm = "PKCS1v15-SHA512"
+
+	// DefaultAlgorithm is used by Sign and Verify.
+	DefaultAlgorithm = AlgorithmPKCS1v15SHA256
+)
+
+// ParseAlgorithm validates an algorithm name. An empty name selects
+// DefaultAlgorithm.
+func ParseAlgorithm(name string) (Algorithm, error) {
+	switch alg := Algorithm(name); alg {
+	case "":
+		return DefaultAlgorithm, nil
+	case AlgorithmPKCS1v15SHA256, AlgorithmPSSSHA256, AlgorithmPKCS1v15SHA512:
+		return alg, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedAlgo, name)
+	}
+}
+
 func Sign(privateKeyPEM []byte, payload []byte) (string, error) {
+	return SignWithAlgorithm(DefaultAlgorithm, privateKeyPEM, payload)
+}
+
+func SignWithAlgorithm(alg Algorithm, privateKeyPEM []byte, payload []byte) (string, error) {
+	hash, err := algorithmHash(alg)
+	if err != nil {
+		return "", err
+	}
+
 	rsaPrivateKey, err := parseRSAPrivateKey(privateKeyPEM)
 	if err != nil {
 		return "", err
 	}
 
-	digest := sha256.Sum256(payload)
+	digest := hashPayload(hash, payload)
+
+	var signature []byte
+
+	if alg == AlgorithmPSSSHA256 {
+		signature, err = rsa.SignPSS(rand.Reader, rsaPrivateKey, hash, digest, &rsa.PSSOptions{
+			SaltLength: rsa.PSSSaltLengthEqualsHash,
+			Hash:       hash,
+		})
+	} else {
+		signature, err = rsa.SignPKCS1v15(rand.Reader, rsaPrivateKey, hash, digest)
+	}
 
-	signature, err := rsa.SignPKCS1v15(
-		rand.Reader,
-		rsaPrivateKey,
-		crypto.SHA256,
-		digest[:],
-	)
 	if err != nil {
 		return "", fmt.Errorf("sign payload: %w", err)
 	}
@@ -40,6 +81,29 @@ func Sign(privateKeyPEM []byte, payload []byte) (string, error) {
 	return base64.StdEncoding.EncodeToString(signature), nil
 }
 
+func algorithmHash(alg Algorithm) (crypto.Hash, error) {
+	switch alg {
+	case "", AlgorithmPKCS1v15SHA256, AlgorithmPSSSHA256:
+		return crypto.SHA256, nil
+	case AlgorithmPKCS1v15SHA512:
+		return crypto.SHA512, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnsupportedAlgo, alg)
+	}
+}
+
+func hashPayload(hash crypto.Hash, payload []byte) []byte {
+	if hash == crypto.SHA512 {
+		digest := sha512.Sum512(payload)
+
+		return digest[:]
+	}
+
+	digest := sha256.Sum256(payload)
+
+	return digest[:]
+}
+
 func parseRSAPrivateKey(privateKeyPEM []byte) (*rsa.PrivateKey, error) {
 	block, _ := pem.Decode(privateKeyPEM)
 	if block == nil {
@@ -66,6 +130,17 @@ func parseRSAPrivateKey(privateKeyPEM []byte) (*rsa.PrivateKey, error) {
 }
 
 func Verify(publicKeyPEM []byte, payload []byte, signatureBase64 string) error {
+	return VerifyWithAlgorithm(DefaultAlgorithm, publicKeyPEM, payload, signatureBase64)
+}
+
+// VerifyWithAlgorithm checks a base64 signature produced with alg. PSS
+// signatures are accepted with any salt length.
+func VerifyWithAlgorithm(alg Algorithm, publicKeyPEM []byte, payload []byte, signatureBase64 string) error {
+	hash, err := algorithmHash(alg)
+	if err != nil {
+		return err
+	}
+
 	rsaPublicKey, err := parseRSAPublicKey(publicKeyPEM)
 	if err != nil {
 		return err
@@ -76,9 +151,16 @@ func Verify(publicKeyPEM []byte, payload []byte, signatureBase64 string) error {
 		return fmt.Errorf("decode signature: %w", err)
 	}
 
-	digest := sha256.Sum256(payload)
+	digest := hashPayload(hash, payload)
+
+	if alg == AlgorithmPSSSHA256 {
+		return rsa.VerifyPSS(rsaPublicKey, hash, digest, signature, &rsa.PSSOptions{
+			SaltLength: rsa.PSSSaltLengthAuto,
+			Hash:       hash,
+		})
+	}
 
-	return rsa.VerifyPKCS1v15(rsaPublicKey, crypto.SHA256, digest[:], signature)
+	return rsa.VerifyPKCS1v15(rsaPublicKey, hash, digest, signature)
 }
 
 func parseRSAPublicKey(publicKeyPEM []byte) (*rsa.PublicKey, error) {
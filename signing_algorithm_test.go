@@ -0,0 +1,119 @@
+package goaliniex_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/andyle182810/goaliniex"
+	"github.com/andyle182810/goaliniex/signer"
+)
+
+func TestClient_SigningAlgorithmSelection(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		opts     []goaliniex.Option
+		expected signer.Algorithm
+	}{
+		{
+			name:     "default",
+			opts:     nil,
+			expected: signer.AlgorithmPKCS1v15SHA256,
+		},
+		{
+			name:     "client-wide",
+			opts:     []goaliniex.Option{goaliniex.WithSigningAlgorithm(signer.AlgorithmPKCS1v15SHA512)},
+			expected: signer.AlgorithmPKCS1v15SHA512,
+		},
+		{
+			name: "per-operation overrides client-wide",
+			opts: []goaliniex.Option{
+				goaliniex.WithSigningAlgorithm(signer.AlgorithmPKCS1v15SHA512),
+				goaliniex.WithOperationSigningAlgorithm(goaliniex.OperationGetOrderDetails, signer.AlgorithmPSSSHA256),
+			},
+			expected: signer.AlgorithmPSSSHA256,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := &recordingHTTPClient{
+				response: func() *http.Response {
+					//nolint:bodyclose // Response body closed by client
+					return mockResponse(http.StatusOK, `{"success":true,"message":"ok","data":null,"errorCode":0}`)
+				},
+			}
+
+			opts := append([]goaliniex.Option{goaliniex.WithHTTPClient(recorder)}, tc.opts...)
+
+			client, err := goaliniex.NewClient(
+				"https://sandbox.alixpay.com", "TEST_PARTNER", "TEST_SECRET", testPrivateKey(), opts...,
+			)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			_, err = client.GetOrderDetails(context.Background(), &goaliniex.GetOrderDetailsRequest{
+				ExternalOrderID: "order-1",
+			})
+			if err != nil {
+				t.Fatalf("GetOrderDetails returned error: %v", err)
+			}
+
+			signature, _ := recorder.lastBody(t)["signature"].(string)
+			payload := []byte("TEST_PARTNER|order-1|TEST_SECRET")
+
+			if err := signer.VerifyWithAlgorithm(tc.expected, testPublicKey(t), payload, signature); err != nil {
+				t.Errorf("signature not produced with %s: %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestClient_GetUserKyc_UsesOwnOperationAlgorithm(t *testing.T) {
+	t.Parallel()
+
+	recorder := &recordingHTTPClient{
+		response: func() *http.Response {
+			//nolint:bodyclose // Response body closed by client
+			return mockResponse(http.StatusOK, `{"success":true,"message":"ok","data":null,"errorCode":0}`)
+		},
+	}
+
+	client, err := goaliniex.NewClient(
+		"https://sandbox.alixpay.com", "TEST_PARTNER", "TEST_SECRET", testPrivateKey(),
+		goaliniex.WithHTTPClient(recorder),
+		goaliniex.WithOperationSigningAlgorithm(goaliniex.OperationGetUserKyc, signer.AlgorithmPSSSHA256),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := client.GetUserKyc(context.Background(), &goaliniex.GetUserKycRequest{UserEmail: "a@b.c"}); err != nil {
+		t.Fatalf("GetUserKyc returned error: %v", err)
+	}
+
+	signature, _ := recorder.lastBody(t)["signature"].(string)
+	payload := []byte("TEST_PARTNER|a@b.c|TEST_SECRET")
+
+	if err := signer.VerifyWithAlgorithm(signer.AlgorithmPSSSHA256, testPublicKey(t), payload, signature); err != nil {
+		t.Errorf("signature not produced with %s: %v", signer.AlgorithmPSSSHA256, err)
+	}
+}
+
+func TestNewClient_RejectsUnknownAlgorithm(t *testing.T) {
+	t.Parallel()
+
+	_, err := goaliniex.NewClient(
+		"https://sandbox.alixpay.com", "TEST_PARTNER", "TEST_SECRET", testPrivateKey(),
+		goaliniex.WithOperationSigningAlgorithm(goaliniex.OperationCreateOrder, "RSA-MD5"),
+	)
+	if !errors.Is(err, signer.ErrUnsupportedAlgo) {
+		t.Errorf("expected ErrUnsupportedAlgo, got %v", err)
+	}
+}
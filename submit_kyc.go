@@ -2,8 +2,6 @@ package goaliniex
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"net/http"
 )
 
@@ -50,20 +48,17 @@ type SubmitKycResponse struct {
 	Signature string `json:"signature"`
 }
 
-func (c *Client) SubmitKyc(ctx context.Context, req *SubmitKycRequest) (*Response[SubmitKycResponse], error) {
-	signaturePayload := fmt.Sprintf(
-		"%s|%s|%s|%s",
-		c.partnerCode,
-		req.UserEmail,
-		req.Nationality,
-		c.secretKey,
-	)
+func SubmitKycSignaturePayload(partnerCode, secretKey string, req *SubmitKycRequest) (string, error) {
+	return SignaturePayload(OperationSubmitKyc, partnerCode, secretKey, req)
+}
 
+func (c *Client) SubmitKyc(ctx context.Context, req *SubmitKycRequest) (*Response[SubmitKycResponse], error) {
 	apiRequest := request{
 		Method:      http.MethodPost,
 		Endpoint:    "/api/v2/user/submit-kyc",
 		Params:      req,
-		SigningData: []byte(signaturePayload),
+		Operation:   OperationSubmitKyc,
+		SigningData: nil,
 		Header:      nil,
 		Body:        nil,
 		FullURL:     "",
@@ -75,8 +70,12 @@ func (c *Client) SubmitKyc(ctx context.Context, req *SubmitKycRequest) (*Respons
 		return nil, err
 	}
 
-	response := new(Response[SubmitKycResponse])
-	if err := json.Unmarshal(rawResponse, response); err != nil {
+	response, err := decodeResponse[SubmitKycResponse](c, apiRequest.Endpoint, rawResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifyResponse(c, OperationSubmitKyc, response); err != nil {
 		return nil, err
 	}
 
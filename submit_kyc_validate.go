@@ -0,0 +1,233 @@
+package goaliniex
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// KycDateLayout is the date format Aliniex expects for KYC dates.
+	KycDateLayout = "2006-01-02"
+
+	// MinKycAge is the minimum age, in years, of a user submitting KYC.
+	MinKycAge = 18
+
+	maxDialCodeDigits = 4
+)
+
+var (
+	ErrInvalidKycRequest   = errors.New("invalid kyc request")
+	ErrMissingField        = errors.New("field is required")
+	ErrInvalidEmail        = errors.New("invalid email address")
+	ErrInvalidDate         = errors.New("invalid date")
+	ErrInvalidDateOrder    = errors.New("dates are out of order")
+	ErrDocumentExpired     = errors.New("document has expired")
+	ErrUnderage            = errors.New("user is below minimum age")
+	ErrInvalidNationality  = errors.New("invalid nationality")
+	ErrInvalidDocumentType = errors.New("invalid document type")
+	ErrInvalidPhone        = errors.New("invalid phone number")
+)
+
+// KycFieldError reports a validation failure for a single request field,
+// identified by its JSON name.
+type KycFieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *KycFieldError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", ErrInvalidKycRequest, e.Field, e.Err)
+}
+
+func (e *KycFieldError) Unwrap() []error {
+	return []error{ErrInvalidKycRequest, e.Err}
+}
+
+func fieldError(field string, err error, format string, args ...any) error {
+	if format == "" {
+		return &KycFieldError{Field: field, Err: err}
+	}
+
+	return &KycFieldError{Field: field, Err: fmt.Errorf("%w: "+format, append([]any{err}, args...)...)}
+}
+
+// Validate checks the request against Aliniex's KYC rules as of now. All
+// failures are returned joined; each is a *KycFieldError wrapping
+// ErrInvalidKycRequest and a specific cause such as ErrDocumentExpired.
+func (r *SubmitKycRequest) Validate() error {
+	return r.ValidateAt(time.Now())
+}
+
+// ValidateAt is Validate with an explicit reference time.
+func (r *SubmitKycRequest) ValidateAt(now time.Time) error {
+	if r == nil {
+		return ErrNilRequest
+	}
+
+	var errs []error
+
+	errs = append(errs, r.validateRequired()...)
+	errs = append(errs, r.validateDates(now)...)
+	errs = append(errs, r.validateDocument()...)
+	errs = append(errs, r.validatePhone()...)
+
+	return errors.Join(errs...)
+}
+
+func (r *SubmitKycRequest) validateRequired() []error {
+	required := []struct {
+		field string
+		value string
+	}{
+		{"userEmail", r.UserEmail},
+		{"firstName", r.FirstName},
+		{"lastName", r.LastName},
+		{"nationalId", r.NationalID},
+		{"frontIdImage", r.FrontIDImage},
+		{"holdIdImage", r.HoldIDImage},
+	}
+
+	var errs []error
+
+	for _, item := range required {
+		if strings.TrimSpace(item.value) == "" {
+			errs = append(errs, fieldError(item.field, ErrMissingField, ""))
+		}
+	}
+
+	if r.UserEmail != "" && !strings.Contains(r.UserEmail, "@") {
+		errs = append(errs, fieldError("userEmail", ErrInvalidEmail, "%q", r.UserEmail))
+	}
+
+	return errs
+}
+
+func parseKycDate(field, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fieldError(field, ErrMissingField, "")
+	}
+
+	date, err := time.Parse(KycDateLayout, value)
+	if err != nil {
+		return time.Time{}, fieldError(field, ErrInvalidDate, "%q is not in %s format", value, KycDateLayout)
+	}
+
+	return date, nil
+}
+
+func (r *SubmitKycRequest) validateDates(now time.Time) []error {
+	var errs []error
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	dob, dobErr := parseKycDate("dateOfBirth", r.DateOfBirth)
+	issued, issueErr := parseKycDate("issueDate", r.IssueDate)
+	expires, expiryErr := parseKycDate("expiryDate", r.ExpiryDate)
+
+	for _, err := range []error{dobErr, issueErr, expiryErr} {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if dobErr == nil {
+		if !dob.Before(today) {
+			errs = append(errs, fieldError("dateOfBirth", ErrInvalidDateOrder, "%s is not in the past", r.DateOfBirth))
+		} else if dob.AddDate(MinKycAge, 0, 0).After(today) {
+			errs = append(errs, fieldError("dateOfBirth", ErrUnderage, "must be at least %d years old", MinKycAge))
+		}
+	}
+
+	if issueErr == nil {
+		if issued.After(today) {
+			errs = append(errs, fieldError("issueDate", ErrInvalidDateOrder, "%s is in the future", r.IssueDate))
+		}
+
+		if dobErr == nil && issued.Before(dob) {
+			errs = append(errs, fieldError("issueDate", ErrInvalidDateOrder, "%s is before date of birth", r.IssueDate))
+		}
+	}
+
+	if expiryErr == nil {
+		if issueErr == nil && !issued.Before(expires) {
+			errs = append(errs, fieldError("expiryDate", ErrInvalidDateOrder, "%s is not after issue date", r.ExpiryDate))
+		}
+
+		if expires.Before(today) {
+			errs = append(errs, fieldError("expiryDate", ErrDocumentExpired, "expired on %s", r.ExpiryDate))
+		}
+	}
+
+	return errs
+}
+
+func (r *SubmitKycRequest) validateDocument() []error {
+	var errs []error
+
+	if !isKnownCountry(r.Nationality) {
+		errs = append(errs, fieldError("nationality", ErrInvalidNationality, "%q is not an ISO 3166 country code", r.Nationality))
+	}
+
+	switch r.DocumentType {
+	case IDTypeIDCard:
+		if strings.TrimSpace(r.BackIDImage) == "" {
+			errs = append(errs, fieldError("backIdImage", ErrMissingField, "required for %s", IDTypeIDCard))
+		}
+	case IDTypePassport:
+	default:
+		errs = append(errs, fieldError("type", ErrInvalidDocumentType, "%q, want %s or %s",
+			r.DocumentType, IDTypeIDCard, IDTypePassport))
+	}
+
+	return errs
+}
+
+// isKnownCountry accepts alpha-2 and alpha-3 codes of registry countries.
+func isKnownCountry(code string) bool {
+	_, ok := LookupCountry(code)
+
+	return ok && !isDigits(strings.TrimSpace(code))
+}
+
+func (r *SubmitKycRequest) validatePhone() []error {
+	if r.PhoneNumber == "" && r.PhoneCountryCode == "" {
+		return nil
+	}
+
+	if r.PhoneNumber == "" {
+		return []error{fieldError("phoneNumber", ErrMissingField, "required with phoneCountryCode")}
+	}
+
+	dialCode := strings.TrimPrefix(strings.TrimSpace(r.PhoneCountryCode), "+")
+	if dialCode == "" {
+		return []error{fieldError("phoneCountryCode", ErrMissingField, "required with phoneNumber")}
+	}
+
+	if !isDigits(dialCode) || len(dialCode) > maxDialCodeDigits || matchDialCode(dialCode) == "" {
+		return []error{fieldError("phoneCountryCode", ErrInvalidPhone, "%q is not a dialing code", r.PhoneCountryCode)}
+	}
+
+	nationality, _ := LookupCountry(r.Nationality)
+
+	if _, err := parsePhone(r.PhoneNumber, dialCode, nationality); err != nil {
+		return []error{fieldError("phoneNumber", err, "")}
+	}
+
+	return nil
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,243 @@
+package goaliniex_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andyle182810/goaliniex"
+)
+
+var validationNow = time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+
+func validKycRequest() *goaliniex.SubmitKycRequest {
+	return &goaliniex.SubmitKycRequest{
+		UserEmail:        "jane@example.com",
+		FirstName:        "Jane",
+		LastName:         "Smith",
+		DateOfBirth:      "1985-05-15",
+		Gender:           goaliniex.GenderFemale,
+		Nationality:      "VN",
+		DocumentType:     goaliniex.IDTypeIDCard,
+		NationalID:       "987654321",
+		IssueDate:        "2019-06-01",
+		ExpiryDate:       "2029-06-01",
+		AddressLine1:     "456 Oak Ave",
+		AddressLine2:     "",
+		City:             "Ho Chi Minh",
+		State:            "HCM",
+		ZipCode:          "70000",
+		FrontIDImage:     getTestImageDataURI(),
+		BackIDImage:      getTestImageDataURI(),
+		HoldIDImage:      getTestImageDataURI(),
+		PhoneNumber:      "0987 654 321",
+		PhoneCountryCode: "+84",
+	}
+}
+
+func TestSubmitKycRequest_ValidateAt_Valid(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		modify func(r *goaliniex.SubmitKycRequest)
+	}{
+		{name: "id card", modify: func(_ *goaliniex.SubmitKycRequest) {}},
+		{
+			name: "passport without back image",
+			modify: func(r *goaliniex.SubmitKycRequest) {
+				r.DocumentType = goaliniex.IDTypePassport
+				r.BackIDImage = ""
+			},
+		},
+		{name: "alpha-3 nationality", modify: func(r *goaliniex.SubmitKycRequest) { r.Nationality = "VNM" }},
+		{name: "no phone", modify: func(r *goaliniex.SubmitKycRequest) { r.PhoneNumber, r.PhoneCountryCode = "", "" }},
+		{name: "international phone", modify: func(r *goaliniex.SubmitKycRequest) { r.PhoneNumber = "+84 987 654 321" }},
+		{name: "phone with parentheses", modify: func(r *goaliniex.SubmitKycRequest) { r.PhoneNumber = "(098) 765-4321" }},
+		{
+			name: "foreign phone",
+			modify: func(r *goaliniex.SubmitKycRequest) {
+				r.PhoneNumber = "1234567890"
+				r.PhoneCountryCode = "1"
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validKycRequest()
+			tc.modify(req)
+
+			if err := req.ValidateAt(validationNow); err != nil {
+				t.Errorf("expected valid request, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSubmitKycRequest_ValidateAt_Invalid(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		modify   func(r *goaliniex.SubmitKycRequest)
+		field    string
+		expected error
+	}{
+		{
+			name:     "missing email",
+			modify:   func(r *goaliniex.SubmitKycRequest) { r.UserEmail = "" },
+			field:    "userEmail",
+			expected: goaliniex.ErrMissingField,
+		},
+		{
+			name:     "malformed email",
+			modify:   func(r *goaliniex.SubmitKycRequest) { r.UserEmail = "jane" },
+			field:    "userEmail",
+			expected: goaliniex.ErrInvalidEmail,
+		},
+		{
+			name:     "bad date format",
+			modify:   func(r *goaliniex.SubmitKycRequest) { r.DateOfBirth = "15/05/1985" },
+			field:    "dateOfBirth",
+			expected: goaliniex.ErrInvalidDate,
+		},
+		{
+			name:     "date of birth in future",
+			modify:   func(r *goaliniex.SubmitKycRequest) { r.DateOfBirth = "2027-01-01" },
+			field:    "dateOfBirth",
+			expected: goaliniex.ErrInvalidDateOrder,
+		},
+		{
+			name:     "underage",
+			modify:   func(r *goaliniex.SubmitKycRequest) { r.DateOfBirth = "2008-10-19" },
+			field:    "dateOfBirth",
+			expected: goaliniex.ErrUnderage,
+		},
+		{
+			name: "issue after expiry",
+			modify: func(r *goaliniex.SubmitKycRequest) {
+				r.IssueDate = "2025-01-01"
+				r.ExpiryDate = "2024-01-01"
+			},
+			field:    "expiryDate",
+			expected: goaliniex.ErrInvalidDateOrder,
+		},
+		{
+			name:     "issue before birth",
+			modify:   func(r *goaliniex.SubmitKycRequest) { r.IssueDate = "1980-01-01" },
+			field:    "issueDate",
+			expected: goaliniex.ErrInvalidDateOrder,
+		},
+		{
+			name:     "expired",
+			modify:   func(r *goaliniex.SubmitKycRequest) { r.ExpiryDate = "2026-10-17" },
+			field:    "expiryDate",
+			expected: goaliniex.ErrDocumentExpired,
+		},
+		{
+			name:     "unknown nationality",
+			modify:   func(r *goaliniex.SubmitKycRequest) { r.Nationality = "XYZ" },
+			field:    "nationality",
+			expected: goaliniex.ErrInvalidNationality,
+		},
+		{
+			name:     "unknown document type",
+			modify:   func(r *goaliniex.SubmitKycRequest) { r.DocumentType = "DRIVER_LICENSE" },
+			field:    "type",
+			expected: goaliniex.ErrInvalidDocumentType,
+		},
+		{
+			name:     "id card without back image",
+			modify:   func(r *goaliniex.SubmitKycRequest) { r.BackIDImage = "" },
+			field:    "backIdImage",
+			expected: goaliniex.ErrMissingField,
+		},
+		{
+			name:     "phone without country code",
+			modify:   func(r *goaliniex.SubmitKycRequest) { r.PhoneCountryCode = "" },
+			field:    "phoneCountryCode",
+			expected: goaliniex.ErrMissingField,
+		},
+		{
+			name:     "phone with other country prefix",
+			modify:   func(r *goaliniex.SubmitKycRequest) { r.PhoneNumber = "+44 7911 123456" },
+			field:    "phoneNumber",
+			expected: goaliniex.ErrInvalidPhone,
+		},
+		{
+			name:     "phone too short for country",
+			modify:   func(r *goaliniex.SubmitKycRequest) { r.PhoneNumber = "0987 654" },
+			field:    "phoneNumber",
+			expected: goaliniex.ErrInvalidPhone,
+		},
+		{
+			name:     "unknown dialing code",
+			modify:   func(r *goaliniex.SubmitKycRequest) { r.PhoneCountryCode = "+999" },
+			field:    "phoneCountryCode",
+			expected: goaliniex.ErrInvalidPhone,
+		},
+		{
+			name:     "phone with letters",
+			modify:   func(r *goaliniex.SubmitKycRequest) { r.PhoneNumber = "09876ABCDE" },
+			field:    "phoneNumber",
+			expected: goaliniex.ErrInvalidPhone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validKycRequest()
+			tc.modify(req)
+
+			err := req.ValidateAt(validationNow)
+			if !errors.Is(err, goaliniex.ErrInvalidKycRequest) {
+				t.Fatalf("expected ErrInvalidKycRequest, got %v", err)
+			}
+
+			if !errors.Is(err, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, err)
+			}
+
+			var fieldErr *goaliniex.KycFieldError
+			if !errors.As(err, &fieldErr) || fieldErr.Field != tc.field {
+				t.Errorf("expected field error for %q, got %v", tc.field, err)
+			}
+		})
+	}
+}
+
+func TestSubmitKycRequest_ValidateAt_ReportsAllErrors(t *testing.T) {
+	t.Parallel()
+
+	req := validKycRequest()
+	req.FirstName = ""
+	req.ExpiryDate = "2020-01-01"
+	req.DocumentType = "VISA"
+
+	err := req.ValidateAt(validationNow)
+
+	for _, expected := range []error{
+		goaliniex.ErrMissingField,
+		goaliniex.ErrDocumentExpired,
+		goaliniex.ErrInvalidDocumentType,
+	} {
+		if !errors.Is(err, expected) {
+			t.Errorf("expected %v in %v", expected, err)
+		}
+	}
+}
+
+func TestSubmitKycRequest_Validate_Nil(t *testing.T) {
+	t.Parallel()
+
+	var req *goaliniex.SubmitKycRequest
+
+	if err := req.Validate(); !errors.Is(err, goaliniex.ErrNilRequest) {
+		t.Errorf("expected ErrNilRequest, got %v", err)
+	}
+}
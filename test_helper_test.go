@@ -3,17 +3,24 @@ package goaliniex_test
 import (
 	"bytes"
 	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
 	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/andyle182810/goaliniex"
+	"github.com/andyle182810/goaliniex/aliniextest"
 )
 
 type mockHTTPClient struct {
@@ -25,6 +32,51 @@ func (m *mockHTTPClient) Do(_ *http.Request) (*http.Response, error) {
 	return m.response, m.err
 }
 
+type recordingHTTPClient struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+	response func() *http.Response
+}
+
+func (r *recordingHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	var body []byte
+
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		body = data
+	}
+
+	r.mu.Lock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	r.mu.Unlock()
+
+	return r.response(), nil
+}
+
+func (r *recordingHTTPClient) lastBody(t *testing.T) map[string]any {
+	t.Helper()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.bodies) == 0 {
+		t.Fatal("no request recorded")
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal(r.bodies[len(r.bodies)-1], &body); err != nil {
+		t.Fatalf("decode recorded body: %v", err)
+	}
+
+	return body
+}
+
 func mockResponse(statusCode int, body string) *http.Response {
 	return &http.Response{
 		Status:           http.StatusText(statusCode),
@@ -75,19 +127,137 @@ UpCbzjvpmE2CMpZTOBnfwSE=
 -----END PRIVATE KEY-----`)
 }
 
-func newTestClientWithMock(httpClient goaliniex.HTTPClient) (*goaliniex.Client, error) {
+func testPublicKey(t *testing.T) []byte {
+	t.Helper()
+
+	block, _ := pem.Decode(testPrivateKey())
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		t.Fatalf("parse test private key: %v", err)
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		t.Fatal("test private key is not RSA")
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	if err != nil {
+		t.Fatalf("marshal test public key: %v", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Headers: nil, Bytes: der})
+}
+
+const sandboxURL = "https://sandbox.alixpay.com"
+
+func newTestClientWithMock(httpClient goaliniex.HTTPClient, opts ...goaliniex.Option) (*goaliniex.Client, error) {
 	return goaliniex.NewClient(
-		"https://sandbox.alixpay.com",
+		sandboxURL,
 		"TEST_PARTNER",
 		"TEST_SECRET",
 		testPrivateKey(),
-		goaliniex.WithHTTPClient(httpClient),
+		append([]goaliniex.Option{goaliniex.WithHTTPClient(httpClient)}, opts...)...,
 	)
 }
 
+// cassettePath is where the integration test owning t records its
+// exchanges. Subtests share their top-level test's cassette.
+func cassettePath(t *testing.T) string {
+	t.Helper()
+
+	name, _, _ := strings.Cut(t.Name(), "/")
+
+	return filepath.Join("testdata", "cassettes", name+".json")
+}
+
+// Placeholder inputs for offline runs. Cassettes scrub these fields, so
+// any value matches the recorded requests.
+const (
+	replayEmail             = "replay@example.com"
+	replayEmail2            = "replay2@example.com"
+	replayBankCode          = "970436"
+	replayBankAccountNumber = "0123456789"
+	replayWebhookSecretKey  = "replay-webhook-secret"
+)
+
+// recordingFake reports whether cassettes are recorded against the
+// aliniextest server (ALIX_RECORD=fake) instead of the sandbox.
+func recordingFake() bool {
+	return os.Getenv("ALIX_RECORD") == "fake"
+}
+
+// replaying reports whether t runs against a recorded cassette: one exists
+// and ALIX_RECORD is not set.
+func replaying(t *testing.T) bool {
+	t.Helper()
+
+	if os.Getenv("ALIX_RECORD") != "" {
+		return false
+	}
+
+	_, err := os.Stat(cassettePath(t))
+
+	return err == nil
+}
+
+// offline reports whether t runs without the sandbox, so its inputs come
+// from the placeholders above rather than the environment.
+func offline(t *testing.T) bool {
+	t.Helper()
+
+	return replaying(t) || recordingFake()
+}
+
+// integrationRecorder opens t's cassette with the scrubbing shared by
+// recording and replay.
+func integrationRecorder(t *testing.T, mode aliniextest.Mode, source string, secrets []string) *aliniextest.Recorder {
+	t.Helper()
+
+	recorder, err := aliniextest.NewRecorder(cassettePath(t), &aliniextest.RecorderOptions{
+		Mode:         mode,
+		HTTPClient:   nil,
+		Redact:       []string{"bankCode"},
+		Secrets:      secrets,
+		IgnoreFields: []string{"externalOrderId"},
+		Source:       source,
+	})
+	if err != nil {
+		t.Fatalf("failed to open cassette: %v", err)
+	}
+
+	if mode == aliniextest.ModeRecord {
+		t.Cleanup(func() {
+			if err := recorder.Stop(); err != nil {
+				t.Errorf("failed to write cassette: %v", err)
+			}
+		})
+	}
+
+	return recorder
+}
+
+// newTestClient returns a client for integration tests. It replays the
+// test's cassette when there is one, and otherwise talks to the sandbox,
+// recording to the cassette when ALIX_RECORD is set. ALIX_RECORD=fake
+// records against the aliniextest server instead.
 func newTestClient(t *testing.T) *goaliniex.Client {
 	t.Helper()
 
+	if replaying(t) {
+		client, err := newTestClientWithMock(integrationRecorder(t, aliniextest.ModeReplay, "", nil))
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		return client
+	}
+
+	if recordingFake() {
+		return newFakeRecordingClient(t)
+	}
+
 	privateKey, err := os.ReadFile("./alix-private-key.pem")
 	if err != nil {
 		t.Skipf("skipping test: unable to read private key: %v", err)
@@ -109,13 +279,22 @@ func newTestClient(t *testing.T) *goaliniex.Client {
 		ReplaceAttr: nil,
 	}))
 
+	opts := []goaliniex.Option{
+		goaliniex.WithDebug(true),
+		goaliniex.WithLogger(logger),
+	}
+
+	if os.Getenv("ALIX_RECORD") != "" {
+		recorder := integrationRecorder(t, aliniextest.ModeRecord, sandboxURL, []string{partnerCode, secretKey})
+		opts = append(opts, goaliniex.WithHTTPClient(recorder))
+	}
+
 	client, err := goaliniex.NewClient(
-		"https://sandbox.alixpay.com",
+		sandboxURL,
 		partnerCode,
 		secretKey,
 		privateKey,
-		goaliniex.WithDebug(true),
-		goaliniex.WithLogger(logger),
+		opts...,
 	)
 	if err != nil {
 		t.Fatalf("failed to create client: %v", err)
@@ -127,6 +306,10 @@ func newTestClient(t *testing.T) *goaliniex.Client {
 func getTestEmail(t *testing.T) string {
 	t.Helper()
 
+	if offline(t) {
+		return replayEmail
+	}
+
 	email := strings.TrimSpace(os.Getenv("ALIX_TEST_EMAIL"))
 	if email == "" {
 		t.Skip("skipping test: ALIX_TEST_EMAIL not set")
@@ -135,6 +318,18 @@ func getTestEmail(t *testing.T) string {
 	return email
 }
 
+// testOrderID returns a unique external order ID, or a fixed one offline so
+// responses replayed from a cassette carry the ID the test sent.
+func testOrderID(t *testing.T, prefix string) string {
+	t.Helper()
+
+	if offline(t) {
+		return prefix + "replay"
+	}
+
+	return prefix + time.Now().Format("20060102150405")
+}
+
 func generateRandomGmail(t *testing.T) string {
 	t.Helper()
 
@@ -175,6 +370,10 @@ func generateTestSSN(t *testing.T) string {
 func getTestEmail2(t *testing.T) string {
 	t.Helper()
 
+	if offline(t) {
+		return replayEmail2
+	}
+
 	email := strings.TrimSpace(os.Getenv("ALIX_TEST_EMAIL_2"))
 	if email == "" {
 		t.Skip("skipping test: ALIX_TEST_EMAIL_2 not set")
@@ -186,6 +385,10 @@ func getTestEmail2(t *testing.T) string {
 func getTestEmails(t *testing.T) []string {
 	t.Helper()
 
+	if offline(t) {
+		return []string{replayEmail, replayEmail2}
+	}
+
 	email1 := strings.TrimSpace(os.Getenv("ALIX_TEST_EMAIL"))
 	if email1 == "" {
 		t.Skip("skipping test: ALIX_TEST_EMAIL not set")
@@ -204,6 +407,10 @@ func getTestEmails(t *testing.T) []string {
 func getTestBankCode(t *testing.T) string {
 	t.Helper()
 
+	if offline(t) {
+		return replayBankCode
+	}
+
 	bankCode := strings.TrimSpace(os.Getenv("ALIX_TEST_BANK_CODE"))
 	if bankCode == "" {
 		t.Skip("skipping test: ALIX_TEST_BANK_CODE not set")
@@ -215,6 +422,10 @@ func getTestBankCode(t *testing.T) string {
 func getTestBankAccountNumber(t *testing.T) string {
 	t.Helper()
 
+	if offline(t) {
+		return replayBankAccountNumber
+	}
+
 	bankAccountNumber := strings.TrimSpace(os.Getenv("ALIX_TEST_BANK_ACCOUNT_NUMBER"))
 	if bankAccountNumber == "" {
 		t.Skip("skipping test: ALIX_TEST_BANK_ACCOUNT_NUMBER not set")
@@ -222,3 +433,52 @@ func getTestBankAccountNumber(t *testing.T) string {
 
 	return bankAccountNumber
 }
+
+// newFakeServer starts an aliniextest server and returns a client for it,
+// signing with the shared test key.
+func newFakeServer(t *testing.T, opts ...goaliniex.Option) (*aliniextest.Server, *goaliniex.Client) {
+	t.Helper()
+
+	server, err := aliniextest.NewServer(aliniextest.Config{
+		PartnerCode: "TEST_PARTNER",
+		SecretKey:   "TEST_SECRET",
+		PublicKey:   testPublicKey(t),
+		Algorithm:   "",
+		ResponseKey: nil,
+		Balances:    map[goaliniex.Currency]float64{goaliniex.CurrencyUSDT: 1000},
+		OrderTTL:    0,
+		Now:         nil,
+	})
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+
+	t.Cleanup(server.Close)
+
+	client, err := server.NewClient(testPrivateKey(), opts...)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	return server, client
+}
+
+// newFakeRecordingClient records t's cassette against an aliniextest server
+// whose users are the placeholder emails, verified.
+func newFakeRecordingClient(t *testing.T) *goaliniex.Client {
+	t.Helper()
+
+	recorder := integrationRecorder(t, aliniextest.ModeRecord, aliniextest.SourceFakeServer,
+		[]string{"TEST_PARTNER", "TEST_SECRET"})
+	server, client := newFakeServer(t, goaliniex.WithHTTPClient(recorder))
+
+	for _, email := range []string{replayEmail, replayEmail2} {
+		server.SetKyc(email, goaliniex.Kyc{ //nolint:exhaustruct // placeholder user
+			FirstName: "Replay",
+			LastName:  "User",
+			KycStatus: goaliniex.KycStatusVerified,
+		})
+	}
+
+	return client
+}
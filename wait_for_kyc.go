@@ -0,0 +1,189 @@
+package goaliniex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultKycPollInitialInterval = 2 * time.Second
+	defaultKycPollMaxInterval     = 30 * time.Second
+	defaultKycPollMultiplier      = 2.0
+	defaultKycPollMaxErrors       = 3
+	defaultKycPollMaxUnknown      = 10
+)
+
+var (
+	ErrKycRejected      = errors.New("kyc rejected")
+	ErrKycLookupError   = errors.New("kyc lookup failed")
+	ErrKycNotSubmitted  = errors.New("kyc not submitted")
+	ErrKycUnknownStatus = errors.New("unknown kyc status")
+)
+
+// KycRejectedError is returned by WaitForKyc when the KYC review ends in
+// KycStatusRejected. It matches ErrKycRejected with errors.Is.
+type KycRejectedError struct {
+	Reason string
+	Kyc    *Kyc
+}
+
+func (e *KycRejectedError) Error() string {
+	if e.Reason == "" {
+		return ErrKycRejected.Error()
+	}
+
+	return fmt.Sprintf("%s: %s", ErrKycRejected, e.Reason)
+}
+
+func (e *KycRejectedError) Unwrap() error {
+	return ErrKycRejected
+}
+
+type WaitForKycOptions struct {
+	// InitialInterval is the delay before the second poll. Defaults to 2s.
+	InitialInterval time.Duration
+	// MaxInterval caps the delay between polls. Defaults to 30s.
+	MaxInterval time.Duration
+	// Multiplier grows the delay after each poll. Defaults to 2.
+	Multiplier float64
+	// MaxConsecutiveErrors is how many failed lookups in a row are tolerated
+	// before giving up. Defaults to 3; a negative value fails on the first.
+	MaxConsecutiveErrors int
+	// MaxUnknownStatusPolls is how many polls in a row may return a status
+	// WaitForKyc does not know before it fails with ErrKycUnknownStatus.
+	// Defaults to 10.
+	MaxUnknownStatusPolls int
+	// OnStatusChange is called whenever the observed status differs from
+	// the previous poll, including the first poll where previous is empty.
+	OnStatusChange func(previous, current KycStatus, kyc *Kyc)
+}
+
+func (o *WaitForKycOptions) withDefaults() WaitForKycOptions {
+	opts := WaitForKycOptions{
+		InitialInterval:       defaultKycPollInitialInterval,
+		MaxInterval:           defaultKycPollMaxInterval,
+		Multiplier:            defaultKycPollMultiplier,
+		MaxConsecutiveErrors:  defaultKycPollMaxErrors,
+		MaxUnknownStatusPolls: defaultKycPollMaxUnknown,
+		OnStatusChange:        nil,
+	}
+
+	if o == nil {
+		return opts
+	}
+
+	if o.InitialInterval > 0 {
+		opts.InitialInterval = o.InitialInterval
+	}
+
+	if o.MaxInterval > 0 {
+		opts.MaxInterval = o.MaxInterval
+	}
+
+	if o.Multiplier >= 1 {
+		opts.Multiplier = o.Multiplier
+	}
+
+	if o.MaxConsecutiveErrors != 0 {
+		opts.MaxConsecutiveErrors = max(o.MaxConsecutiveErrors, 0)
+	}
+
+	if o.MaxUnknownStatusPolls > 0 {
+		opts.MaxUnknownStatusPolls = o.MaxUnknownStatusPolls
+	}
+
+	opts.OnStatusChange = o.OnStatusChange
+
+	return opts
+}
+
+// WaitForKyc polls the user's KYC information with exponential backoff while
+// it is KycStatusProcessing, until it is KycStatusVerified, returning the
+// record, or KycStatusRejected, returning a *KycRejectedError. KycStatusNone
+// fails at once with ErrKycNotSubmitted, since no review is pending. It stops
+// early when ctx is done.
+func (c *Client) WaitForKyc(ctx context.Context, email string, opts *WaitForKycOptions) (*Kyc, error) {
+	settings := opts.withDefaults()
+	interval := min(settings.InitialInterval, settings.MaxInterval)
+
+	var (
+		previous KycStatus
+		failures int
+		unknown  int
+	)
+
+	for {
+		kyc, err := c.lookupKyc(ctx, email)
+
+		switch {
+		case err != nil:
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			failures++
+			if failures > settings.MaxConsecutiveErrors {
+				return nil, err
+			}
+
+			c.logDebug("kyc poll failed", "email", email, "error", err)
+		default:
+			failures = 0
+
+			if kyc.KycStatus != previous && settings.OnStatusChange != nil {
+				settings.OnStatusChange(previous, kyc.KycStatus, kyc)
+			}
+
+			previous = kyc.KycStatus
+
+			switch kyc.KycStatus {
+			case KycStatusVerified:
+				return kyc, nil
+			case KycStatusRejected:
+				return kyc, &KycRejectedError{Reason: kyc.RejectReason, Kyc: kyc}
+			case KycStatusNone:
+				return kyc, fmt.Errorf("%w: %s", ErrKycNotSubmitted, email)
+			case KycStatusProcessing:
+				unknown = 0
+			default:
+				unknown++
+				if unknown >= settings.MaxUnknownStatusPolls {
+					return kyc, fmt.Errorf("%w: %q", ErrKycUnknownStatus, kyc.KycStatus)
+				}
+			}
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return nil, err
+		}
+
+		interval = min(time.Duration(float64(interval)*settings.Multiplier), settings.MaxInterval)
+	}
+}
+
+func (c *Client) lookupKyc(ctx context.Context, email string) (*Kyc, error) {
+	response, err := c.GetKyc(ctx, &KycInformationRequest{UserEmail: email})
+	if err != nil {
+		return nil, err
+	}
+
+	if !response.Success || response.Data == nil {
+		return nil, fmt.Errorf("%w: %s (errorCode=%d)", ErrKycLookupError, response.Message, response.ErrorCode)
+	}
+
+	return response.Data, nil
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,287 @@
+package goaliniex_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andyle182810/goaliniex"
+)
+
+func kycStatusBody(status goaliniex.KycStatus, reason string) string {
+	return `{"success":true,"message":"Success","errorCode":0,"data":{"firstName":"Jane","kycStatus":"` +
+		string(status) + `","rejectReason":"` + reason + `"}}`
+}
+
+// sequenceResponses replays bodies in order, repeating the last one.
+func sequenceResponses(responses ...*http.Response) func() *http.Response {
+	var (
+		mu    sync.Mutex
+		index int
+	)
+
+	return func() *http.Response {
+		mu.Lock()
+		defer mu.Unlock()
+
+		resp := responses[min(index, len(responses)-1)]
+		index++
+
+		return resp
+	}
+}
+
+func fastKycPolling() *goaliniex.WaitForKycOptions {
+	return &goaliniex.WaitForKycOptions{
+		InitialInterval:       time.Millisecond,
+		MaxInterval:           2 * time.Millisecond,
+		Multiplier:            2,
+		MaxConsecutiveErrors:  0,
+		MaxUnknownStatusPolls: 0,
+		OnStatusChange:        nil,
+	}
+}
+
+//nolint:bodyclose // Response bodies closed by client
+func TestClient_WaitForKyc_Verified(t *testing.T) {
+	t.Parallel()
+
+	recorder := &recordingHTTPClient{
+		response: sequenceResponses(
+			mockResponse(http.StatusOK, kycStatusBody(goaliniex.KycStatusProcessing, "")),
+			mockResponse(http.StatusOK, kycStatusBody("IN_REVIEW", "")),
+			mockResponse(http.StatusOK, kycStatusBody(goaliniex.KycStatusProcessing, "")),
+			mockResponse(http.StatusOK, kycStatusBody(goaliniex.KycStatusVerified, "")),
+		),
+	}
+
+	client, err := newTestClientWithMock(recorder)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	var transitions []string
+
+	opts := fastKycPolling()
+	opts.OnStatusChange = func(previous, current goaliniex.KycStatus, _ *goaliniex.Kyc) {
+		transitions = append(transitions, string(previous)+"->"+string(current))
+	}
+
+	kyc, err := client.WaitForKyc(context.Background(), "jane@example.com", opts)
+	if err != nil {
+		t.Fatalf("WaitForKyc returned error: %v", err)
+	}
+
+	if !kyc.IsVerified() {
+		t.Errorf("expected verified KYC, got %s", kyc.KycStatus)
+	}
+
+	expected := []string{"->PROCESSING", "PROCESSING->IN_REVIEW", "IN_REVIEW->PROCESSING", "PROCESSING->VERIFIED"}
+	if len(transitions) != len(expected) {
+		t.Fatalf("expected transitions %v, got %v", expected, transitions)
+	}
+
+	for i := range expected {
+		if transitions[i] != expected[i] {
+			t.Errorf("transition %d: expected %s, got %s", i, expected[i], transitions[i])
+		}
+	}
+
+	if len(recorder.requests) != 4 {
+		t.Errorf("expected 4 polls, got %d", len(recorder.requests))
+	}
+}
+
+//nolint:bodyclose // Response bodies closed by client
+func TestClient_WaitForKyc_Rejected(t *testing.T) {
+	t.Parallel()
+
+	client, err := newTestClientWithMock(&recordingHTTPClient{
+		response: sequenceResponses(
+			mockResponse(http.StatusOK, kycStatusBody(goaliniex.KycStatusProcessing, "")),
+			mockResponse(http.StatusOK, kycStatusBody(goaliniex.KycStatusRejected, "Document is blurry")),
+		),
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	kyc, err := client.WaitForKyc(context.Background(), "jane@example.com", fastKycPolling())
+	if !errors.Is(err, goaliniex.ErrKycRejected) {
+		t.Fatalf("expected ErrKycRejected, got %v", err)
+	}
+
+	var rejected *goaliniex.KycRejectedError
+	if !errors.As(err, &rejected) || rejected.Reason != "Document is blurry" {
+		t.Errorf("expected rejection reason, got %v", err)
+	}
+
+	if kyc == nil || kyc.KycStatus != goaliniex.KycStatusRejected {
+		t.Errorf("expected rejected KYC record, got %+v", kyc)
+	}
+}
+
+//nolint:bodyclose // Response bodies closed by client
+func TestClient_WaitForKyc_ToleratesTransientErrors(t *testing.T) {
+	t.Parallel()
+
+	client, err := newTestClientWithMock(&recordingHTTPClient{
+		response: sequenceResponses(
+			mockResponse(http.StatusBadGateway, "bad gateway"),
+			mockResponse(http.StatusOK, kycStatusBody(goaliniex.KycStatusVerified, "")),
+		),
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	opts := fastKycPolling()
+	opts.MaxConsecutiveErrors = 1
+
+	if _, err := client.WaitForKyc(context.Background(), "jane@example.com", opts); err != nil {
+		t.Fatalf("WaitForKyc returned error: %v", err)
+	}
+}
+
+//nolint:bodyclose // Response bodies closed by client
+func TestClient_WaitForKyc_Errors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		body     string
+		status   int
+		expected error
+	}{
+		{
+			name:     "api failure",
+			body:     `{"success":false,"message":"User not found","data":null,"errorCode":404}`,
+			status:   http.StatusOK,
+			expected: goaliniex.ErrKycLookupError,
+		},
+		{
+			name:     "http failure",
+			body:     "unavailable",
+			status:   http.StatusServiceUnavailable,
+			expected: goaliniex.ErrUnexpectedStatus,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, err := newTestClientWithMock(&recordingHTTPClient{
+				response: func() *http.Response { return mockResponse(tc.status, tc.body) },
+			})
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			opts := fastKycPolling()
+			opts.MaxConsecutiveErrors = -1
+
+			_, err = client.WaitForKyc(context.Background(), "jane@example.com", opts)
+			if !errors.Is(err, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+//nolint:bodyclose // Response bodies closed by client
+func TestClient_WaitForKyc_StopsPolling(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		status   goaliniex.KycStatus
+		polls    int
+		expected error
+	}{
+		{name: "not submitted", status: goaliniex.KycStatusNone, polls: 1, expected: goaliniex.ErrKycNotSubmitted},
+		{name: "unknown status", status: "ON_HOLD", polls: 3, expected: goaliniex.ErrKycUnknownStatus},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := &recordingHTTPClient{
+				response: func() *http.Response { return mockResponse(http.StatusOK, kycStatusBody(tc.status, "")) },
+			}
+
+			client, err := newTestClientWithMock(recorder)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			opts := fastKycPolling()
+			opts.MaxUnknownStatusPolls = 3
+
+			kyc, err := client.WaitForKyc(context.Background(), "jane@example.com", opts)
+			if !errors.Is(err, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, err)
+			}
+
+			if kyc == nil || kyc.KycStatus != tc.status {
+				t.Errorf("expected the last KYC record, got %+v", kyc)
+			}
+
+			if len(recorder.requests) != tc.polls {
+				t.Errorf("expected %d polls, got %d", tc.polls, len(recorder.requests))
+			}
+		})
+	}
+}
+
+//nolint:bodyclose // Response bodies closed by client
+func TestClient_WaitForKyc_FirstDelayCappedByMaxInterval(t *testing.T) {
+	t.Parallel()
+
+	client, err := newTestClientWithMock(&recordingHTTPClient{
+		response: sequenceResponses(
+			mockResponse(http.StatusOK, kycStatusBody(goaliniex.KycStatusProcessing, "")),
+			mockResponse(http.StatusOK, kycStatusBody(goaliniex.KycStatusVerified, "")),
+		),
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	opts := fastKycPolling()
+	opts.InitialInterval = time.Hour
+	opts.MaxInterval = time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := client.WaitForKyc(ctx, "jane@example.com", opts); err != nil {
+		t.Fatalf("WaitForKyc returned error: %v", err)
+	}
+}
+
+//nolint:bodyclose // Response bodies closed by client
+func TestClient_WaitForKyc_ContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	client, err := newTestClientWithMock(&recordingHTTPClient{
+		response: func() *http.Response {
+			return mockResponse(http.StatusOK, kycStatusBody(goaliniex.KycStatusProcessing, ""))
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err = client.WaitForKyc(ctx, "jane@example.com", fastKycPolling())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}